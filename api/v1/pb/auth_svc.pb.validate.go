@@ -675,3 +675,1112 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = RegisterResponseValidationError{}
+
+// Validate checks the field values on RequestLoginCodeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestLoginCodeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestLoginCodeRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RequestLoginCodeRequestMultiError, or nil if none found.
+func (m *RequestLoginCodeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestLoginCodeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofDestinationPresent := false
+	switch v := m.Destination.(type) {
+	case *RequestLoginCodeRequest_Email:
+		if v == nil {
+			err := RequestLoginCodeRequestValidationError{
+				field:  "Destination",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofDestinationPresent = true
+
+		if err := m._validateEmail(m.GetEmail()); err != nil {
+			err = RequestLoginCodeRequestValidationError{
+				field:  "Email",
+				reason: "value must be a valid email address",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	case *RequestLoginCodeRequest_PhoneNumber:
+		if v == nil {
+			err := RequestLoginCodeRequestValidationError{
+				field:  "Destination",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofDestinationPresent = true
+
+		if utf8.RuneCountInString(m.GetPhoneNumber()) != 12 {
+			err := RequestLoginCodeRequestValidationError{
+				field:  "PhoneNumber",
+				reason: "value length must be 12 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofDestinationPresent {
+		err := RequestLoginCodeRequestValidationError{
+			field:  "Destination",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RequestLoginCodeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *RequestLoginCodeRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *RequestLoginCodeRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// RequestLoginCodeRequestMultiError is an error wrapping multiple validation
+// errors returned by RequestLoginCodeRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RequestLoginCodeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestLoginCodeRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestLoginCodeRequestMultiError) AllErrors() []error { return m }
+
+// RequestLoginCodeRequestValidationError is the validation error returned by
+// RequestLoginCodeRequest.Validate if the designated constraints aren't met.
+type RequestLoginCodeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestLoginCodeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestLoginCodeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestLoginCodeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestLoginCodeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestLoginCodeRequestValidationError) ErrorName() string {
+	return "RequestLoginCodeRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequestLoginCodeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestLoginCodeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestLoginCodeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestLoginCodeRequestValidationError{}
+
+// Validate checks the field values on RequestLoginCodeResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestLoginCodeResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestLoginCodeResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RequestLoginCodeResponseMultiError, or nil if none found.
+func (m *RequestLoginCodeResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestLoginCodeResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RequestLoginCodeResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RequestLoginCodeResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RequestLoginCodeResponseValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RequestLoginCodeResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RequestLoginCodeResponseMultiError is an error wrapping multiple validation
+// errors returned by RequestLoginCodeResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RequestLoginCodeResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestLoginCodeResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestLoginCodeResponseMultiError) AllErrors() []error { return m }
+
+// RequestLoginCodeResponseValidationError is the validation error returned by
+// RequestLoginCodeResponse.Validate if the designated constraints aren't met.
+type RequestLoginCodeResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestLoginCodeResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestLoginCodeResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestLoginCodeResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestLoginCodeResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestLoginCodeResponseValidationError) ErrorName() string {
+	return "RequestLoginCodeResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequestLoginCodeResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestLoginCodeResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestLoginCodeResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestLoginCodeResponseValidationError{}
+
+// Validate checks the field values on LoginWithCodeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *LoginWithCodeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoginWithCodeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// LoginWithCodeRequestMultiError, or nil if none found.
+func (m *LoginWithCodeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoginWithCodeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetCode()); l < 6 || l > 64 {
+		err := LoginWithCodeRequestValidationError{
+			field:  "Code",
+			reason: "value length must be between 6 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	oneofDestinationPresent := false
+	switch v := m.Destination.(type) {
+	case *LoginWithCodeRequest_Email:
+		if v == nil {
+			err := LoginWithCodeRequestValidationError{
+				field:  "Destination",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofDestinationPresent = true
+
+		if err := m._validateEmail(m.GetEmail()); err != nil {
+			err = LoginWithCodeRequestValidationError{
+				field:  "Email",
+				reason: "value must be a valid email address",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	case *LoginWithCodeRequest_PhoneNumber:
+		if v == nil {
+			err := LoginWithCodeRequestValidationError{
+				field:  "Destination",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofDestinationPresent = true
+
+		if utf8.RuneCountInString(m.GetPhoneNumber()) != 12 {
+			err := LoginWithCodeRequestValidationError{
+				field:  "PhoneNumber",
+				reason: "value length must be 12 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofDestinationPresent {
+		err := LoginWithCodeRequestValidationError{
+			field:  "Destination",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return LoginWithCodeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *LoginWithCodeRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *LoginWithCodeRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// LoginWithCodeRequestMultiError is an error wrapping multiple validation
+// errors returned by LoginWithCodeRequest.ValidateAll() if the designated
+// constraints aren't met.
+type LoginWithCodeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoginWithCodeRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoginWithCodeRequestMultiError) AllErrors() []error { return m }
+
+// LoginWithCodeRequestValidationError is the validation error returned by
+// LoginWithCodeRequest.Validate if the designated constraints aren't met.
+type LoginWithCodeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoginWithCodeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoginWithCodeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoginWithCodeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoginWithCodeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoginWithCodeRequestValidationError) ErrorName() string {
+	return "LoginWithCodeRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e LoginWithCodeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoginWithCodeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoginWithCodeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoginWithCodeRequestValidationError{}
+
+// Validate checks the field values on RequestVerificationCodeRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestVerificationCodeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestVerificationCodeRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RequestVerificationCodeRequestMultiError, or nil if none found.
+func (m *RequestVerificationCodeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestVerificationCodeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _RequestVerificationCodeRequest_Channel_NotInLookup[m.GetChannel()]; ok {
+		err := RequestVerificationCodeRequestValidationError{
+			field:  "Channel",
+			reason: "value must not be in list [CONTACT_CHANNEL_UNKNOWN]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := ContactChannel_name[int32(m.GetChannel())]; !ok {
+		err := RequestVerificationCodeRequestValidationError{
+			field:  "Channel",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RequestVerificationCodeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RequestVerificationCodeRequestMultiError is an error wrapping multiple
+// validation errors returned by RequestVerificationCodeRequest.ValidateAll()
+// if the designated constraints aren't met.
+type RequestVerificationCodeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestVerificationCodeRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestVerificationCodeRequestMultiError) AllErrors() []error { return m }
+
+// RequestVerificationCodeRequestValidationError is the validation error
+// returned by RequestVerificationCodeRequest.Validate if the designated
+// constraints aren't met.
+type RequestVerificationCodeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestVerificationCodeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestVerificationCodeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestVerificationCodeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestVerificationCodeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestVerificationCodeRequestValidationError) ErrorName() string {
+	return "RequestVerificationCodeRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequestVerificationCodeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestVerificationCodeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestVerificationCodeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestVerificationCodeRequestValidationError{}
+
+var _RequestVerificationCodeRequest_Channel_NotInLookup = map[ContactChannel]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on RequestVerificationCodeResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestVerificationCodeResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestVerificationCodeResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RequestVerificationCodeResponseMultiError, or nil if none found.
+func (m *RequestVerificationCodeResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestVerificationCodeResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RequestVerificationCodeResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RequestVerificationCodeResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RequestVerificationCodeResponseValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RequestVerificationCodeResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RequestVerificationCodeResponseMultiError is an error wrapping multiple
+// validation errors returned by RequestVerificationCodeResponse.ValidateAll()
+// if the designated constraints aren't met.
+type RequestVerificationCodeResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestVerificationCodeResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestVerificationCodeResponseMultiError) AllErrors() []error { return m }
+
+// RequestVerificationCodeResponseValidationError is the validation error
+// returned by RequestVerificationCodeResponse.Validate if the designated
+// constraints aren't met.
+type RequestVerificationCodeResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestVerificationCodeResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestVerificationCodeResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestVerificationCodeResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestVerificationCodeResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestVerificationCodeResponseValidationError) ErrorName() string {
+	return "RequestVerificationCodeResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequestVerificationCodeResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestVerificationCodeResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestVerificationCodeResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestVerificationCodeResponseValidationError{}
+
+// Validate checks the field values on VerifyContactRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyContactRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyContactRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerifyContactRequestMultiError, or nil if none found.
+func (m *VerifyContactRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyContactRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _VerifyContactRequest_Channel_NotInLookup[m.GetChannel()]; ok {
+		err := VerifyContactRequestValidationError{
+			field:  "Channel",
+			reason: "value must not be in list [CONTACT_CHANNEL_UNKNOWN]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := ContactChannel_name[int32(m.GetChannel())]; !ok {
+		err := VerifyContactRequestValidationError{
+			field:  "Channel",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetCode()); l < 6 || l > 64 {
+		err := VerifyContactRequestValidationError{
+			field:  "Code",
+			reason: "value length must be between 6 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return VerifyContactRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyContactRequestMultiError is an error wrapping multiple validation
+// errors returned by VerifyContactRequest.ValidateAll() if the designated
+// constraints aren't met.
+type VerifyContactRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyContactRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyContactRequestMultiError) AllErrors() []error { return m }
+
+// VerifyContactRequestValidationError is the validation error returned by
+// VerifyContactRequest.Validate if the designated constraints aren't met.
+type VerifyContactRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyContactRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyContactRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyContactRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyContactRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyContactRequestValidationError) ErrorName() string {
+	return "VerifyContactRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyContactRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyContactRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyContactRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyContactRequestValidationError{}
+
+var _VerifyContactRequest_Channel_NotInLookup = map[ContactChannel]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on VerifyContactResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyContactResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyContactResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerifyContactResponseMultiError, or nil if none found.
+func (m *VerifyContactResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyContactResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, VerifyContactResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, VerifyContactResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return VerifyContactResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return VerifyContactResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyContactResponseMultiError is an error wrapping multiple validation
+// errors returned by VerifyContactResponse.ValidateAll() if the designated
+// constraints aren't met.
+type VerifyContactResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyContactResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyContactResponseMultiError) AllErrors() []error { return m }
+
+// VerifyContactResponseValidationError is the validation error returned by
+// VerifyContactResponse.Validate if the designated constraints aren't met.
+type VerifyContactResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyContactResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyContactResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyContactResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyContactResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyContactResponseValidationError) ErrorName() string {
+	return "VerifyContactResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyContactResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyContactResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyContactResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyContactResponseValidationError{}
@@ -0,0 +1,504 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.24.4
+// source: kyc.proto
+
+package pb
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type KYCDocument_Type int32
+
+const (
+	KYCDocument_UNKNOWN             KYCDocument_Type = 0
+	KYCDocument_NATIONAL_ID_FRONT   KYCDocument_Type = 1
+	KYCDocument_NATIONAL_ID_BACK    KYCDocument_Type = 2
+	KYCDocument_KRA_PIN_CERTIFICATE KYCDocument_Type = 3
+	KYCDocument_PASSPORT            KYCDocument_Type = 4
+	KYCDocument_SELFIE              KYCDocument_Type = 5
+)
+
+// Enum value maps for KYCDocument_Type.
+var (
+	KYCDocument_Type_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "NATIONAL_ID_FRONT",
+		2: "NATIONAL_ID_BACK",
+		3: "KRA_PIN_CERTIFICATE",
+		4: "PASSPORT",
+		5: "SELFIE",
+	}
+	KYCDocument_Type_value = map[string]int32{
+		"UNKNOWN":             0,
+		"NATIONAL_ID_FRONT":   1,
+		"NATIONAL_ID_BACK":    2,
+		"KRA_PIN_CERTIFICATE": 3,
+		"PASSPORT":            4,
+		"SELFIE":              5,
+	}
+)
+
+func (x KYCDocument_Type) Enum() *KYCDocument_Type {
+	p := new(KYCDocument_Type)
+	*p = x
+	return p
+}
+
+func (x KYCDocument_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KYCDocument_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_kyc_proto_enumTypes[0].Descriptor()
+}
+
+func (KYCDocument_Type) Type() protoreflect.EnumType {
+	return &file_kyc_proto_enumTypes[0]
+}
+
+func (x KYCDocument_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KYCDocument_Type.Descriptor instead.
+func (KYCDocument_Type) EnumDescriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type KYCSubmission_Status int32
+
+const (
+	KYCSubmission_UNKNOWN      KYCSubmission_Status = 0
+	KYCSubmission_SUBMITTED    KYCSubmission_Status = 1
+	KYCSubmission_UNDER_REVIEW KYCSubmission_Status = 2
+	KYCSubmission_APPROVED     KYCSubmission_Status = 3
+	KYCSubmission_REJECTED     KYCSubmission_Status = 4
+)
+
+// Enum value maps for KYCSubmission_Status.
+var (
+	KYCSubmission_Status_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "SUBMITTED",
+		2: "UNDER_REVIEW",
+		3: "APPROVED",
+		4: "REJECTED",
+	}
+	KYCSubmission_Status_value = map[string]int32{
+		"UNKNOWN":      0,
+		"SUBMITTED":    1,
+		"UNDER_REVIEW": 2,
+		"APPROVED":     3,
+		"REJECTED":     4,
+	}
+)
+
+func (x KYCSubmission_Status) Enum() *KYCSubmission_Status {
+	p := new(KYCSubmission_Status)
+	*p = x
+	return p
+}
+
+func (x KYCSubmission_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KYCSubmission_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_kyc_proto_enumTypes[1].Descriptor()
+}
+
+func (KYCSubmission_Status) Type() protoreflect.EnumType {
+	return &file_kyc_proto_enumTypes[1]
+}
+
+func (x KYCSubmission_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KYCSubmission_Status.Descriptor instead.
+func (KYCSubmission_Status) EnumDescriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type KYCDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        KYCDocument_Type `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.KYCDocument_Type" json:"type,omitempty"`
+	FileUrl     string           `protobuf:"bytes,2,opt,name=file_url,proto3" json:"file_url,omitempty"`
+	ContentType string           `protobuf:"bytes,3,opt,name=content_type,proto3" json:"content_type,omitempty"`
+	SizeBytes   int64            `protobuf:"varint,4,opt,name=size_bytes,proto3" json:"size_bytes,omitempty"`
+	Sha256      string           `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+}
+
+func (x *KYCDocument) Reset() {
+	*x = KYCDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KYCDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KYCDocument) ProtoMessage() {}
+
+func (x *KYCDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KYCDocument.ProtoReflect.Descriptor instead.
+func (*KYCDocument) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KYCDocument) GetType() KYCDocument_Type {
+	if x != nil {
+		return x.Type
+	}
+	return KYCDocument_UNKNOWN
+}
+
+func (x *KYCDocument) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
+	}
+	return ""
+}
+
+func (x *KYCDocument) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *KYCDocument) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *KYCDocument) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type KYCSubmission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID          string                 `protobuf:"bytes,1,opt,name=ID,json=id,proto3" json:"ID,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,proto3" json:"user_id,omitempty"`
+	IdNumber    string                 `protobuf:"bytes,3,opt,name=id_number,proto3" json:"id_number,omitempty"`
+	KraPin      string                 `protobuf:"bytes,4,opt,name=kra_pin,proto3" json:"kra_pin,omitempty"`
+	Documents   []*KYCDocument         `protobuf:"bytes,5,rep,name=documents,proto3" json:"documents,omitempty"`
+	Status      KYCSubmission_Status   `protobuf:"varint,6,opt,name=status,proto3,enum=api.v1.KYCSubmission_Status" json:"status,omitempty"`
+	ReviewerId  string                 `protobuf:"bytes,7,opt,name=reviewer_id,proto3" json:"reviewer_id,omitempty"`
+	ReviewNotes string                 `protobuf:"bytes,8,opt,name=review_notes,proto3" json:"review_notes,omitempty"`
+	SubmittedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=submitted_at,proto3" json:"submitted_at,omitempty"`
+	ReviewedAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=reviewed_at,proto3" json:"reviewed_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *KYCSubmission) Reset() {
+	*x = KYCSubmission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KYCSubmission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KYCSubmission) ProtoMessage() {}
+
+func (x *KYCSubmission) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KYCSubmission.ProtoReflect.Descriptor instead.
+func (*KYCSubmission) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KYCSubmission) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *KYCSubmission) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *KYCSubmission) GetIdNumber() string {
+	if x != nil {
+		return x.IdNumber
+	}
+	return ""
+}
+
+func (x *KYCSubmission) GetKraPin() string {
+	if x != nil {
+		return x.KraPin
+	}
+	return ""
+}
+
+func (x *KYCSubmission) GetDocuments() []*KYCDocument {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *KYCSubmission) GetStatus() KYCSubmission_Status {
+	if x != nil {
+		return x.Status
+	}
+	return KYCSubmission_UNKNOWN
+}
+
+func (x *KYCSubmission) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *KYCSubmission) GetReviewNotes() string {
+	if x != nil {
+		return x.ReviewNotes
+	}
+	return ""
+}
+
+func (x *KYCSubmission) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+func (x *KYCSubmission) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *KYCSubmission) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_kyc_proto protoreflect.FileDescriptor
+
+var file_kyc_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x6b, 0x79, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x72,
+	0x65, 0x64, 0x61, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x96, 0x03, 0x0a, 0x0b,
+	0x4b, 0x59, 0x43, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x4b, 0x59, 0x43, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x54,
+	0x79, 0x70, 0x65, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0x82, 0x01, 0x04, 0x10, 0x01, 0x20, 0x00, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x75, 0x72,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0c, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x88, 0x01,
+	0x01, 0xa0, 0xbb, 0x18, 0x03, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x12,
+	0x51, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2d, 0xfa, 0x42, 0x2a, 0x72, 0x28, 0x52, 0x0a, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x2f, 0x6a, 0x70, 0x65, 0x67, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x2f,
+	0x70, 0x6e, 0x67, 0x52, 0x0f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2f, 0x70, 0x64, 0x66, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x2c, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0c, 0xfa, 0x42, 0x09, 0x22, 0x07, 0x18, 0x80, 0x80,
+	0x80, 0x05, 0x20, 0x00, 0x52, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x2d, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x15, 0xfa, 0x42, 0x12, 0x72, 0x10, 0x32, 0x0e, 0x5e, 0x5b, 0x61, 0x2d, 0x66, 0x30, 0x2d,
+	0x39, 0x5d, 0x7b, 0x36, 0x34, 0x7d, 0x24, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x22,
+	0x73, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x4c,
+	0x5f, 0x49, 0x44, 0x5f, 0x46, 0x52, 0x4f, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x4e,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x4c, 0x5f, 0x49, 0x44, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x10,
+	0x02, 0x12, 0x17, 0x0a, 0x13, 0x4b, 0x52, 0x41, 0x5f, 0x50, 0x49, 0x4e, 0x5f, 0x43, 0x45, 0x52,
+	0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x45, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x41,
+	0x53, 0x53, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x45, 0x4c, 0x46,
+	0x49, 0x45, 0x10, 0x05, 0x22, 0xba, 0x04, 0x0a, 0x0d, 0x4b, 0x59, 0x43, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x12, 0x22, 0x0a, 0x09, 0x69, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x04, 0xa0, 0xbb, 0x18, 0x01, 0x52, 0x09, 0x69, 0x64, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x07, 0x6b, 0x72, 0x61, 0x5f, 0x70, 0x69, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x04, 0xa0, 0xbb, 0x18, 0x01, 0x52, 0x07, 0x6b, 0x72, 0x61,
+	0x5f, 0x70, 0x69, 0x6e, 0x12, 0x31, 0x0a, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4b, 0x59, 0x43, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4b, 0x59, 0x43, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x12,
+	0x22, 0x0a, 0x0c, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6e, 0x6f,
+	0x74, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x22, 0x52, 0x0a,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x42, 0x4d, 0x49, 0x54, 0x54, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x4e, 0x44, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x56,
+	0x49, 0x45, 0x57, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x50, 0x50, 0x52, 0x4f, 0x56, 0x45,
+	0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10,
+	0x04, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_kyc_proto_rawDescOnce sync.Once
+	file_kyc_proto_rawDescData = file_kyc_proto_rawDesc
+)
+
+func file_kyc_proto_rawDescGZIP() []byte {
+	file_kyc_proto_rawDescOnce.Do(func() {
+		file_kyc_proto_rawDescData = protoimpl.X.CompressGZIP(file_kyc_proto_rawDescData)
+	})
+	return file_kyc_proto_rawDescData
+}
+
+var file_kyc_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_kyc_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_kyc_proto_goTypes = []interface{}{
+	(KYCDocument_Type)(0),         // 0: api.v1.KYCDocument.Type
+	(KYCSubmission_Status)(0),     // 1: api.v1.KYCSubmission.Status
+	(*KYCDocument)(nil),           // 2: api.v1.KYCDocument
+	(*KYCSubmission)(nil),         // 3: api.v1.KYCSubmission
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_kyc_proto_depIdxs = []int32{
+	0, // 0: api.v1.KYCDocument.type:type_name -> api.v1.KYCDocument.Type
+	2, // 1: api.v1.KYCSubmission.documents:type_name -> api.v1.KYCDocument
+	1, // 2: api.v1.KYCSubmission.status:type_name -> api.v1.KYCSubmission.Status
+	4, // 3: api.v1.KYCSubmission.submitted_at:type_name -> google.protobuf.Timestamp
+	4, // 4: api.v1.KYCSubmission.reviewed_at:type_name -> google.protobuf.Timestamp
+	4, // 5: api.v1.KYCSubmission.updated_at:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_kyc_proto_init() }
+func file_kyc_proto_init() {
+	if File_kyc_proto != nil {
+		return
+	}
+	file_redact_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_kyc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KYCDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kyc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KYCSubmission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_kyc_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_kyc_proto_goTypes,
+		DependencyIndexes: file_kyc_proto_depIdxs,
+		EnumInfos:         file_kyc_proto_enumTypes,
+		MessageInfos:      file_kyc_proto_msgTypes,
+	}.Build()
+	File_kyc_proto = out.File
+	file_kyc_proto_rawDesc = nil
+	file_kyc_proto_goTypes = nil
+	file_kyc_proto_depIdxs = nil
+}
@@ -0,0 +1,458 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: kyc.proto
+
+package pb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on KYCDocument with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *KYCDocument) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KYCDocument with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in KYCDocumentMultiError, or
+// nil if none found.
+func (m *KYCDocument) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KYCDocument) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _KYCDocument_Type_NotInLookup[m.GetType()]; ok {
+		err := KYCDocumentValidationError{
+			field:  "Type",
+			reason: "value must not be in list [UNKNOWN]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := KYCDocument_Type_name[int32(m.GetType())]; !ok {
+		err := KYCDocumentValidationError{
+			field:  "Type",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if uri, err := url.Parse(m.GetFileUrl()); err != nil {
+		err = KYCDocumentValidationError{
+			field:  "FileUrl",
+			reason: "value must be a valid URI",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	} else if !uri.IsAbs() {
+		err := KYCDocumentValidationError{
+			field:  "FileUrl",
+			reason: "value must be absolute",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _KYCDocument_ContentType_InLookup[m.GetContentType()]; !ok {
+		err := KYCDocumentValidationError{
+			field:  "ContentType",
+			reason: "value must be in list [image/jpeg image/png application/pdf]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetSizeBytes(); val <= 0 || val > 10485760 {
+		err := KYCDocumentValidationError{
+			field:  "SizeBytes",
+			reason: "value must be inside range (0, 10485760]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_KYCDocument_Sha256_Pattern.MatchString(m.GetSha256()) {
+		err := KYCDocumentValidationError{
+			field:  "Sha256",
+			reason: "value does not match regex pattern \"^[a-f0-9]{64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return KYCDocumentMultiError(errors)
+	}
+
+	return nil
+}
+
+// KYCDocumentMultiError is an error wrapping multiple validation errors
+// returned by KYCDocument.ValidateAll() if the designated constraints aren't met.
+type KYCDocumentMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KYCDocumentMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KYCDocumentMultiError) AllErrors() []error { return m }
+
+// KYCDocumentValidationError is the validation error returned by
+// KYCDocument.Validate if the designated constraints aren't met.
+type KYCDocumentValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KYCDocumentValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KYCDocumentValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KYCDocumentValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KYCDocumentValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KYCDocumentValidationError) ErrorName() string { return "KYCDocumentValidationError" }
+
+// Error satisfies the builtin error interface
+func (e KYCDocumentValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKYCDocument.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KYCDocumentValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KYCDocumentValidationError{}
+
+var _KYCDocument_Type_NotInLookup = map[KYCDocument_Type]struct{}{
+	0: {},
+}
+
+var _KYCDocument_ContentType_InLookup = map[string]struct{}{
+	"image/jpeg":      {},
+	"image/png":       {},
+	"application/pdf": {},
+}
+
+var _KYCDocument_Sha256_Pattern = regexp.MustCompile("^[a-f0-9]{64}$")
+
+// Validate checks the field values on KYCSubmission with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *KYCSubmission) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KYCSubmission with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in KYCSubmissionMultiError, or
+// nil if none found.
+func (m *KYCSubmission) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KYCSubmission) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ID
+
+	// no validation rules for UserId
+
+	// no validation rules for IdNumber
+
+	// no validation rules for KraPin
+
+	for idx, item := range m.GetDocuments() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, KYCSubmissionValidationError{
+						field:  fmt.Sprintf("Documents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, KYCSubmissionValidationError{
+						field:  fmt.Sprintf("Documents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return KYCSubmissionValidationError{
+					field:  fmt.Sprintf("Documents[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Status
+
+	// no validation rules for ReviewerId
+
+	// no validation rules for ReviewNotes
+
+	if all {
+		switch v := interface{}(m.GetSubmittedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KYCSubmissionValidationError{
+					field:  "SubmittedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KYCSubmissionValidationError{
+					field:  "SubmittedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSubmittedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KYCSubmissionValidationError{
+				field:  "SubmittedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetReviewedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KYCSubmissionValidationError{
+					field:  "ReviewedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KYCSubmissionValidationError{
+					field:  "ReviewedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReviewedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KYCSubmissionValidationError{
+				field:  "ReviewedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KYCSubmissionValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KYCSubmissionValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KYCSubmissionValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return KYCSubmissionMultiError(errors)
+	}
+
+	return nil
+}
+
+// KYCSubmissionMultiError is an error wrapping multiple validation errors
+// returned by KYCSubmission.ValidateAll() if the designated constraints
+// aren't met.
+type KYCSubmissionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KYCSubmissionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KYCSubmissionMultiError) AllErrors() []error { return m }
+
+// KYCSubmissionValidationError is the validation error returned by
+// KYCSubmission.Validate if the designated constraints aren't met.
+type KYCSubmissionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KYCSubmissionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KYCSubmissionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KYCSubmissionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KYCSubmissionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KYCSubmissionValidationError) ErrorName() string { return "KYCSubmissionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e KYCSubmissionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKYCSubmission.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KYCSubmissionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KYCSubmissionValidationError{}
@@ -0,0 +1,673 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.24.4
+// source: kyc_svc.proto
+
+package pb
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubmitKYCRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IdNumber  string         `protobuf:"bytes,1,opt,name=id_number,proto3" json:"id_number,omitempty"`
+	KraPin    string         `protobuf:"bytes,2,opt,name=kra_pin,proto3" json:"kra_pin,omitempty"`
+	Documents []*KYCDocument `protobuf:"bytes,3,rep,name=documents,proto3" json:"documents,omitempty"`
+}
+
+func (x *SubmitKYCRequest) Reset() {
+	*x = SubmitKYCRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_svc_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitKYCRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitKYCRequest) ProtoMessage() {}
+
+func (x *SubmitKYCRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_svc_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitKYCRequest.ProtoReflect.Descriptor instead.
+func (*SubmitKYCRequest) Descriptor() ([]byte, []int) {
+	return file_kyc_svc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitKYCRequest) GetIdNumber() string {
+	if x != nil {
+		return x.IdNumber
+	}
+	return ""
+}
+
+func (x *SubmitKYCRequest) GetKraPin() string {
+	if x != nil {
+		return x.KraPin
+	}
+	return ""
+}
+
+func (x *SubmitKYCRequest) GetDocuments() []*KYCDocument {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+type SubmitKYCResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Submission *KYCSubmission `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+}
+
+func (x *SubmitKYCResponse) Reset() {
+	*x = SubmitKYCResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_svc_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitKYCResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitKYCResponse) ProtoMessage() {}
+
+func (x *SubmitKYCResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_svc_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitKYCResponse.ProtoReflect.Descriptor instead.
+func (*SubmitKYCResponse) Descriptor() ([]byte, []int) {
+	return file_kyc_svc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitKYCResponse) GetSubmission() *KYCSubmission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+type GetKYCStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetKYCStatusRequest) Reset() {
+	*x = GetKYCStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_svc_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKYCStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKYCStatusRequest) ProtoMessage() {}
+
+func (x *GetKYCStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_svc_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKYCStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetKYCStatusRequest) Descriptor() ([]byte, []int) {
+	return file_kyc_svc_proto_rawDescGZIP(), []int{2}
+}
+
+type GetKYCStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Submission *KYCSubmission `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+}
+
+func (x *GetKYCStatusResponse) Reset() {
+	*x = GetKYCStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_svc_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKYCStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKYCStatusResponse) ProtoMessage() {}
+
+func (x *GetKYCStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_svc_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKYCStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetKYCStatusResponse) Descriptor() ([]byte, []int) {
+	return file_kyc_svc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetKYCStatusResponse) GetSubmission() *KYCSubmission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+type StartKYCReviewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubmissionId string `protobuf:"bytes,1,opt,name=submission_id,proto3" json:"submission_id,omitempty"`
+}
+
+func (x *StartKYCReviewRequest) Reset() {
+	*x = StartKYCReviewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_svc_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartKYCReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartKYCReviewRequest) ProtoMessage() {}
+
+func (x *StartKYCReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_svc_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartKYCReviewRequest.ProtoReflect.Descriptor instead.
+func (*StartKYCReviewRequest) Descriptor() ([]byte, []int) {
+	return file_kyc_svc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StartKYCReviewRequest) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+type StartKYCReviewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Submission *KYCSubmission `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+}
+
+func (x *StartKYCReviewResponse) Reset() {
+	*x = StartKYCReviewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_svc_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartKYCReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartKYCReviewResponse) ProtoMessage() {}
+
+func (x *StartKYCReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_svc_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartKYCReviewResponse.ProtoReflect.Descriptor instead.
+func (*StartKYCReviewResponse) Descriptor() ([]byte, []int) {
+	return file_kyc_svc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StartKYCReviewResponse) GetSubmission() *KYCSubmission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+type ReviewKYCRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubmissionId string               `protobuf:"bytes,1,opt,name=submission_id,proto3" json:"submission_id,omitempty"`
+	Decision     KYCSubmission_Status `protobuf:"varint,2,opt,name=decision,proto3,enum=api.v1.KYCSubmission_Status" json:"decision,omitempty"`
+	Notes        string               `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+}
+
+func (x *ReviewKYCRequest) Reset() {
+	*x = ReviewKYCRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_svc_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReviewKYCRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewKYCRequest) ProtoMessage() {}
+
+func (x *ReviewKYCRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_svc_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewKYCRequest.ProtoReflect.Descriptor instead.
+func (*ReviewKYCRequest) Descriptor() ([]byte, []int) {
+	return file_kyc_svc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReviewKYCRequest) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+func (x *ReviewKYCRequest) GetDecision() KYCSubmission_Status {
+	if x != nil {
+		return x.Decision
+	}
+	return KYCSubmission_UNKNOWN
+}
+
+func (x *ReviewKYCRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type ReviewKYCResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Submission *KYCSubmission `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+}
+
+func (x *ReviewKYCResponse) Reset() {
+	*x = ReviewKYCResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kyc_svc_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReviewKYCResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewKYCResponse) ProtoMessage() {}
+
+func (x *ReviewKYCResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_svc_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewKYCResponse.ProtoReflect.Descriptor instead.
+func (*ReviewKYCResponse) Descriptor() ([]byte, []int) {
+	return file_kyc_svc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReviewKYCResponse) GetSubmission() *KYCSubmission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+var File_kyc_svc_proto protoreflect.FileDescriptor
+
+var file_kyc_svc_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x6b, 0x79, 0x63, 0x5f, 0x73, 0x76, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x06, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x09, 0x6b, 0x79, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x72, 0x65, 0x64,
+	0x61, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc2, 0x01, 0x0a, 0x10, 0x53, 0x75,
+	0x62, 0x6d, 0x69, 0x74, 0x4b, 0x59, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35,
+	0x0a, 0x09, 0x69, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x17, 0xfa, 0x42, 0x10, 0x72, 0x0e, 0x32, 0x0c, 0x5e, 0x5b, 0x30, 0x2d, 0x39, 0x5d,
+	0x7b, 0x37, 0x2c, 0x38, 0x7d, 0x24, 0xa0, 0xbb, 0x18, 0x01, 0x52, 0x09, 0x69, 0x64, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x07, 0x6b, 0x72, 0x61, 0x5f, 0x70, 0x69, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1e, 0xfa, 0x42, 0x17, 0x72, 0x15, 0x32, 0x13, 0x5e,
+	0x5b, 0x41, 0x50, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x39, 0x7d, 0x5b, 0x41, 0x2d, 0x5a,
+	0x5d, 0x24, 0xa0, 0xbb, 0x18, 0x01, 0x52, 0x07, 0x6b, 0x72, 0x61, 0x5f, 0x70, 0x69, 0x6e, 0x12,
+	0x3d, 0x0a, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x59, 0x43, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0x92, 0x01, 0x04, 0x08,
+	0x01, 0x10, 0x0a, 0x52, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x4a,
+	0x0a, 0x11, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4b, 0x59, 0x43, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4b, 0x59, 0x43, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x15, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x4b, 0x59, 0x43, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x4d, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4b, 0x59, 0x43, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x73, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x59, 0x43, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x47, 0x0a, 0x15, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x59, 0x43, 0x52, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x0d, 0x73, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x0d, 0x73, 0x75, 0x62, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x22, 0x4f, 0x0a, 0x16, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x4b, 0x59, 0x43, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4b, 0x59, 0x43, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xaa, 0x01, 0x0a, 0x10, 0x52,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x4b, 0x59, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2e, 0x0a, 0x0d, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01,
+	0x52, 0x0d, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x12,
+	0x44, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x59, 0x43, 0x53, 0x75,
+	0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42,
+	0x0a, 0xfa, 0x42, 0x07, 0x82, 0x01, 0x04, 0x18, 0x03, 0x18, 0x04, 0x52, 0x08, 0x64, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0x72, 0x05, 0x10, 0x03, 0x18, 0xe8, 0x07,
+	0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x11, 0x52, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x4b, 0x59, 0x43, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a,
+	0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x59, 0x43, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x32, 0xac, 0x02, 0x0a, 0x0a, 0x4b, 0x59, 0x43, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4b, 0x59, 0x43, 0x12,
+	0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4b,
+	0x59, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4b, 0x59, 0x43, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4b, 0x59, 0x43, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x4b, 0x59, 0x43, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x59,
+	0x43, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4f, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x59, 0x43, 0x52, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x12, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x4b, 0x59, 0x43, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4b,
+	0x59, 0x43, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x40, 0x0a, 0x09, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x4b, 0x59, 0x43, 0x12, 0x18, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x4b, 0x59, 0x43,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x4b, 0x59, 0x43, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_kyc_svc_proto_rawDescOnce sync.Once
+	file_kyc_svc_proto_rawDescData = file_kyc_svc_proto_rawDesc
+)
+
+func file_kyc_svc_proto_rawDescGZIP() []byte {
+	file_kyc_svc_proto_rawDescOnce.Do(func() {
+		file_kyc_svc_proto_rawDescData = protoimpl.X.CompressGZIP(file_kyc_svc_proto_rawDescData)
+	})
+	return file_kyc_svc_proto_rawDescData
+}
+
+var file_kyc_svc_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_kyc_svc_proto_goTypes = []interface{}{
+	(*SubmitKYCRequest)(nil),       // 0: api.v1.SubmitKYCRequest
+	(*SubmitKYCResponse)(nil),      // 1: api.v1.SubmitKYCResponse
+	(*GetKYCStatusRequest)(nil),    // 2: api.v1.GetKYCStatusRequest
+	(*GetKYCStatusResponse)(nil),   // 3: api.v1.GetKYCStatusResponse
+	(*StartKYCReviewRequest)(nil),  // 4: api.v1.StartKYCReviewRequest
+	(*StartKYCReviewResponse)(nil), // 5: api.v1.StartKYCReviewResponse
+	(*ReviewKYCRequest)(nil),       // 6: api.v1.ReviewKYCRequest
+	(*ReviewKYCResponse)(nil),      // 7: api.v1.ReviewKYCResponse
+	(*KYCDocument)(nil),            // 8: api.v1.KYCDocument
+	(*KYCSubmission)(nil),          // 9: api.v1.KYCSubmission
+	(KYCSubmission_Status)(0),      // 10: api.v1.KYCSubmission.Status
+}
+var file_kyc_svc_proto_depIdxs = []int32{
+	8,  // 0: api.v1.SubmitKYCRequest.documents:type_name -> api.v1.KYCDocument
+	9,  // 1: api.v1.SubmitKYCResponse.submission:type_name -> api.v1.KYCSubmission
+	9,  // 2: api.v1.GetKYCStatusResponse.submission:type_name -> api.v1.KYCSubmission
+	9,  // 3: api.v1.StartKYCReviewResponse.submission:type_name -> api.v1.KYCSubmission
+	10, // 4: api.v1.ReviewKYCRequest.decision:type_name -> api.v1.KYCSubmission.Status
+	9,  // 5: api.v1.ReviewKYCResponse.submission:type_name -> api.v1.KYCSubmission
+	0,  // 6: api.v1.KYCService.SubmitKYC:input_type -> api.v1.SubmitKYCRequest
+	2,  // 7: api.v1.KYCService.GetKYCStatus:input_type -> api.v1.GetKYCStatusRequest
+	4,  // 8: api.v1.KYCService.StartKYCReview:input_type -> api.v1.StartKYCReviewRequest
+	6,  // 9: api.v1.KYCService.ReviewKYC:input_type -> api.v1.ReviewKYCRequest
+	1,  // 10: api.v1.KYCService.SubmitKYC:output_type -> api.v1.SubmitKYCResponse
+	3,  // 11: api.v1.KYCService.GetKYCStatus:output_type -> api.v1.GetKYCStatusResponse
+	5,  // 12: api.v1.KYCService.StartKYCReview:output_type -> api.v1.StartKYCReviewResponse
+	7,  // 13: api.v1.KYCService.ReviewKYC:output_type -> api.v1.ReviewKYCResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_kyc_svc_proto_init() }
+func file_kyc_svc_proto_init() {
+	if File_kyc_svc_proto != nil {
+		return
+	}
+	file_kyc_proto_init()
+	file_redact_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_kyc_svc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitKYCRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kyc_svc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitKYCResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kyc_svc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKYCStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kyc_svc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKYCStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kyc_svc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartKYCReviewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kyc_svc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartKYCReviewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kyc_svc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReviewKYCRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kyc_svc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReviewKYCResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_kyc_svc_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kyc_svc_proto_goTypes,
+		DependencyIndexes: file_kyc_svc_proto_depIdxs,
+		MessageInfos:      file_kyc_svc_proto_msgTypes,
+	}.Build()
+	File_kyc_svc_proto = out.File
+	file_kyc_svc_proto_rawDesc = nil
+	file_kyc_svc_proto_goTypes = nil
+	file_kyc_svc_proto_depIdxs = nil
+}
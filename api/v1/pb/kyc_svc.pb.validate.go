@@ -0,0 +1,1105 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: kyc_svc.proto
+
+package pb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// define the regex for a UUID once up-front
+var _kyc_svc_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on SubmitKYCRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SubmitKYCRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SubmitKYCRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SubmitKYCRequestMultiError, or nil if none found.
+func (m *SubmitKYCRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SubmitKYCRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_SubmitKYCRequest_IdNumber_Pattern.MatchString(m.GetIdNumber()) {
+		err := SubmitKYCRequestValidationError{
+			field:  "IdNumber",
+			reason: "value does not match regex pattern \"^[0-9]{7,8}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_SubmitKYCRequest_KraPin_Pattern.MatchString(m.GetKraPin()) {
+		err := SubmitKYCRequestValidationError{
+			field:  "KraPin",
+			reason: "value does not match regex pattern \"^[AP][0-9]{9}[A-Z]$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := len(m.GetDocuments()); l < 1 || l > 10 {
+		err := SubmitKYCRequestValidationError{
+			field:  "Documents",
+			reason: "value must contain between 1 and 10 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetDocuments() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SubmitKYCRequestValidationError{
+						field:  fmt.Sprintf("Documents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SubmitKYCRequestValidationError{
+						field:  fmt.Sprintf("Documents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SubmitKYCRequestValidationError{
+					field:  fmt.Sprintf("Documents[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return SubmitKYCRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SubmitKYCRequestMultiError is an error wrapping multiple validation errors
+// returned by SubmitKYCRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SubmitKYCRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SubmitKYCRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SubmitKYCRequestMultiError) AllErrors() []error { return m }
+
+// SubmitKYCRequestValidationError is the validation error returned by
+// SubmitKYCRequest.Validate if the designated constraints aren't met.
+type SubmitKYCRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SubmitKYCRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SubmitKYCRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SubmitKYCRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SubmitKYCRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SubmitKYCRequestValidationError) ErrorName() string { return "SubmitKYCRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SubmitKYCRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSubmitKYCRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SubmitKYCRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SubmitKYCRequestValidationError{}
+
+var _SubmitKYCRequest_IdNumber_Pattern = regexp.MustCompile("^[0-9]{7,8}$")
+
+var _SubmitKYCRequest_KraPin_Pattern = regexp.MustCompile("^[AP][0-9]{9}[A-Z]$")
+
+// Validate checks the field values on SubmitKYCResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SubmitKYCResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SubmitKYCResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SubmitKYCResponseMultiError, or nil if none found.
+func (m *SubmitKYCResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SubmitKYCResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetSubmission()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SubmitKYCResponseValidationError{
+					field:  "Submission",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SubmitKYCResponseValidationError{
+					field:  "Submission",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSubmission()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SubmitKYCResponseValidationError{
+				field:  "Submission",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SubmitKYCResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SubmitKYCResponseMultiError is an error wrapping multiple validation errors
+// returned by SubmitKYCResponse.ValidateAll() if the designated constraints
+// aren't met.
+type SubmitKYCResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SubmitKYCResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SubmitKYCResponseMultiError) AllErrors() []error { return m }
+
+// SubmitKYCResponseValidationError is the validation error returned by
+// SubmitKYCResponse.Validate if the designated constraints aren't met.
+type SubmitKYCResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SubmitKYCResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SubmitKYCResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SubmitKYCResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SubmitKYCResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SubmitKYCResponseValidationError) ErrorName() string {
+	return "SubmitKYCResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SubmitKYCResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSubmitKYCResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SubmitKYCResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SubmitKYCResponseValidationError{}
+
+// Validate checks the field values on GetKYCStatusRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetKYCStatusRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetKYCStatusRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetKYCStatusRequestMultiError, or nil if none found.
+func (m *GetKYCStatusRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetKYCStatusRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetKYCStatusRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetKYCStatusRequestMultiError is an error wrapping multiple validation
+// errors returned by GetKYCStatusRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetKYCStatusRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetKYCStatusRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetKYCStatusRequestMultiError) AllErrors() []error { return m }
+
+// GetKYCStatusRequestValidationError is the validation error returned by
+// GetKYCStatusRequest.Validate if the designated constraints aren't met.
+type GetKYCStatusRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetKYCStatusRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetKYCStatusRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetKYCStatusRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetKYCStatusRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetKYCStatusRequestValidationError) ErrorName() string {
+	return "GetKYCStatusRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetKYCStatusRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetKYCStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetKYCStatusRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetKYCStatusRequestValidationError{}
+
+// Validate checks the field values on GetKYCStatusResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetKYCStatusResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetKYCStatusResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetKYCStatusResponseMultiError, or nil if none found.
+func (m *GetKYCStatusResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetKYCStatusResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetSubmission()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetKYCStatusResponseValidationError{
+					field:  "Submission",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetKYCStatusResponseValidationError{
+					field:  "Submission",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSubmission()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetKYCStatusResponseValidationError{
+				field:  "Submission",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetKYCStatusResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetKYCStatusResponseMultiError is an error wrapping multiple validation
+// errors returned by GetKYCStatusResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetKYCStatusResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetKYCStatusResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetKYCStatusResponseMultiError) AllErrors() []error { return m }
+
+// GetKYCStatusResponseValidationError is the validation error returned by
+// GetKYCStatusResponse.Validate if the designated constraints aren't met.
+type GetKYCStatusResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetKYCStatusResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetKYCStatusResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetKYCStatusResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetKYCStatusResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetKYCStatusResponseValidationError) ErrorName() string {
+	return "GetKYCStatusResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetKYCStatusResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetKYCStatusResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetKYCStatusResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetKYCStatusResponseValidationError{}
+
+// Validate checks the field values on StartKYCReviewRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StartKYCReviewRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StartKYCReviewRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StartKYCReviewRequestMultiError, or nil if none found.
+func (m *StartKYCReviewRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StartKYCReviewRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetSubmissionId()); err != nil {
+		err = StartKYCReviewRequestValidationError{
+			field:  "SubmissionId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return StartKYCReviewRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *StartKYCReviewRequest) _validateUuid(uuid string) error {
+	if matched := _kyc_svc_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// StartKYCReviewRequestMultiError is an error wrapping multiple validation
+// errors returned by StartKYCReviewRequest.ValidateAll() if the designated
+// constraints aren't met.
+type StartKYCReviewRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StartKYCReviewRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StartKYCReviewRequestMultiError) AllErrors() []error { return m }
+
+// StartKYCReviewRequestValidationError is the validation error returned by
+// StartKYCReviewRequest.Validate if the designated constraints aren't met.
+type StartKYCReviewRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StartKYCReviewRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StartKYCReviewRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StartKYCReviewRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StartKYCReviewRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StartKYCReviewRequestValidationError) ErrorName() string {
+	return "StartKYCReviewRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StartKYCReviewRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStartKYCReviewRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StartKYCReviewRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StartKYCReviewRequestValidationError{}
+
+// Validate checks the field values on StartKYCReviewResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StartKYCReviewResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StartKYCReviewResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StartKYCReviewResponseMultiError, or nil if none found.
+func (m *StartKYCReviewResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StartKYCReviewResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetSubmission()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StartKYCReviewResponseValidationError{
+					field:  "Submission",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StartKYCReviewResponseValidationError{
+					field:  "Submission",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSubmission()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StartKYCReviewResponseValidationError{
+				field:  "Submission",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return StartKYCReviewResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// StartKYCReviewResponseMultiError is an error wrapping multiple validation
+// errors returned by StartKYCReviewResponse.ValidateAll() if the designated
+// constraints aren't met.
+type StartKYCReviewResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StartKYCReviewResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StartKYCReviewResponseMultiError) AllErrors() []error { return m }
+
+// StartKYCReviewResponseValidationError is the validation error returned by
+// StartKYCReviewResponse.Validate if the designated constraints aren't met.
+type StartKYCReviewResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StartKYCReviewResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StartKYCReviewResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StartKYCReviewResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StartKYCReviewResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StartKYCReviewResponseValidationError) ErrorName() string {
+	return "StartKYCReviewResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StartKYCReviewResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStartKYCReviewResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StartKYCReviewResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StartKYCReviewResponseValidationError{}
+
+// Validate checks the field values on ReviewKYCRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ReviewKYCRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReviewKYCRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReviewKYCRequestMultiError, or nil if none found.
+func (m *ReviewKYCRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReviewKYCRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetSubmissionId()); err != nil {
+		err = ReviewKYCRequestValidationError{
+			field:  "SubmissionId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _ReviewKYCRequest_Decision_InLookup[m.GetDecision()]; !ok {
+		err := ReviewKYCRequestValidationError{
+			field:  "Decision",
+			reason: "value must be in list [APPROVED REJECTED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetNotes()); l < 3 || l > 1000 {
+		err := ReviewKYCRequestValidationError{
+			field:  "Notes",
+			reason: "value length must be between 3 and 1000 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ReviewKYCRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ReviewKYCRequest) _validateUuid(uuid string) error {
+	if matched := _kyc_svc_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ReviewKYCRequestMultiError is an error wrapping multiple validation errors
+// returned by ReviewKYCRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ReviewKYCRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReviewKYCRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReviewKYCRequestMultiError) AllErrors() []error { return m }
+
+// ReviewKYCRequestValidationError is the validation error returned by
+// ReviewKYCRequest.Validate if the designated constraints aren't met.
+type ReviewKYCRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReviewKYCRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReviewKYCRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReviewKYCRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReviewKYCRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReviewKYCRequestValidationError) ErrorName() string { return "ReviewKYCRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReviewKYCRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReviewKYCRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReviewKYCRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReviewKYCRequestValidationError{}
+
+var _ReviewKYCRequest_Decision_InLookup = map[KYCSubmission_Status]struct{}{
+	3: {},
+	4: {},
+}
+
+// Validate checks the field values on ReviewKYCResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ReviewKYCResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReviewKYCResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReviewKYCResponseMultiError, or nil if none found.
+func (m *ReviewKYCResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReviewKYCResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetSubmission()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ReviewKYCResponseValidationError{
+					field:  "Submission",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ReviewKYCResponseValidationError{
+					field:  "Submission",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSubmission()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReviewKYCResponseValidationError{
+				field:  "Submission",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ReviewKYCResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReviewKYCResponseMultiError is an error wrapping multiple validation errors
+// returned by ReviewKYCResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ReviewKYCResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReviewKYCResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReviewKYCResponseMultiError) AllErrors() []error { return m }
+
+// ReviewKYCResponseValidationError is the validation error returned by
+// ReviewKYCResponse.Validate if the designated constraints aren't met.
+type ReviewKYCResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReviewKYCResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReviewKYCResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReviewKYCResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReviewKYCResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReviewKYCResponseValidationError) ErrorName() string {
+	return "ReviewKYCResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReviewKYCResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReviewKYCResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReviewKYCResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReviewKYCResponseValidationError{}
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.24.4
+// source: kyc_svc.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// KYCServiceClient is the client API for KYCService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KYCServiceClient interface {
+	SubmitKYC(ctx context.Context, in *SubmitKYCRequest, opts ...grpc.CallOption) (*SubmitKYCResponse, error)
+	GetKYCStatus(ctx context.Context, in *GetKYCStatusRequest, opts ...grpc.CallOption) (*GetKYCStatusResponse, error)
+	StartKYCReview(ctx context.Context, in *StartKYCReviewRequest, opts ...grpc.CallOption) (*StartKYCReviewResponse, error)
+	ReviewKYC(ctx context.Context, in *ReviewKYCRequest, opts ...grpc.CallOption) (*ReviewKYCResponse, error)
+}
+
+type kYCServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKYCServiceClient(cc grpc.ClientConnInterface) KYCServiceClient {
+	return &kYCServiceClient{cc}
+}
+
+func (c *kYCServiceClient) SubmitKYC(ctx context.Context, in *SubmitKYCRequest, opts ...grpc.CallOption) (*SubmitKYCResponse, error) {
+	out := new(SubmitKYCResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.KYCService/SubmitKYC", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kYCServiceClient) GetKYCStatus(ctx context.Context, in *GetKYCStatusRequest, opts ...grpc.CallOption) (*GetKYCStatusResponse, error) {
+	out := new(GetKYCStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.KYCService/GetKYCStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kYCServiceClient) StartKYCReview(ctx context.Context, in *StartKYCReviewRequest, opts ...grpc.CallOption) (*StartKYCReviewResponse, error) {
+	out := new(StartKYCReviewResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.KYCService/StartKYCReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kYCServiceClient) ReviewKYC(ctx context.Context, in *ReviewKYCRequest, opts ...grpc.CallOption) (*ReviewKYCResponse, error) {
+	out := new(ReviewKYCResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.KYCService/ReviewKYC", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KYCServiceServer is the server API for KYCService service.
+// All implementations must embed UnimplementedKYCServiceServer
+// for forward compatibility
+type KYCServiceServer interface {
+	SubmitKYC(context.Context, *SubmitKYCRequest) (*SubmitKYCResponse, error)
+	GetKYCStatus(context.Context, *GetKYCStatusRequest) (*GetKYCStatusResponse, error)
+	StartKYCReview(context.Context, *StartKYCReviewRequest) (*StartKYCReviewResponse, error)
+	ReviewKYC(context.Context, *ReviewKYCRequest) (*ReviewKYCResponse, error)
+	mustEmbedUnimplementedKYCServiceServer()
+}
+
+// UnimplementedKYCServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedKYCServiceServer struct {
+}
+
+func (UnimplementedKYCServiceServer) SubmitKYC(context.Context, *SubmitKYCRequest) (*SubmitKYCResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitKYC not implemented")
+}
+func (UnimplementedKYCServiceServer) GetKYCStatus(context.Context, *GetKYCStatusRequest) (*GetKYCStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKYCStatus not implemented")
+}
+func (UnimplementedKYCServiceServer) StartKYCReview(context.Context, *StartKYCReviewRequest) (*StartKYCReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartKYCReview not implemented")
+}
+func (UnimplementedKYCServiceServer) ReviewKYC(context.Context, *ReviewKYCRequest) (*ReviewKYCResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewKYC not implemented")
+}
+func (UnimplementedKYCServiceServer) mustEmbedUnimplementedKYCServiceServer() {}
+
+// UnsafeKYCServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KYCServiceServer will
+// result in compilation errors.
+type UnsafeKYCServiceServer interface {
+	mustEmbedUnimplementedKYCServiceServer()
+}
+
+func RegisterKYCServiceServer(s grpc.ServiceRegistrar, srv KYCServiceServer) {
+	s.RegisterService(&KYCService_ServiceDesc, srv)
+}
+
+func _KYCService_SubmitKYC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitKYCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KYCServiceServer).SubmitKYC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.KYCService/SubmitKYC",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KYCServiceServer).SubmitKYC(ctx, req.(*SubmitKYCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KYCService_GetKYCStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKYCStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KYCServiceServer).GetKYCStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.KYCService/GetKYCStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KYCServiceServer).GetKYCStatus(ctx, req.(*GetKYCStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KYCService_StartKYCReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartKYCReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KYCServiceServer).StartKYCReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.KYCService/StartKYCReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KYCServiceServer).StartKYCReview(ctx, req.(*StartKYCReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KYCService_ReviewKYC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewKYCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KYCServiceServer).ReviewKYC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.KYCService/ReviewKYC",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KYCServiceServer).ReviewKYC(ctx, req.(*ReviewKYCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KYCService_ServiceDesc is the grpc.ServiceDesc for KYCService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KYCService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.v1.KYCService",
+	HandlerType: (*KYCServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitKYC",
+			Handler:    _KYCService_SubmitKYC_Handler,
+		},
+		{
+			MethodName: "GetKYCStatus",
+			Handler:    _KYCService_GetKYCStatus_Handler,
+		},
+		{
+			MethodName: "StartKYCReview",
+			Handler:    _KYCService_StartKYCReview_Handler,
+		},
+		{
+			MethodName: "ReviewKYC",
+			Handler:    _KYCService_ReviewKYC_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kyc_svc.proto",
+}
@@ -0,0 +1,170 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.24.4
+// source: redact.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Redaction controls how a field is masked when a message is logged.
+type Redaction int32
+
+const (
+	Redaction_REDACTION_NONE Redaction = 0
+	// Replaces the value with a truncated HMAC-SHA256 keyed with LOG_HASH_KEY, so equal values can still be correlated
+	// but can't be recovered by hashing guessed values. The value is dropped if no key is set.
+	Redaction_REDACTION_HASH Redaction = 1
+	// Masks most of the value, keeping the email domain or the last characters.
+	Redaction_REDACTION_PARTIAL Redaction = 2
+	// Removes the field.
+	Redaction_REDACTION_DROP Redaction = 3
+)
+
+// Enum value maps for Redaction.
+var (
+	Redaction_name = map[int32]string{
+		0: "REDACTION_NONE",
+		1: "REDACTION_HASH",
+		2: "REDACTION_PARTIAL",
+		3: "REDACTION_DROP",
+	}
+	Redaction_value = map[string]int32{
+		"REDACTION_NONE":    0,
+		"REDACTION_HASH":    1,
+		"REDACTION_PARTIAL": 2,
+		"REDACTION_DROP":    3,
+	}
+)
+
+func (x Redaction) Enum() *Redaction {
+	p := new(Redaction)
+	*p = x
+	return p
+}
+
+func (x Redaction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Redaction) Descriptor() protoreflect.EnumDescriptor {
+	return file_redact_proto_enumTypes[0].Descriptor()
+}
+
+func (Redaction) Type() protoreflect.EnumType {
+	return &file_redact_proto_enumTypes[0]
+}
+
+func (x Redaction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Redaction.Descriptor instead.
+func (Redaction) EnumDescriptor() ([]byte, []int) {
+	return file_redact_proto_rawDescGZIP(), []int{0}
+}
+
+var file_redact_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*Redaction)(nil),
+		Field:         50100,
+		Name:          "api.v1.redact",
+		Tag:           "varint,50100,opt,name=redact,enum=api.v1.Redaction",
+		Filename:      "redact.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional api.v1.Redaction redact = 50100;
+	E_Redact = &file_redact_proto_extTypes[0]
+)
+
+var File_redact_proto protoreflect.FileDescriptor
+
+var file_redact_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0x5e, 0x0a, 0x09, 0x52, 0x65, 0x64, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x45, 0x44, 0x41, 0x43, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x45, 0x44,
+	0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x48, 0x41, 0x53, 0x48, 0x10, 0x01, 0x12, 0x15, 0x0a,
+	0x11, 0x52, 0x45, 0x44, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x41, 0x52, 0x54, 0x49,
+	0x41, 0x4c, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x45, 0x44, 0x41, 0x43, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x44, 0x52, 0x4f, 0x50, 0x10, 0x03, 0x3a, 0x4a, 0x0a, 0x06, 0x72, 0x65, 0x64, 0x61,
+	0x63, 0x74, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0xb4, 0x87, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65,
+	0x64, 0x61, 0x63, 0x74, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_redact_proto_rawDescOnce sync.Once
+	file_redact_proto_rawDescData = file_redact_proto_rawDesc
+)
+
+func file_redact_proto_rawDescGZIP() []byte {
+	file_redact_proto_rawDescOnce.Do(func() {
+		file_redact_proto_rawDescData = protoimpl.X.CompressGZIP(file_redact_proto_rawDescData)
+	})
+	return file_redact_proto_rawDescData
+}
+
+var file_redact_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_redact_proto_goTypes = []interface{}{
+	(Redaction)(0),                    // 0: api.v1.Redaction
+	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+}
+var file_redact_proto_depIdxs = []int32{
+	1, // 0: api.v1.redact:extendee -> google.protobuf.FieldOptions
+	0, // 1: api.v1.redact:type_name -> api.v1.Redaction
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_redact_proto_init() }
+func file_redact_proto_init() {
+	if File_redact_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_redact_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_redact_proto_goTypes,
+		DependencyIndexes: file_redact_proto_depIdxs,
+		EnumInfos:         file_redact_proto_enumTypes,
+		ExtensionInfos:    file_redact_proto_extTypes,
+	}.Build()
+	File_redact_proto = out.File
+	file_redact_proto_rawDesc = nil
+	file_redact_proto_goTypes = nil
+	file_redact_proto_depIdxs = nil
+}
@@ -56,9 +56,35 @@ func (m *KYCData) validate(all bool) error {
 
 	var errors []error
 
-	// no validation rules for IdNumber
+	if m.GetIdNumber() != "" {
 
-	// no validation rules for KraPin
+		if !_KYCData_IdNumber_Pattern.MatchString(m.GetIdNumber()) {
+			err := KYCDataValidationError{
+				field:  "IdNumber",
+				reason: "value does not match regex pattern \"^[0-9]{7,8}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetKraPin() != "" {
+
+		if !_KYCData_KraPin_Pattern.MatchString(m.GetKraPin()) {
+			err := KYCDataValidationError{
+				field:  "KraPin",
+				reason: "value does not match regex pattern \"^[AP][0-9]{9}[A-Z]$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
 
 	if len(errors) > 0 {
 		return KYCDataMultiError(errors)
@@ -137,6 +163,10 @@ var _ interface {
 	ErrorName() string
 } = KYCDataValidationError{}
 
+var _KYCData_IdNumber_Pattern = regexp.MustCompile("^[0-9]{7,8}$")
+
+var _KYCData_KraPin_Pattern = regexp.MustCompile("^[AP][0-9]{9}[A-Z]$")
+
 // Validate checks the field values on UserMeta with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -414,6 +444,43 @@ func (m *User) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for Role
+
+	// no validation rules for StatusReason
+
+	if all {
+		switch v := interface{}(m.GetStatusExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "StatusExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "StatusExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStatusExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserValidationError{
+				field:  "StatusExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for EmailVerified
+
+	// no validation rules for PhoneNumberVerified
+
 	if len(errors) > 0 {
 		return UserMultiError(errors)
 	}
@@ -490,3 +557,175 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = UserValidationError{}
+
+// Validate checks the field values on AccountStatusChange with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AccountStatusChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AccountStatusChange with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AccountStatusChangeMultiError, or nil if none found.
+func (m *AccountStatusChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AccountStatusChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ID
+
+	// no validation rules for UserId
+
+	// no validation rules for From
+
+	// no validation rules for To
+
+	// no validation rules for Reason
+
+	// no validation rules for ActorId
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AccountStatusChangeValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AccountStatusChangeValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AccountStatusChangeValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AccountStatusChangeValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AccountStatusChangeValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AccountStatusChangeValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AccountStatusChangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// AccountStatusChangeMultiError is an error wrapping multiple validation
+// errors returned by AccountStatusChange.ValidateAll() if the designated
+// constraints aren't met.
+type AccountStatusChangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AccountStatusChangeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AccountStatusChangeMultiError) AllErrors() []error { return m }
+
+// AccountStatusChangeValidationError is the validation error returned by
+// AccountStatusChange.Validate if the designated constraints aren't met.
+type AccountStatusChangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AccountStatusChangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AccountStatusChangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AccountStatusChangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AccountStatusChangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AccountStatusChangeValidationError) ErrorName() string {
+	return "AccountStatusChangeValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AccountStatusChangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAccountStatusChange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AccountStatusChangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AccountStatusChangeValidationError{}
@@ -35,6 +35,9 @@ var (
 	_ = sort.Sort
 )
 
+// define the regex for a UUID once up-front
+var _user_svc_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
 // Validate checks the field values on CreateUserRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -639,3 +642,1493 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = UpdateResponseValidationError{}
+
+// Validate checks the field values on SuspendUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SuspendUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SuspendUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SuspendUserRequestMultiError, or nil if none found.
+func (m *SuspendUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SuspendUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = SuspendUserRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetReason()) < 3 {
+		err := SuspendUserRequestValidationError{
+			field:  "Reason",
+			reason: "value length must be at least 3 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if t := m.GetExpiresAt(); t != nil {
+		ts, err := t.AsTime(), t.CheckValid()
+		if err != nil {
+			err = SuspendUserRequestValidationError{
+				field:  "ExpiresAt",
+				reason: "value is not a valid timestamp",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			now := time.Now()
+
+			if ts.Sub(now) <= 0 {
+				err := SuspendUserRequestValidationError{
+					field:  "ExpiresAt",
+					reason: "value must be greater than now",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return SuspendUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *SuspendUserRequest) _validateUuid(uuid string) error {
+	if matched := _user_svc_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// SuspendUserRequestMultiError is an error wrapping multiple validation errors
+// returned by SuspendUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SuspendUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SuspendUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SuspendUserRequestMultiError) AllErrors() []error { return m }
+
+// SuspendUserRequestValidationError is the validation error returned by
+// SuspendUserRequest.Validate if the designated constraints aren't met.
+type SuspendUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SuspendUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SuspendUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SuspendUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SuspendUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SuspendUserRequestValidationError) ErrorName() string {
+	return "SuspendUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SuspendUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSuspendUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SuspendUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SuspendUserRequestValidationError{}
+
+// Validate checks the field values on SuspendUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SuspendUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SuspendUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SuspendUserResponseMultiError, or nil if none found.
+func (m *SuspendUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SuspendUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SuspendUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SuspendUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SuspendUserResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SuspendUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SuspendUserResponseMultiError is an error wrapping multiple validation
+// errors returned by SuspendUserResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SuspendUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SuspendUserResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SuspendUserResponseMultiError) AllErrors() []error { return m }
+
+// SuspendUserResponseValidationError is the validation error returned by
+// SuspendUserResponse.Validate if the designated constraints aren't met.
+type SuspendUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SuspendUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SuspendUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SuspendUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SuspendUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SuspendUserResponseValidationError) ErrorName() string {
+	return "SuspendUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SuspendUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSuspendUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SuspendUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SuspendUserResponseValidationError{}
+
+// Validate checks the field values on ReactivateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReactivateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReactivateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReactivateUserRequestMultiError, or nil if none found.
+func (m *ReactivateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReactivateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = ReactivateUserRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetReason()) < 3 {
+		err := ReactivateUserRequestValidationError{
+			field:  "Reason",
+			reason: "value length must be at least 3 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ReactivateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ReactivateUserRequest) _validateUuid(uuid string) error {
+	if matched := _user_svc_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ReactivateUserRequestMultiError is an error wrapping multiple validation
+// errors returned by ReactivateUserRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ReactivateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReactivateUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReactivateUserRequestMultiError) AllErrors() []error { return m }
+
+// ReactivateUserRequestValidationError is the validation error returned by
+// ReactivateUserRequest.Validate if the designated constraints aren't met.
+type ReactivateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReactivateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReactivateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReactivateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReactivateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReactivateUserRequestValidationError) ErrorName() string {
+	return "ReactivateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReactivateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReactivateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReactivateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReactivateUserRequestValidationError{}
+
+// Validate checks the field values on ReactivateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReactivateUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReactivateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReactivateUserResponseMultiError, or nil if none found.
+func (m *ReactivateUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReactivateUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ReactivateUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ReactivateUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReactivateUserResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ReactivateUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReactivateUserResponseMultiError is an error wrapping multiple validation
+// errors returned by ReactivateUserResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ReactivateUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReactivateUserResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReactivateUserResponseMultiError) AllErrors() []error { return m }
+
+// ReactivateUserResponseValidationError is the validation error returned by
+// ReactivateUserResponse.Validate if the designated constraints aren't met.
+type ReactivateUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReactivateUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReactivateUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReactivateUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReactivateUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReactivateUserResponseValidationError) ErrorName() string {
+	return "ReactivateUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReactivateUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReactivateUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReactivateUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReactivateUserResponseValidationError{}
+
+// Validate checks the field values on DeactivateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeactivateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeactivateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeactivateUserRequestMultiError, or nil if none found.
+func (m *DeactivateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeactivateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = DeactivateUserRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetReason()) < 3 {
+		err := DeactivateUserRequestValidationError{
+			field:  "Reason",
+			reason: "value length must be at least 3 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeactivateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *DeactivateUserRequest) _validateUuid(uuid string) error {
+	if matched := _user_svc_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// DeactivateUserRequestMultiError is an error wrapping multiple validation
+// errors returned by DeactivateUserRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DeactivateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeactivateUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeactivateUserRequestMultiError) AllErrors() []error { return m }
+
+// DeactivateUserRequestValidationError is the validation error returned by
+// DeactivateUserRequest.Validate if the designated constraints aren't met.
+type DeactivateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeactivateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeactivateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeactivateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeactivateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeactivateUserRequestValidationError) ErrorName() string {
+	return "DeactivateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeactivateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeactivateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeactivateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeactivateUserRequestValidationError{}
+
+// Validate checks the field values on DeactivateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeactivateUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeactivateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeactivateUserResponseMultiError, or nil if none found.
+func (m *DeactivateUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeactivateUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DeactivateUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DeactivateUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DeactivateUserResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DeactivateUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeactivateUserResponseMultiError is an error wrapping multiple validation
+// errors returned by DeactivateUserResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DeactivateUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeactivateUserResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeactivateUserResponseMultiError) AllErrors() []error { return m }
+
+// DeactivateUserResponseValidationError is the validation error returned by
+// DeactivateUserResponse.Validate if the designated constraints aren't met.
+type DeactivateUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeactivateUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeactivateUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeactivateUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeactivateUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeactivateUserResponseValidationError) ErrorName() string {
+	return "DeactivateUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeactivateUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeactivateUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeactivateUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeactivateUserResponseValidationError{}
+
+// Validate checks the field values on ListAccountStatusHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAccountStatusHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAccountStatusHistoryRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListAccountStatusHistoryRequestMultiError, or nil if none found.
+func (m *ListAccountStatusHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAccountStatusHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = ListAccountStatusHistoryRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListAccountStatusHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ListAccountStatusHistoryRequest) _validateUuid(uuid string) error {
+	if matched := _user_svc_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ListAccountStatusHistoryRequestMultiError is an error wrapping multiple
+// validation errors returned by ListAccountStatusHistoryRequest.ValidateAll()
+// if the designated constraints aren't met.
+type ListAccountStatusHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAccountStatusHistoryRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAccountStatusHistoryRequestMultiError) AllErrors() []error { return m }
+
+// ListAccountStatusHistoryRequestValidationError is the validation error
+// returned by ListAccountStatusHistoryRequest.Validate if the designated
+// constraints aren't met.
+type ListAccountStatusHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAccountStatusHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAccountStatusHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAccountStatusHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAccountStatusHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAccountStatusHistoryRequestValidationError) ErrorName() string {
+	return "ListAccountStatusHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAccountStatusHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAccountStatusHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAccountStatusHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAccountStatusHistoryRequestValidationError{}
+
+// Validate checks the field values on ListAccountStatusHistoryResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *ListAccountStatusHistoryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAccountStatusHistoryResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListAccountStatusHistoryResponseMultiError, or nil if none found.
+func (m *ListAccountStatusHistoryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAccountStatusHistoryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetChanges() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAccountStatusHistoryResponseValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAccountStatusHistoryResponseValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAccountStatusHistoryResponseValidationError{
+					field:  fmt.Sprintf("Changes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListAccountStatusHistoryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAccountStatusHistoryResponseMultiError is an error wrapping multiple
+// validation errors returned by
+// ListAccountStatusHistoryResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListAccountStatusHistoryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAccountStatusHistoryResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAccountStatusHistoryResponseMultiError) AllErrors() []error { return m }
+
+// ListAccountStatusHistoryResponseValidationError is the validation error
+// returned by ListAccountStatusHistoryResponse.Validate if the designated
+// constraints aren't met.
+type ListAccountStatusHistoryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAccountStatusHistoryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAccountStatusHistoryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAccountStatusHistoryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAccountStatusHistoryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAccountStatusHistoryResponseValidationError) ErrorName() string {
+	return "ListAccountStatusHistoryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAccountStatusHistoryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAccountStatusHistoryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAccountStatusHistoryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAccountStatusHistoryResponseValidationError{}
+
+// Validate checks the field values on SearchUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SearchUsersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SearchUsersRequestMultiError, or nil if none found.
+func (m *SearchUsersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchUsersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetQuery()); l < 2 || l > 128 {
+		err := SearchUsersRequestValidationError{
+			field:  "Query",
+			reason: "value length must be between 2 and 128 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetLimit(); val < 0 || val > 100 {
+		err := SearchUsersRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetOffset(); val < 0 || val > 1000 {
+		err := SearchUsersRequestValidationError{
+			field:  "Offset",
+			reason: "value must be inside range [0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return SearchUsersRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchUsersRequestMultiError is an error wrapping multiple validation errors
+// returned by SearchUsersRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SearchUsersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchUsersRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchUsersRequestMultiError) AllErrors() []error { return m }
+
+// SearchUsersRequestValidationError is the validation error returned by
+// SearchUsersRequest.Validate if the designated constraints aren't met.
+type SearchUsersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchUsersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchUsersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchUsersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchUsersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchUsersRequestValidationError) ErrorName() string {
+	return "SearchUsersRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchUsersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchUsersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchUsersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchUsersRequestValidationError{}
+
+// Validate checks the field values on UserSearchHit with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserSearchHit) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserSearchHit with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserSearchHitMultiError, or
+// nil if none found.
+func (m *UserSearchHit) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserSearchHit) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserSearchHitValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserSearchHitValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserSearchHitValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Score
+
+	// no validation rules for Highlights
+
+	if len(errors) > 0 {
+		return UserSearchHitMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserSearchHitMultiError is an error wrapping multiple validation errors
+// returned by UserSearchHit.ValidateAll() if the designated constraints
+// aren't met.
+type UserSearchHitMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserSearchHitMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserSearchHitMultiError) AllErrors() []error { return m }
+
+// UserSearchHitValidationError is the validation error returned by
+// UserSearchHit.Validate if the designated constraints aren't met.
+type UserSearchHitValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserSearchHitValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserSearchHitValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserSearchHitValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserSearchHitValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserSearchHitValidationError) ErrorName() string { return "UserSearchHitValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserSearchHitValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserSearchHit.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserSearchHitValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserSearchHitValidationError{}
+
+// Validate checks the field values on SearchUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SearchUsersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SearchUsersResponseMultiError, or nil if none found.
+func (m *SearchUsersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchUsersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetHits() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SearchUsersResponseValidationError{
+						field:  fmt.Sprintf("Hits[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SearchUsersResponseValidationError{
+						field:  fmt.Sprintf("Hits[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SearchUsersResponseValidationError{
+					field:  fmt.Sprintf("Hits[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextOffset
+
+	if len(errors) > 0 {
+		return SearchUsersResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchUsersResponseMultiError is an error wrapping multiple validation
+// errors returned by SearchUsersResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SearchUsersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchUsersResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchUsersResponseMultiError) AllErrors() []error { return m }
+
+// SearchUsersResponseValidationError is the validation error returned by
+// SearchUsersResponse.Validate if the designated constraints aren't met.
+type SearchUsersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchUsersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchUsersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchUsersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchUsersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchUsersResponseValidationError) ErrorName() string {
+	return "SearchUsersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchUsersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchUsersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchUsersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchUsersResponseValidationError{}
@@ -2,17 +2,36 @@ package main
 
 import (
 	"bridge/api/v1/pb"
+	auditlog "bridge/internal/audit"
+	"bridge/internal/cache"
 	"bridge/internal/config"
 	"bridge/internal/db"
+	"bridge/internal/encryption"
+	"bridge/internal/health"
+	"bridge/internal/idempotency"
 	"bridge/internal/interceptors"
+	"bridge/internal/lifecycle"
 	"bridge/internal/logger"
+	"bridge/internal/metrics"
+	"bridge/internal/ratelimit"
 	"bridge/internal/repository"
+	"bridge/internal/sender"
 	"bridge/internal/server"
+	"bridge/internal/tracing"
+	"bridge/internal/webhooks"
+	"bridge/internal/worker"
+	"bridge/services/audit"
 	"bridge/services/auth"
+	"bridge/services/kyc"
+	"bridge/services/user"
+	"bridge/services/webhook"
 	"context"
-	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"errors"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"net"
 	"net/http"
 	"os"
@@ -22,34 +41,171 @@ import (
 	"time"
 )
 
-func main() {
-	appLogger := logger.NewLogger()
+// Deadlines for stopping the components on shutdown.
+const (
+	drainTimeout    = 5 * time.Second
+	serverTimeout   = 15 * time.Second
+	resourceTimeout = 5 * time.Second
+)
 
+func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	_, err := config.NewDefaultConfig(ctx)
+	// The logger is created once the config is read since it's configured with the fields to redact.
+	appConfig, err := config.NewDefaultConfig(ctx)
+	appLogger := logger.NewLogger(config.EnvKey.LogRedactFields)
 	if err != nil {
 		appLogger.Fatal().Err(err).Msg("get default config")
 	}
 
+	// The values redacted using a hash are dropped from the logs until the key is set.
+	logger.SetHashKey([]byte(config.EnvKey.LogHashKey))
+
 	appLogger = appLogger.With().
 		Str("app_name", config.EnvKey.Name).
 		Str("env", config.EnvKey.Env).
 		Logger()
 
+	lm := lifecycle.NewManager(appLogger)
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    config.EnvKey.TracingExporter,
+		Endpoint:    config.EnvKey.TracingEndpoint,
+		ServiceName: config.EnvKey.Name,
+		Environment: config.EnvKey.Env,
+	})
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("tracing initialization failed")
+	}
+
+	lm.Register(lifecycle.Component{
+		Name:    "tracing",
+		Stop:    shutdownTracing,
+		Timeout: resourceTimeout,
+	})
+
 	var (
 		svcLogger  = appLogger.With().Str("category", "svc").Logger()
 		repoLogger = appLogger.With().Str("category", "repo").Logger()
 	)
 
-	dbConn, err := db.NewConnection(config.EnvKey.DbDsn)
+	dbConfig, err := db.ParseConfig(
+		config.EnvKey.DbMaxOpenConns,
+		config.EnvKey.DbMaxIdleConns,
+		config.EnvKey.DbConnMaxLifetime,
+		config.EnvKey.DbConnMaxIdleTime,
+		config.EnvKey.DbStatementCacheMode,
+		config.EnvKey.DbStatementTimeout,
+	)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("invalid db config")
+	}
+
+	dbConn, err := db.NewConnection(config.EnvKey.DbDsn, dbConfig)
 	if err != nil {
 		appLogger.Fatal().Err(err).Msg("db connection failed")
 	}
 
+	lm.Register(lifecycle.Component{
+		Name: "database",
+		Stop: func(context.Context) error {
+			return dbConn.Close()
+		},
+		Timeout: resourceTimeout,
+	})
+
+	if err = metrics.RegisterDBStats(dbConn.DB, "primary"); err != nil {
+		appLogger.Fatal().Err(err).Msg("failed to register db stats metrics")
+	}
+
+	replicas, err := db.NewReplicas(config.EnvKey.DbReplicaDsns, dbConfig)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("db replicas connection failed")
+	}
+
+	for i, replica := range replicas {
+		if err = metrics.RegisterDBStats(replica.DB, db.ReplicaName(i)); err != nil {
+			appLogger.Fatal().Err(err).Msg("failed to register db stats metrics")
+		}
+	}
+
+	dbCluster := db.NewCluster(appLogger, dbConn, replicas, db.DefaultHealthCheckInterval)
+
+	lm.Register(lifecycle.Component{
+		Name: "database replicas",
+		Start: func(ctx context.Context) error {
+			dbCluster.Run(ctx)
+			return nil
+		},
+		Stop: func(context.Context) error {
+			return dbCluster.Close()
+		},
+		Timeout: resourceTimeout,
+	})
+
+	cipher, err := encryption.NewConfiguredCipher(
+		config.EnvKey.VaultAddr,
+		config.EnvKey.VaultToken,
+		config.EnvKey.TransitKey,
+		config.EnvKey.EncryptionKeys,
+		config.EnvKey.BlindIndexKey,
+	)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("cipher initialization failed")
+	}
+
 	rs := repository.NewStore()
-	rs.UserRepo = repository.NewUserRepo(dbConn, repoLogger)
+	rs.AuditRepo = repository.NewAuditRepo(dbConn, repoLogger)
+	rs.CategoryRepo = repository.NewCategoryRepo(dbConn, repoLogger)
+	rs.IdempotencyRepo = repository.NewIdempotencyRepo(dbConn, repoLogger, cipher)
+	rs.JobRepo = repository.NewJobRepo(dbConn, repoLogger)
+	rs.KYCRepo = repository.NewKYCRepo(dbConn, repoLogger, cipher)
+	rs.LoginCodeRepo = repository.NewLoginCodeRepo(dbConn, repoLogger)
+	rs.OutboxRepo = repository.NewOutboxRepo(dbConn, repoLogger)
+	rs.RateLimitRepo = repository.NewRateLimitRepo(dbConn, repoLogger)
+	rs.UserRepo = repository.NewUserRepo(dbCluster, repoLogger, cipher)
+	rs.WebhookRepo = repository.NewWebhookRepo(dbConn, repoLogger, cipher)
+
+	userCacheTTL, err := time.ParseDuration(config.EnvKey.UserCacheTTL)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("invalid user cache ttl")
+	}
+
+	if userCacheTTL > 0 {
+		cacheBackend, err := cache.NewBackend(config.EnvKey.CacheBackend, config.EnvKey.CacheAddr)
+		if err != nil {
+			appLogger.Fatal().Err(err).Msg("cache backend initialization failed")
+		}
+
+		lm.Register(lifecycle.Component{
+			Name: "cache",
+			Stop: func(context.Context) error {
+				return cacheBackend.Close()
+			},
+			Timeout: resourceTimeout,
+		})
+
+		rs.UserRepo = repository.NewCachedUserRepo(rs.UserRepo, cacheBackend, cipher, userCacheTTL, repoLogger)
+	}
+
+	rotator := encryption.NewRotator(
+		svcLogger,
+		cipher,
+		encryption.DefaultRotationInterval,
+		encryption.DefaultRotationBatchSize,
+		rs.UserRepo,
+		rs.KYCRepo,
+	)
+
+	lm.Register(lifecycle.Component{
+		Name: "encryption rotator",
+		Start: func(ctx context.Context) error {
+			rotator.Run(ctx)
+			return nil
+		},
+		Timeout: resourceTimeout,
+	})
 
 	var (
 		grpcGWPort = config.EnvKey.GrpcGatewayPort
@@ -62,14 +218,115 @@ func main() {
 		appLogger.Fatal().Err(err).Msg("jwt manager initialization failed")
 	}
 
+	// TODO: add email and SMS providers, the messages are only logged in development and tests until then
+	senders, err := sender.NewSenders(svcLogger, config.EnvKey.Env)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("senders initialization failed")
+	}
+
+	rateLimitPolicies, err := ratelimit.ParsePolicies(config.EnvKey.RateLimits)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("invalid rate limit policies")
+	}
+
+	rateLimitBackend, err := ratelimit.NewBackend(config.EnvKey.RateLimitBackend, svcLogger, rs.RateLimitRepo)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("rate limit backend initialization failed")
+	}
+
+	lm.Register(lifecycle.Component{
+		Name: "rate limiter",
+		Start: func(ctx context.Context) error {
+			rateLimitBackend.Run(ctx)
+			return nil
+		},
+		Timeout: resourceTimeout,
+	})
+
+	idempotencyTTL, err := time.ParseDuration(config.EnvKey.IdempotencyTTL)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("invalid idempotency ttl")
+	}
+
+	guard := idempotency.NewGuard(svcLogger, rs.IdempotencyRepo, idempotencyTTL, idempotency.Methods...)
+
+	lm.Register(lifecycle.Component{
+		Name: "idempotency keys cleanup",
+		Start: func(ctx context.Context) error {
+			guard.Run(ctx)
+			return nil
+		},
+		Timeout: resourceTimeout,
+	})
+
+	webhookAllowlist := webhooks.ParseAllowlist(config.EnvKey.WebhookAllowedHosts)
+
+	trustedProxies, err := logger.ParseTrustedProxies(config.EnvKey.TrustedProxies)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("invalid trusted proxies")
+	}
+
 	var (
-		unarySrvInterceptors = interceptors.NewUnaryServerInterceptors()
-		authSvc              = auth.NewService(jwtManager, svcLogger, rs)
-		authProcessor        = auth.NewAuthProcessor(jwtManager, svcLogger, rs)
-		grpcSrv              = server.NewGrpcSrv(authProcessor, unarySrvInterceptors)
+		unarySrvInterceptors  = interceptors.NewUnaryServerInterceptors()
+		streamSrvInterceptors = interceptors.NewStreamServerInterceptors()
+		authSvc               = auth.NewService(jwtManager, svcLogger, rs, senders)
+		userSvc               = user.NewService(svcLogger, rs)
+		kycSvc                = kyc.NewService(svcLogger, rs)
+		auditSvc              = audit.NewService(svcLogger, rs)
+		webhookSvc            = webhook.NewService(svcLogger, rs, worker.NewEnqueuer(rs.JobRepo), webhookAllowlist)
+		authProcessor         = auth.NewAuthProcessor(jwtManager, svcLogger, rs)
+		kycEnforcer           = kyc.NewEnforcer(svcLogger, rs, kyc.RequiredMethods...)
+		auditor               = auditlog.NewAuditor(svcLogger, rs.AuditRepo, logger.ActiveRedactor(), auditlog.Methods...)
+		limiter               = ratelimit.NewLimiter(
+			svcLogger,
+			rateLimitBackend,
+			ratelimit.MergePolicies(ratelimit.DefaultPolicies, rateLimitPolicies),
+		)
+		grpcSrv = server.NewGrpcSrv(
+			appLogger,
+			authProcessor,
+			kycEnforcer,
+			auditor,
+			limiter,
+			guard,
+			trustedProxies,
+			unarySrvInterceptors,
+			streamSrvInterceptors,
+		)
+	)
+
+	server.RegisterServices(grpcSrv, server.Services{
+		Audit:   auditSvc,
+		Auth:    authSvc,
+		KYC:     kycSvc,
+		User:    userSvc,
+		Webhook: webhookSvc,
+	})
+
+	healthChecker := health.NewChecker(
+		appLogger,
+		[]string{
+			pb.AuthService_ServiceDesc.ServiceName,
+			pb.UserService_ServiceDesc.ServiceName,
+			pb.KYCService_ServiceDesc.ServiceName,
+			pb.WebhookService_ServiceDesc.ServiceName,
+			pb.AuditService_ServiceDesc.ServiceName,
+		},
+		map[string]health.CheckFunc{
+			"postgres": dbConn.PingContext,
+			"vault":    appConfig.Ping,
+		},
 	)
+	healthpb.RegisterHealthServer(grpcSrv, healthChecker.GrpcServer())
 
-	pb.RegisterAuthServiceServer(grpcSrv, authSvc)
+	lm.Register(lifecycle.Component{
+		Name: "health checker",
+		Start: func(ctx context.Context) error {
+			healthChecker.Run(ctx, health.DefaultCheckInterval)
+			return nil
+		},
+		Timeout: resourceTimeout,
+	})
 
 	lis, err := net.Listen("tcp", ":"+grpcPort)
 	if err != nil {
@@ -77,49 +334,88 @@ func main() {
 	}
 
 	appLogger.Info().Msgf("starting grpc server on %v", grpcPort)
-	go func() {
-		if err = grpcSrv.Serve(lis); err != nil {
-			appLogger.Fatal().Err(err).Msg("failed to start grpc server")
-		}
-	}()
+
+	lm.Register(lifecycle.Component{
+		Name: "grpc server",
+		Start: func(context.Context) error {
+			return grpcSrv.Serve(lis)
+		},
+		Stop: func(ctx context.Context) error {
+			return server.GracefulStop(ctx, grpcSrv)
+		},
+		Timeout: serverTimeout,
+	})
 
 	grpcDialOpts := []grpc.DialOption{
-		grpc.WithBlock(),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
 	}
 
-	conn, err := grpc.DialContext(ctx, grpcPort, grpcDialOpts...)
+	// The connection is established once the grpc server is started.
+	conn, err := grpc.DialContext(ctx, net.JoinHostPort("localhost", grpcPort), grpcDialOpts...)
 	if err != nil {
 		appLogger.Fatal().Err(err).Msg("failed to dial grpc server")
 	}
 
-	gmux := runtime.NewServeMux()
+	lm.Register(lifecycle.Component{
+		Name: "gRPC-Gateway client",
+		Stop: func(context.Context) error {
+			return conn.Close()
+		},
+		Timeout: resourceTimeout,
+	})
+
+	gmux := server.NewGatewayMux()
 	if err = pb.RegisterAuthServiceHandler(ctx, gmux, conn); err != nil {
 		appLogger.Fatal().Err(err).Msg("failed to register auth svc gateway")
 	}
 
+	httpMux := http.NewServeMux()
+	httpMux.Handle("/metrics", metrics.Handler())
+	httpMux.Handle("/healthz", healthChecker.LivenessHandler())
+	httpMux.Handle("/readyz", healthChecker.ReadinessHandler())
+	httpMux.Handle(auth.MagicLinkPath, server.MagicLinkHandler())
+	httpMux.Handle("/", metrics.InstrumentHandler(otelhttp.NewHandler(gmux, "grpc-gateway")))
+
 	gwServer := &http.Server{
 		Addr:    grpcGWPort,
-		Handler: gmux,
+		Handler: httpMux,
 	}
 
 	appLogger.Info().Msgf("starting gRPC-Gateway on %v", gwServer.Addr)
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	go func() {
-		sig := <-sigChan
-		appLogger.Info().Msgf("shutting down server, received os signal - %v", sig)
+	lm.Register(
+		lifecycle.Component{
+			Name: "gRPC-Gateway server",
+			Start: func(context.Context) error {
+				if err := gwServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+					return err
+				}
+				return nil
+			},
+			Stop:    gwServer.Shutdown,
+			Timeout: serverTimeout,
+		},
+		// Registered last so that it's the first to stop. The services are marked as NOT_SERVING and the drain timeout
+		// gives the load balancers time to stop sending new requests before the servers stop.
+		lifecycle.Component{
+			Name: "drain",
+			Stop: func(ctx context.Context) error {
+				healthChecker.Shutdown()
+				<-ctx.Done()
+				return nil
+			},
+			Timeout: drainTimeout,
+		},
+	)
 
-		if err = gwServer.Shutdown(ctx); err != nil {
-			appLogger.Fatal().Err(err).Msg("failed to stop gRPC-Gateway server")
-		}
+	// The startup deadline doesn't apply to the components.
+	cancel()
 
-		grpcSrv.GracefulStop()
-	}()
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	code := lm.Run(signalCtx)
+	stop()
 
-	if err = gwServer.ListenAndServe(); err != nil {
-		appLogger.Fatal().Err(err).Msg("failed to start gRPC-Gateway server")
-	}
+	os.Exit(code)
 }
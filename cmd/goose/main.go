@@ -18,11 +18,8 @@ var (
 const _migrateDown = "down"
 
 func main() {
-	appLogger := logger.NewLogger()
-
-	if err := flags.Parse(os.Args[1:]); err != nil {
-		appLogger.Fatal().Err(err).Msg("parse flags")
-	}
+	// The flags exit on errors.
+	_ = flags.Parse(os.Args[1:])
 
 	args := flags.Args()
 	if len(args) < 1 {
@@ -38,12 +35,26 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// The logger is created once the config is read since it's configured with the fields to redact.
 	_, err := config.NewDefaultConfig(ctx)
+	appLogger := logger.NewLogger(config.EnvKey.LogRedactFields)
 	if err != nil {
 		appLogger.Fatal().Err(err).Msg("get default config")
 	}
 
-	dbConn, err := db.NewConnection(config.EnvKey.DbDsn)
+	dbConfig, err := db.ParseConfig(
+		config.EnvKey.DbMaxOpenConns,
+		config.EnvKey.DbMaxIdleConns,
+		config.EnvKey.DbConnMaxLifetime,
+		config.EnvKey.DbConnMaxIdleTime,
+		config.EnvKey.DbStatementCacheMode,
+		config.EnvKey.DbStatementTimeout,
+	)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("invalid db config")
+	}
+
+	dbConn, err := db.NewConnection(config.EnvKey.DbDsn, dbConfig)
 	if err != nil {
 		appLogger.Fatal().Err(err).Msg("create db connection")
 	}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/audit"
+	"bridge/internal/config"
+	"bridge/internal/db"
+	"bridge/internal/encryption"
+	"bridge/internal/logger"
+	"bridge/internal/repository"
+	"context"
+	"flag"
+	"os"
+	"strings"
+	"time"
+)
+
+// _method is recorded as the method of the audit events, since the roles aren't changed through an RPC.
+const _method = "cmd/role"
+
+var (
+	flags = flag.NewFlagSet("role", flag.ExitOnError)
+	email = flags.String("email", "", "email of the user")
+	role  = flags.String("role", "", "role assigned to the user, one of USER, STAFF or ADMIN")
+)
+
+// The role command assigns a role to an existing user. The roles can't be changed through the API, so it's used to
+// assign the first staff members and administrators, for example:
+//
+//	go run ./cmd/role -email jane@example.com -role ADMIN
+func main() {
+	// The flags exit on errors.
+	_ = flags.Parse(os.Args[1:])
+
+	newRole, ok := pb.User_Role_value[strings.ToUpper(*role)]
+	if *email == "" || !ok {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The logger is created once the config is read since it's configured with the fields to redact.
+	_, err := config.NewDefaultConfig(ctx)
+	appLogger := logger.NewLogger(config.EnvKey.LogRedactFields)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("get default config")
+	}
+
+	logger.SetHashKey([]byte(config.EnvKey.LogHashKey))
+
+	dbConfig, err := db.ParseConfig(
+		config.EnvKey.DbMaxOpenConns,
+		config.EnvKey.DbMaxIdleConns,
+		config.EnvKey.DbConnMaxLifetime,
+		config.EnvKey.DbConnMaxIdleTime,
+		config.EnvKey.DbStatementCacheMode,
+		config.EnvKey.DbStatementTimeout,
+	)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("invalid db config")
+	}
+
+	dbConn, err := db.NewConnection(config.EnvKey.DbDsn, dbConfig)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("create db connection")
+	}
+
+	defer func() {
+		_ = dbConn.Close()
+	}()
+
+	cipher, err := encryption.NewConfiguredCipher(
+		config.EnvKey.VaultAddr,
+		config.EnvKey.VaultToken,
+		config.EnvKey.TransitKey,
+		config.EnvKey.EncryptionKeys,
+		config.EnvKey.BlindIndexKey,
+	)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("cipher initialization failed")
+	}
+
+	var (
+		dbCluster = db.NewCluster(appLogger, dbConn, nil, db.DefaultHealthCheckInterval)
+		userRepo  = repository.NewUserRepo(dbCluster, appLogger, cipher)
+	)
+
+	u, err := userRepo.FindByEmail(ctx, *email)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("find user")
+	}
+
+	l := appLogger.With().Str("user_id", u.ID).Stringer("from", u.Role).Stringer("to", pb.User_Role(newRole)).Logger()
+
+	if u.Role == pb.User_Role(newRole) {
+		l.Info().Msg("user already has the role")
+		return
+	}
+
+	event := &pb.AuditEvent{
+		Action:     audit.ActionUserRoleChange,
+		TargetType: audit.TargetUser,
+		TargetId:   u.ID,
+		Changes: []*pb.AuditChange{
+			{Field: "role", Before: u.Role.String(), After: pb.User_Role(newRole).String()},
+		},
+		Method: _method,
+	}
+
+	// The API caches the users for USER_CACHE_TTL at most, after which it reads the new role.
+	if err = userRepo.UpdateRole(ctx, u.ID, pb.User_Role(newRole), event); err != nil {
+		l.Fatal().Err(err).Msg("update role")
+	}
+
+	l.Info().Msg("role assigned")
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bridge/internal/encryption"
+	"bridge/internal/repository"
+	"bridge/internal/webhooks"
+	"bridge/internal/worker"
+	"context"
+	"github.com/rs/zerolog"
+)
+
+// jobTypeReencrypt re-encrypts the sensitive fields still using an older key version, for example after a key is
+// rotated.
+const jobTypeReencrypt = "encryption.reencrypt"
+
+// registerHandlers registers the handlers for the job types processed by the worker.
+func registerHandlers(
+	w worker.Worker,
+	l zerolog.Logger,
+	rs repository.Store,
+	cipher encryption.Cipher,
+	allowlist webhooks.Allowlist,
+) {
+	rotator := encryption.NewRotator(
+		l,
+		cipher,
+		encryption.DefaultRotationInterval,
+		encryption.DefaultRotationBatchSize,
+		rs.UserRepo,
+		rs.KYCRepo,
+	)
+
+	w.Handle(jobTypeReencrypt, worker.NewHandler(func(ctx context.Context, _ struct{}) error {
+		_, err := rotator.Reencrypt(ctx)
+		return err
+	}))
+
+	w.Handle(webhooks.JobTypeDeliver, webhooks.NewDeliverHandler(
+		l,
+		rs.WebhookRepo,
+		webhooks.NewClient(allowlist),
+		webhooks.DefaultDisableAfter,
+	))
+}
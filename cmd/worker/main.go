@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bridge/internal/config"
+	"bridge/internal/db"
+	"bridge/internal/encryption"
+	"bridge/internal/events"
+	"bridge/internal/lifecycle"
+	"bridge/internal/logger"
+	"bridge/internal/repository"
+	"bridge/internal/tracing"
+	"bridge/internal/webhooks"
+	"bridge/internal/worker"
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Deadlines for stopping the components on shutdown.
+const (
+	workerTimeout   = 30 * time.Second
+	resourceTimeout = 5 * time.Second
+)
+
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The logger is created once the config is read since it's configured with the fields to redact.
+	_, err := config.NewDefaultConfig(ctx)
+	appLogger := logger.NewLogger(config.EnvKey.LogRedactFields)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("get default config")
+	}
+
+	// The values redacted using a hash are dropped from the logs until the key is set.
+	logger.SetHashKey([]byte(config.EnvKey.LogHashKey))
+
+	appLogger = appLogger.With().
+		Str("app_name", config.EnvKey.Name).
+		Str("env", config.EnvKey.Env).
+		Str("process", "worker").
+		Logger()
+
+	lm := lifecycle.NewManager(appLogger)
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    config.EnvKey.TracingExporter,
+		Endpoint:    config.EnvKey.TracingEndpoint,
+		ServiceName: config.EnvKey.Name + "-worker",
+		Environment: config.EnvKey.Env,
+	})
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("tracing initialization failed")
+	}
+
+	lm.Register(lifecycle.Component{
+		Name:    "tracing",
+		Stop:    shutdownTracing,
+		Timeout: resourceTimeout,
+	})
+
+	var (
+		svcLogger  = appLogger.With().Str("category", "svc").Logger()
+		repoLogger = appLogger.With().Str("category", "repo").Logger()
+	)
+
+	dbConfig, err := db.ParseConfig(
+		config.EnvKey.DbMaxOpenConns,
+		config.EnvKey.DbMaxIdleConns,
+		config.EnvKey.DbConnMaxLifetime,
+		config.EnvKey.DbConnMaxIdleTime,
+		config.EnvKey.DbStatementCacheMode,
+		config.EnvKey.DbStatementTimeout,
+	)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("invalid db config")
+	}
+
+	dbConn, err := db.NewConnection(config.EnvKey.DbDsn, dbConfig)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("db connection failed")
+	}
+
+	lm.Register(lifecycle.Component{
+		Name: "database",
+		Stop: func(context.Context) error {
+			return dbConn.Close()
+		},
+		Timeout: resourceTimeout,
+	})
+
+	cipher, err := encryption.NewConfiguredCipher(
+		config.EnvKey.VaultAddr,
+		config.EnvKey.VaultToken,
+		config.EnvKey.TransitKey,
+		config.EnvKey.EncryptionKeys,
+		config.EnvKey.BlindIndexKey,
+	)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("cipher initialization failed")
+	}
+
+	// Jobs usually act on the rows just written by the API, so the worker doesn't read from the replicas.
+	dbCluster := db.NewCluster(appLogger, dbConn, nil, db.DefaultHealthCheckInterval)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewAuditRepo(dbConn, repoLogger)
+	rs.CategoryRepo = repository.NewCategoryRepo(dbConn, repoLogger)
+	rs.JobRepo = repository.NewJobRepo(dbConn, repoLogger)
+	rs.KYCRepo = repository.NewKYCRepo(dbConn, repoLogger, cipher)
+	rs.LoginCodeRepo = repository.NewLoginCodeRepo(dbConn, repoLogger)
+	rs.OutboxRepo = repository.NewOutboxRepo(dbConn, repoLogger)
+	rs.UserRepo = repository.NewUserRepo(dbCluster, repoLogger, cipher)
+	rs.WebhookRepo = repository.NewWebhookRepo(dbConn, repoLogger, cipher)
+
+	queues, err := worker.ParseQueues(config.EnvKey.WorkerQueues)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("invalid worker queues")
+	}
+
+	w := worker.NewWorker(svcLogger, rs.JobRepo, queues, worker.Options{})
+	registerHandlers(w, svcLogger, rs, cipher, webhooks.ParseAllowlist(config.EnvKey.WebhookAllowedHosts))
+
+	lm.Register(lifecycle.Component{
+		Name: "worker",
+		Start: func(ctx context.Context) error {
+			w.Run(ctx)
+			return nil
+		},
+		Timeout: workerTimeout,
+	})
+
+	brokerPublisher, err := events.NewPublisher(events.Config{
+		Publisher: config.EnvKey.EventsPublisher,
+		Addr:      config.EnvKey.EventsAddr,
+		Topic:     config.EnvKey.EventsTopic,
+	}, svcLogger)
+	if err != nil {
+		appLogger.Fatal().Err(err).Msg("events publisher initialization failed")
+	}
+
+	// The events are also fanned out to the webhook subscriptions, whose deliveries are processed by the worker.
+	publisher := events.NewMultiPublisher(
+		brokerPublisher,
+		webhooks.NewPublisher(svcLogger, rs.WebhookRepo, worker.NewEnqueuer(rs.JobRepo)),
+	)
+
+	relay := events.NewRelay(
+		svcLogger,
+		rs.OutboxRepo,
+		publisher,
+		events.DefaultRelayInterval,
+		events.DefaultRelayBatchSize,
+	)
+
+	lm.Register(
+		lifecycle.Component{
+			Name: "events publisher",
+			Stop: func(context.Context) error {
+				return publisher.Close()
+			},
+			Timeout: resourceTimeout,
+		},
+		lifecycle.Component{
+			Name: "outbox relay",
+			Start: func(ctx context.Context) error {
+				relay.Run(ctx)
+				return nil
+			},
+			Timeout: resourceTimeout,
+		},
+	)
+
+	// The startup deadline doesn't apply to the components.
+	cancel()
+
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	code := lm.Run(signalCtx)
+	stop()
+
+	os.Exit(code)
+}
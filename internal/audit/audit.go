@@ -0,0 +1,212 @@
+package audit
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/repository"
+	"bridge/internal/utils"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Actions recorded by the services along with the target and the changed fields.
+const (
+	ActionUserCreate                = "user.create"
+	ActionUserUpdate                = "user.update"
+	ActionUserAccountStatusChange   = "user.account_status_change"
+	ActionUserRoleChange            = "user.role_change"
+	ActionKYCSubmit                 = "kyc.submit"
+	ActionKYCReview                 = "kyc.review"
+	ActionWebhookSubscriptionCreate = "webhook_subscription.create"
+	ActionWebhookSubscriptionUpdate = "webhook_subscription.update"
+	ActionWebhookSubscriptionDelete = "webhook_subscription.delete"
+	ActionWebhookDeliveryReplay     = "webhook_delivery.replay"
+)
+
+// Types of the targets of the recorded actions.
+const (
+	TargetUser                = "user"
+	TargetKYCSubmission       = "kyc_submission"
+	TargetWebhookSubscription = "webhook_subscription"
+	TargetWebhookDelivery     = "webhook_delivery"
+)
+
+// Methods are the RPCs audited even when the service doesn't record an action, for example because the call failed.
+var Methods = []string{
+	"/api.v1.AuditService/ListAuditEvents",
+	"/api.v1.AuthService/Login",
+	"/api.v1.AuthService/LoginWithCode",
+	"/api.v1.AuthService/Register",
+	"/api.v1.AuthService/VerifyContact",
+	"/api.v1.KYCService/ReviewKYC",
+	"/api.v1.KYCService/StartKYCReview",
+	"/api.v1.KYCService/SubmitKYC",
+	"/api.v1.UserService/Create",
+	"/api.v1.UserService/DeactivateUser",
+	"/api.v1.UserService/ListAccountStatusHistory",
+	"/api.v1.UserService/ReactivateUser",
+	"/api.v1.UserService/SearchUsers",
+	"/api.v1.UserService/SuspendUser",
+	"/api.v1.UserService/Update",
+	"/api.v1.WebhookService/CreateWebhookSubscription",
+	"/api.v1.WebhookService/DeleteWebhookSubscription",
+	"/api.v1.WebhookService/ReplayDelivery",
+	"/api.v1.WebhookService/UpdateWebhookSubscription",
+}
+
+// writeTimeout is the deadline for writing the event of a call, which may outlive the call's context.
+const writeTimeout = 5 * time.Second
+
+// Trail collects the actor and the actions recorded while handling a call.
+type Trail struct {
+	method   string
+	redactor logger.Redactor
+
+	mu       sync.Mutex
+	actor    *pb.User
+	recorded int
+}
+
+// Actor returns the authenticated user making the call, or nil if it isn't authenticated.
+func (t *Trail) Actor() *pb.User {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.actor
+}
+
+// Recorded returns the number of actions recorded.
+func (t *Trail) Recorded() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.recorded
+}
+
+// describe sets the details of the call in ctx on event, which completed with code.
+func (t *Trail) describe(ctx context.Context, event *pb.AuditEvent, code string) {
+	if actor := t.Actor(); actor != nil {
+		event.ActorId, event.ActorRole = actor.ID, actor.Role.String()
+	}
+
+	if r, ok := logger.RequestFromContext(ctx); ok {
+		event.RequestId = r.ID
+	}
+
+	event.Method = t.method
+	event.Code = code
+	event.Ip = logger.ClientIP(ctx)
+}
+
+type trailCtxKey struct{}
+
+// SetActor records the authenticated user making the call on the trail in ctx. It's meant to be called by the
+// authenticator, which runs after the trail is attached so that the calls it rejects are audited too.
+func SetActor(ctx context.Context, actor *pb.User) {
+	if t, ok := ctx.Value(trailCtxKey{}).(*Trail); ok {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.actor = actor
+	}
+}
+
+// Record returns the event of an action performed on the target by the call in ctx, with the target before and after
+// the action, either of which can be nil. The changes are computed using Diff, skipping the ignored fields. It's meant
+// to be called by the services right before the action, passing the event to the repository which writes it in the
+// transaction of the action, so that the action is never committed without its event. The creations can leave out
+// targetID, which is then set by the repository once the target is stored. No event is returned outside a call with a
+// trail, for example in background jobs.
+func Record(
+	ctx context.Context,
+	action, targetType, targetID string,
+	before, after proto.Message,
+	ignore ...string,
+) []*pb.AuditEvent {
+	t, ok := ctx.Value(trailCtxKey{}).(*Trail)
+	if !ok {
+		return nil
+	}
+
+	event := &pb.AuditEvent{
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetID,
+		Changes:    Diff(t.redactor, before, after, ignore...),
+	}
+	t.describe(ctx, event, codes.OK.String())
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.recorded++
+	return []*pb.AuditEvent{event}
+}
+
+// Auditor audits the calls once they complete.
+type Auditor interface {
+	// WithTrail attaches a new Trail to ctx for the actions recorded during a call to fullMethod, whose changes are
+	// masked like in the logs.
+	WithTrail(ctx context.Context, fullMethod string) (context.Context, *Trail)
+	// Audit writes a single event for the calls to the audited methods which failed or didn't record any action, for
+	// example because they were rejected, by the trail's actor. The actions recorded by the other calls were written
+	// along with the actions themselves.
+	Audit(ctx context.Context, trail *Trail, err error)
+}
+
+type auditor struct {
+	batcher  *batcher
+	l        zerolog.Logger
+	methods  map[string]struct{}
+	redactor logger.Redactor
+}
+
+func (a *auditor) WithTrail(ctx context.Context, fullMethod string) (context.Context, *Trail) {
+	t := &Trail{method: fullMethod, redactor: a.redactor}
+	return context.WithValue(ctx, trailCtxKey{}, t), t
+}
+
+func (a *auditor) Audit(ctx context.Context, trail *Trail, err error) {
+	if err == nil && trail.Recorded() > 0 {
+		return
+	}
+
+	if _, ok := a.methods[trail.method]; !ok {
+		return
+	}
+
+	event := &pb.AuditEvent{Action: trail.method}
+	trail.describe(ctx, event, status.Code(err).String())
+
+	// The event is written even if the client went away, since the call may have changed something already.
+	writeCtx, cancel := context.WithTimeout(utils.WithoutCancel(ctx), writeTimeout)
+	defer cancel()
+
+	if err = a.batcher.write(writeCtx, []*pb.AuditEvent{event}); err != nil {
+		l := logger.FromContext(ctx, a.l).With().Str("action", "audit").Logger()
+		l.Err(err).Str("method", trail.method).Msg("failed to write audit event")
+	}
+}
+
+// NewAuditor creates an Auditor writing the events to repo, auditing every call to methods. The events of the calls
+// completing concurrently are appended together. The changes are masked using redactor, which should be the one
+// configured for the logs.
+func NewAuditor(l zerolog.Logger, repo repository.Audit, redactor logger.Redactor, methods ...string) Auditor {
+	m := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		m[method] = struct{}{}
+	}
+
+	return &auditor{
+		batcher:  &batcher{repo: repo},
+		l:        l.With().Str("component", "audit").Logger(),
+		methods:  m,
+		redactor: redactor,
+	}
+}
@@ -0,0 +1,299 @@
+package audit_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/audit"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryAuditRepo is an in-memory repository.Audit.
+type memoryAuditRepo struct {
+	mu     sync.Mutex
+	events []*pb.AuditEvent
+}
+
+func (r *memoryAuditRepo) Append(ctx context.Context, events ...*pb.AuditEvent) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func (r *memoryAuditRepo) List(context.Context, models.AuditFilter) ([]*pb.AuditEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*pb.AuditEvent(nil), r.events...), nil
+}
+
+func (r *memoryAuditRepo) Verify(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func changesByField(changes []*pb.AuditChange) map[string]*pb.AuditChange {
+	m := make(map[string]*pb.AuditChange, len(changes))
+	for _, c := range changes {
+		m[c.Field] = c
+	}
+	return m
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	asserts := assert.New(t)
+
+	before := &pb.User{
+		ID:            "d8b2c1a4-6f1e-4d52-9a3b-0b6c1c0f3e21",
+		Name:          "Jane Doe",
+		Email:         "jane@example.com",
+		Password:      "hashed-password",
+		AccountStatus: pb.User_ACTIVE,
+	}
+	after := &pb.User{
+		ID:            before.ID,
+		Name:          before.Name,
+		Email:         "janet@example.com",
+		Password:      "new-hashed-password",
+		AccountStatus: pb.User_SUSPENDED,
+	}
+
+	redactor := logger.NewRedactor(nil)
+
+	changes := changesByField(audit.Diff(redactor, before, after, "password"))
+	asserts.Len(changes, 2)
+	asserts.NotContains(changes, "name")
+	asserts.NotContains(changes, "password")
+
+	asserts.Equal("j***@example.com", changes["email"].Before)
+	asserts.Equal("j****@example.com", changes["email"].After)
+	asserts.Equal(pb.User_ACTIVE.String(), changes["account_status"].Before)
+	asserts.Equal(pb.User_SUSPENDED.String(), changes["account_status"].After)
+
+	// Dropped fields show up as changed without their values.
+	changes = changesByField(audit.Diff(redactor, before, after))
+	asserts.Contains(changes, "password")
+	asserts.Empty(changes["password"].Before)
+	asserts.Empty(changes["password"].After)
+
+	// Creating a resource changes every field which is set.
+	submission := &pb.KYCSubmission{
+		UserId:   before.ID,
+		IdNumber: "12345678",
+		Documents: []*pb.KYCDocument{{
+			Type:    pb.KYCDocument_NATIONAL_ID_FRONT,
+			FileUrl: "https://files.example.com/national-id.pdf",
+		}},
+	}
+
+	changes = changesByField(audit.Diff(redactor, nil, submission))
+	asserts.Len(changes, 3)
+	asserts.Empty(changes["id_number"].Before)
+	asserts.Equal(logger.Mask(pb.Redaction_REDACTION_HASH, submission.IdNumber), changes["id_number"].After)
+	asserts.NotContains(changes["id_number"].After, submission.IdNumber)
+	asserts.Contains(changes["documents"].After, "NATIONAL_ID_FRONT")
+	asserts.NotContains(changes["documents"].After, "files.example.com")
+
+	asserts.Empty(audit.Diff(redactor, before, before))
+	asserts.Nil(audit.Diff(redactor, nil, nil))
+
+	// The fields configured for the logs are masked too.
+	changes = changesByField(audit.Diff(
+		logger.NewRedactor(map[string]pb.Redaction{"name": pb.Redaction_REDACTION_DROP}),
+		before,
+		&pb.User{ID: before.ID, Name: "Janet Doe"},
+	))
+	asserts.Contains(changes, "name")
+	asserts.Empty(changes["name"].Before)
+	asserts.Empty(changes["name"].After)
+}
+
+func TestAuditor(t *testing.T) {
+	t.Parallel()
+
+	const (
+		audited    = "/api.v1.UserService/SuspendUser"
+		notAudited = "/api.v1.UserService/Read"
+	)
+
+	var (
+		asserts = assert.New(t)
+		repo    = &memoryAuditRepo{}
+		masked  = logger.NewRedactor(map[string]pb.Redaction{"email": pb.Redaction_REDACTION_DROP})
+		auditor = audit.NewAuditor(logger.TestLogger, repo, masked, audited)
+		admin   = &pb.User{ID: "4f0c3a52-1f7b-4f4e-8d8e-1b2a3c4d5e6f", Role: pb.User_ADMIN}
+		ctx     = logger.ContextWithRequest(
+			metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "10.0.0.1, 203.0.113.7")),
+			&logger.Request{ID: "request-1", Peer: "127.0.0.1:51234"},
+		)
+	)
+
+	// Calls to methods which aren't audited and recorded nothing aren't written.
+	trailCtx, trail := auditor.WithTrail(ctx, notAudited)
+	audit.SetActor(trailCtx, admin)
+	auditor.Audit(trailCtx, trail, nil)
+	asserts.Empty(repo.events)
+
+	// Audited methods are written even when they fail.
+	_, trail = auditor.WithTrail(ctx, audited)
+	auditor.Audit(ctx, trail, status.Error(codes.PermissionDenied, "denied"))
+	asserts.Len(repo.events, 1)
+
+	event := repo.events[0]
+	asserts.Equal(audited, event.Action)
+	asserts.Equal(audited, event.Method)
+	asserts.Equal(codes.PermissionDenied.String(), event.Code)
+	asserts.Empty(event.ActorId)
+	asserts.Equal("request-1", event.RequestId)
+	asserts.Equal("203.0.113.7", event.Ip)
+
+	// The actions recorded by the services are returned to be written along with the action, instead of the generic
+	// event.
+	trailCtx, trail = auditor.WithTrail(ctx, audited)
+	audit.SetActor(trailCtx, admin)
+	events := audit.Record(
+		trailCtx,
+		audit.ActionUserAccountStatusChange,
+		audit.TargetUser,
+		"user-1",
+		&pb.User{ID: "user-1", Email: "jane@example.com", AccountStatus: pb.User_ACTIVE},
+		&pb.User{ID: "user-1", Email: "janet@example.com", AccountStatus: pb.User_SUSPENDED},
+	)
+	asserts.Empty(audit.Record(context.Background(), audit.ActionUserUpdate, audit.TargetUser, "user-1", nil, nil))
+
+	auditor.Audit(trailCtx, trail, nil)
+	asserts.Len(repo.events, 1)
+
+	if asserts.Len(events, 1) {
+		event = events[0]
+		asserts.Equal(audit.ActionUserAccountStatusChange, event.Action)
+		asserts.Equal(audit.TargetUser, event.TargetType)
+		asserts.Equal("user-1", event.TargetId)
+		// The changes are masked using the auditor's redactor.
+		changes := changesByField(event.Changes)
+		asserts.Len(changes, 2)
+		asserts.Equal("SUSPENDED", changes["account_status"].After)
+		asserts.Empty(changes["email"].Before)
+		asserts.Empty(changes["email"].After)
+		asserts.Equal(admin.ID, event.ActorId)
+		asserts.Equal(pb.User_ADMIN.String(), event.ActorRole)
+		asserts.Equal(audited, event.Method)
+		asserts.Equal(codes.OK.String(), event.Code)
+		asserts.Equal("request-1", event.RequestId)
+	}
+
+	// The calls failing after recording an action, whose transaction may have been rolled back, are written.
+	trailCtx, trail = auditor.WithTrail(ctx, audited)
+	audit.Record(trailCtx, audit.ActionUserAccountStatusChange, audit.TargetUser, "user-1", nil, nil)
+	auditor.Audit(trailCtx, trail, status.Error(codes.Internal, "failed"))
+	asserts.Len(repo.events, 2)
+	asserts.Equal(audited, repo.events[1].Action)
+	asserts.Equal(codes.Internal.String(), repo.events[1].Code)
+
+	// The forwarded address is only trusted from the loopback interface.
+	remoteCtx := logger.ContextWithRequest(
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "203.0.113.7")),
+		&logger.Request{ID: "request-2", Peer: "198.51.100.20:443"},
+	)
+	_, trail = auditor.WithTrail(remoteCtx, audited)
+	auditor.Audit(remoteCtx, trail, errors.New("failed"))
+	asserts.Len(repo.events, 3)
+	asserts.Equal("198.51.100.20", repo.events[2].Ip)
+	asserts.Equal(codes.Unknown.String(), repo.events[2].Code)
+
+	// The events are written even when the call was cancelled.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, trail = auditor.WithTrail(cancelledCtx, audited)
+	auditor.Audit(cancelledCtx, trail, status.Error(codes.Canceled, "cancelled"))
+	asserts.Len(repo.events, 4)
+}
+
+// serialAuditRepo is a repository.Audit whose appends are serialized and take latency, like the appends to Postgres
+// which hold the chain's lock until they commit.
+type serialAuditRepo struct {
+	memoryAuditRepo
+	latency time.Duration
+
+	mu      sync.Mutex
+	appends int
+}
+
+func (r *serialAuditRepo) Append(ctx context.Context, events ...*pb.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.appends++
+	time.Sleep(r.latency)
+	return r.memoryAuditRepo.Append(ctx, events...)
+}
+
+func TestAuditor_ConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	const (
+		method = "/api.v1.AuthService/Login"
+		calls  = 50
+	)
+
+	var (
+		asserts = assert.New(t)
+		repo    = &serialAuditRepo{latency: 10 * time.Millisecond}
+		auditor = audit.NewAuditor(logger.TestLogger, repo, logger.NewRedactor(nil), method)
+		wg      sync.WaitGroup
+	)
+
+	wg.Add(calls)
+	for i := 0; i < calls; i++ {
+		go func() {
+			defer wg.Done()
+
+			ctx, trail := auditor.WithTrail(context.Background(), method)
+			auditor.Audit(ctx, trail, nil)
+		}()
+	}
+	wg.Wait()
+
+	// Every event is written once the call returns, and the calls waiting for an append are written together.
+	events, err := repo.List(context.Background(), models.AuditFilter{})
+	asserts.NoError(err)
+	asserts.Len(events, calls)
+	asserts.Less(repo.appends, calls)
+}
+
+// BenchmarkAuditor measures the throughput of the logins audited concurrently with appends taking a millisecond.
+func BenchmarkAuditor(b *testing.B) {
+	const method = "/api.v1.AuthService/Login"
+
+	var (
+		repo    = &serialAuditRepo{latency: time.Millisecond}
+		auditor = audit.NewAuditor(logger.TestLogger, repo, logger.NewRedactor(nil), method)
+	)
+
+	b.SetParallelism(16)
+	b.ResetTimer()
+
+	b.RunParallel(func(p *testing.PB) {
+		for p.Next() {
+			ctx, trail := auditor.WithTrail(context.Background(), method)
+			auditor.Audit(ctx, trail, nil)
+		}
+	})
+
+	b.ReportMetric(float64(b.N)/float64(repo.appends), "events/append")
+}
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/repository"
+	"context"
+	"sync"
+)
+
+// batch holds the events of the calls completed while the previous batch was written.
+type batch struct {
+	events  []*pb.AuditEvent
+	written bool
+	err     error
+}
+
+// batcher coalesces the events written concurrently into a single append. The appends are serialized by the
+// repository to chain every event to the previous one, so writing each call's events on its own would queue up the
+// calls under load, such as a burst of logins. Instead, the calls completed while an append is running wait for it
+// and their events are appended together by the first one to take over.
+type batcher struct {
+	repo repository.Audit
+
+	mu      sync.Mutex
+	pending *batch
+
+	// writeMu is held while a batch is appended.
+	writeMu sync.Mutex
+}
+
+// write appends events and returns once they're written, along with the error of their batch.
+func (b *batcher) write(ctx context.Context, events []*pb.AuditEvent) error {
+	b.mu.Lock()
+	if b.pending == nil {
+		b.pending = &batch{}
+	}
+	own := b.pending
+	own.events = append(own.events, events...)
+	b.mu.Unlock()
+
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	b.mu.Lock()
+	if own.written {
+		b.mu.Unlock()
+		return own.err
+	}
+	b.pending = nil
+	b.mu.Unlock()
+
+	err := b.repo.Append(ctx, own.events...)
+
+	b.mu.Lock()
+	own.written, own.err = true, err
+	b.mu.Unlock()
+
+	return err
+}
@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Diff returns the top-level fields whose value differs between before and after, which must be of the same message
+// type, skipping the ignored fields such as timestamps. Either message can be nil, for example when a resource is
+// created. The values are masked using r like in the logs, so a dropped field such as a password shows up as changed
+// with empty values.
+func Diff(r logger.Redactor, before, after proto.Message, ignore ...string) []*pb.AuditChange {
+	var mt protoreflect.MessageType
+	switch {
+	case after != nil:
+		mt = after.ProtoReflect().Type()
+	case before != nil:
+		mt = before.ProtoReflect().Type()
+	default:
+		return nil
+	}
+
+	ignored := make(map[string]struct{}, len(ignore))
+	for _, name := range ignore {
+		ignored[name] = struct{}{}
+	}
+
+	var (
+		b       = reflectOrEmpty(before, mt)
+		a       = reflectOrEmpty(after, mt)
+		fields  = mt.Descriptor().Fields()
+		changes []*pb.AuditChange
+	)
+
+	// The values are compared unmasked, since different values can have the same mask, and rendered masked.
+	var (
+		maskedBefore = r.Redact(b.Interface()).ProtoReflect()
+		maskedAfter  = r.Redact(a.Interface()).ProtoReflect()
+	)
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if _, ok := ignored[string(fd.Name())]; ok {
+			continue
+		}
+
+		if !b.Has(fd) && !a.Has(fd) {
+			continue
+		}
+
+		if b.Get(fd).Equal(a.Get(fd)) {
+			continue
+		}
+
+		changes = append(changes, &pb.AuditChange{
+			Field:  string(fd.Name()),
+			Before: formatValue(fd, maskedBefore),
+			After:  formatValue(fd, maskedAfter),
+		})
+	}
+
+	return changes
+}
+
+// reflectOrEmpty returns m, or an empty message of type mt if m is nil.
+func reflectOrEmpty(m proto.Message, mt protoreflect.MessageType) protoreflect.Message {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return mt.New()
+	}
+	return m.ProtoReflect()
+}
+
+// formatValue renders the value of fd in msg, which is already masked.
+func formatValue(fd protoreflect.FieldDescriptor, msg protoreflect.Message) string {
+	if !msg.Has(fd) {
+		return ""
+	}
+
+	v := msg.Get(fd)
+
+	switch {
+	case fd.IsList() || fd.IsMap() || fd.Message() != nil:
+		// Composite values are rendered as JSON using a message holding only the field.
+		holder := msg.Type().New()
+		holder.Set(fd, v)
+		data, err := protojson.Marshal(holder.Interface())
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	case fd.Enum() != nil:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	default:
+		return v.String()
+	}
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Supported backends.
+const (
+	// BackendMemory stores the values in an in-process LRU. Each replica has its own cache, so a value invalidated by
+	// one replica may be served by the others until it expires.
+	BackendMemory = "memory"
+	// BackendRedis stores the values in a server speaking the Redis protocol, shared by the replicas.
+	BackendRedis = "redis"
+)
+
+// DefaultMemorySize is the number of values kept by the memory backend before the least recently used are evicted.
+const DefaultMemorySize = 10000
+
+var (
+	// ErrMiss is returned when no value is stored with a key or it expired.
+	ErrMiss = errors.New("cache: miss")
+	// ErrUnknownBackend is returned when the configured backend isn't supported.
+	ErrUnknownBackend = errors.New("cache: unknown backend")
+)
+
+// Backend stores values with a TTL.
+type Backend interface {
+	// Get returns the value stored with key, or ErrMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores value with key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the values stored with keys.
+	Delete(ctx context.Context, keys ...string) error
+	// Close releases the connections of the backend.
+	Close() error
+}
+
+// NewBackend returns the backend called name. addr is the URL of the server for BackendRedis, such as
+// "redis://localhost:6379/0".
+func NewBackend(name, addr string) (Backend, error) {
+	switch strings.ToLower(name) {
+	case "", BackendMemory:
+		return NewMemoryBackend(DefaultMemorySize), nil
+	case BackendRedis:
+		opts, err := redis.ParseURL(addr)
+		if err != nil {
+			return nil, fmt.Errorf("cache: invalid redis url: %w", err)
+		}
+		return NewRedisBackend(redis.NewClient(opts)), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, name)
+	}
+}
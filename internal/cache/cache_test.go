@@ -0,0 +1,121 @@
+package cache_test
+
+import (
+	"bridge/internal/cache"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackends(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	redisBackend, err := cache.NewBackend(cache.BackendRedis, "redis://"+mr.Addr())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = redisBackend.Close()
+	})
+
+	backends := map[string]cache.Backend{
+		cache.BackendMemory: cache.NewMemoryBackend(cache.DefaultMemorySize),
+		cache.BackendRedis:  redisBackend,
+	}
+
+	for name, backend := range backends {
+		backend := backend
+		t.Run(name, func(t *testing.T) {
+			var (
+				asserts = assert.New(t)
+				ctx     = context.Background()
+			)
+
+			_, err := backend.Get(ctx, "user:1")
+			asserts.ErrorIs(err, cache.ErrMiss)
+
+			asserts.NoError(backend.Set(ctx, "user:1", []byte("jane"), time.Minute))
+			asserts.NoError(backend.Set(ctx, "user:2", []byte("john"), time.Minute))
+
+			value, err := backend.Get(ctx, "user:1")
+			asserts.NoError(err)
+			asserts.Equal([]byte("jane"), value)
+
+			asserts.NoError(backend.Set(ctx, "user:1", []byte("jane doe"), time.Minute))
+
+			value, err = backend.Get(ctx, "user:1")
+			asserts.NoError(err)
+			asserts.Equal([]byte("jane doe"), value)
+
+			asserts.NoError(backend.Delete(ctx, "user:1", "user:3"))
+
+			_, err = backend.Get(ctx, "user:1")
+			asserts.ErrorIs(err, cache.ErrMiss)
+
+			value, err = backend.Get(ctx, "user:2")
+			asserts.NoError(err)
+			asserts.Equal([]byte("john"), value)
+		})
+	}
+}
+
+func TestBackends_Expiry(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		mr      = miniredis.RunT(t)
+		memory  = cache.NewMemoryBackend(cache.DefaultMemorySize)
+	)
+
+	redisBackend, err := cache.NewBackend(cache.BackendRedis, "redis://"+mr.Addr())
+	asserts.NoError(err)
+	t.Cleanup(func() {
+		_ = redisBackend.Close()
+	})
+
+	asserts.NoError(memory.Set(ctx, "user:1", []byte("jane"), time.Millisecond))
+	asserts.NoError(redisBackend.Set(ctx, "user:1", []byte("jane"), time.Second))
+
+	time.Sleep(5 * time.Millisecond)
+	mr.FastForward(time.Second)
+
+	_, err = memory.Get(ctx, "user:1")
+	asserts.ErrorIs(err, cache.ErrMiss)
+
+	_, err = redisBackend.Get(ctx, "user:1")
+	asserts.ErrorIs(err, cache.ErrMiss)
+}
+
+func TestMemoryBackend_Eviction(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		backend = cache.NewMemoryBackend(2)
+	)
+
+	asserts.NoError(backend.Set(ctx, "user:1", []byte("jane"), time.Minute))
+	asserts.NoError(backend.Set(ctx, "user:2", []byte("john"), time.Minute))
+
+	// user:2 becomes the least recently used.
+	_, err := backend.Get(ctx, "user:1")
+	asserts.NoError(err)
+
+	asserts.NoError(backend.Set(ctx, "user:3", []byte("jill"), time.Minute))
+
+	_, err = backend.Get(ctx, "user:2")
+	asserts.ErrorIs(err, cache.ErrMiss)
+
+	for _, key := range []string{"user:1", "user:3"} {
+		_, err = backend.Get(ctx, key)
+		asserts.NoError(err, key)
+	}
+}
+
+func TestNewBackend(t *testing.T) {
+	_, err := cache.NewBackend("memcached", "")
+	assert.ErrorIs(t, err, cache.ErrUnknownBackend)
+
+	_, err = cache.NewBackend(cache.BackendRedis, "localhost:6379")
+	assert.Error(t, err)
+}
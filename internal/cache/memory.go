@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// entry is a value of the memory backend, kept in the LRU list.
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+type memoryBackend struct {
+	entries map[string]*list.Element
+	// lru holds the entries from the most to the least recently used.
+	lru  *list.List
+	mu   sync.Mutex
+	size int
+}
+
+func (b *memoryBackend) Get(_ context.Context, key string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	el, ok := b.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+
+	e := el.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		b.remove(el)
+		return nil, ErrMiss
+	}
+
+	b.lru.MoveToFront(el)
+	return e.value, nil
+}
+
+func (b *memoryBackend) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+
+	if el, ok := b.entries[key]; ok {
+		e := el.Value.(*entry)
+		e.value, e.expiresAt = value, expiresAt
+		b.lru.MoveToFront(el)
+		return nil
+	}
+
+	b.entries[key] = b.lru.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+
+	for b.lru.Len() > b.size {
+		b.remove(b.lru.Back())
+	}
+	return nil
+}
+
+func (b *memoryBackend) Delete(_ context.Context, keys ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := b.entries[key]; ok {
+			b.remove(el)
+		}
+	}
+	return nil
+}
+
+// remove removes the entry of el. The caller must hold the lock.
+func (b *memoryBackend) remove(el *list.Element) {
+	b.lru.Remove(el)
+	delete(b.entries, el.Value.(*entry).key)
+}
+
+func (b *memoryBackend) Close() error {
+	return nil
+}
+
+// NewMemoryBackend returns a backend keeping up to size values in memory, evicting the least recently used ones.
+// Expired values are removed when they're looked up or evicted.
+func NewMemoryBackend(size int) Backend {
+	return &memoryBackend{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		size:    size,
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
-	"log"
 )
 
 // AuthInterceptor methods intercept the RPC call on the client adding authentication headers.
@@ -21,13 +20,16 @@ type authInterceptor struct {
 	accessToken string
 }
 
-func (ai *authInterceptor) generateAccessToken(ctx context.Context) {
+// generateAccessToken logs in using the client's credentials. The login error is returned as is so the caller
+// receives its status.
+func (ai *authInterceptor) generateAccessToken(ctx context.Context) error {
 	res, err := ai.authClient.Login(ctx)
 	if err != nil {
-		log.Panicf("auth client: login - %v", err.Error())
+		return err
 	}
 
 	ai.accessToken = res.AccessToken
+	return nil
 }
 
 func (ai *authInterceptor) UnaryInterceptor() grpc.UnaryClientInterceptor {
@@ -39,7 +41,10 @@ func (ai *authInterceptor) UnaryInterceptor() grpc.UnaryClientInterceptor {
 		invoker grpc.UnaryInvoker,
 		opts ...grpc.CallOption,
 	) error {
-		ai.generateAccessToken(ctx)
+		if err := ai.generateAccessToken(ctx); err != nil {
+			return err
+		}
+
 		ctx = metadata.AppendToOutgoingContext(ctx, auth.HeaderAuthorize, auth.AppendBearerPrefix(ai.accessToken))
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
@@ -0,0 +1,53 @@
+package client_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/client"
+	"bridge/internal/rpc_error"
+	"bridge/services/auth"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+type testAuthClient struct {
+	res *pb.LoginResponse
+	err error
+}
+
+func (c *testAuthClient) Login(context.Context) (*pb.LoginResponse, error) {
+	return c.res, c.err
+}
+
+func TestAuthInterceptor_UnaryInterceptor(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		called  bool
+		header  []string
+	)
+
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		called = true
+		md, _ := metadata.FromOutgoingContext(ctx)
+		header = md.Get(auth.HeaderAuthorize)
+		return nil
+	}
+
+	interceptor := client.NewAuthInterceptor(&testAuthClient{err: rpc_error.ErrUnauthenticated}).UnaryInterceptor()
+
+	err := interceptor(ctx, "/api.v1.UserService/Update", nil, nil, nil, invoker)
+	asserts.ErrorIs(err, rpc_error.ErrUnauthenticated)
+	asserts.False(called)
+
+	interceptor = client.NewAuthInterceptor(
+		&testAuthClient{res: &pb.LoginResponse{AccessToken: "token"}},
+	).UnaryInterceptor()
+
+	err = interceptor(ctx, "/api.v1.UserService/Update", nil, nil, nil, invoker)
+	asserts.NoError(err)
+	asserts.True(called)
+	asserts.Equal([]string{auth.AppendBearerPrefix("token")}, header)
+}
@@ -17,26 +17,64 @@ import (
 // defaultEnvFile is the default file name used for storing environment variables if none is provided
 const defaultEnvFile = ".env"
 
-// ProductionEnvironment is the release environment.
-const ProductionEnvironment = "production"
+// The environments the application runs in, set in ENV.
+const (
+	// ProductionEnvironment is the release environment.
+	ProductionEnvironment = "production"
+	// DevelopmentEnvironment is the environment of a local development setup.
+	DevelopmentEnvironment = "development"
+	// TestEnvironment is the environment of the tests.
+	TestEnvironment = "test"
+)
 
 // Provider provides methods for interacting with the configuration provider
 type Provider interface {
 	Get(ctx context.Context, key string) (string, error)
 	Put(ctx context.Context, key string, value string) error
+	Ping(ctx context.Context) error
 }
 
-// envKey stores the environment variables keys
+// envKey stores the environment variables keys. The keys tagged optional can be left out of the env, in which case
+// their zero value is used.
 type envKey struct {
-	Debug           bool   `env:"DEBUG"`
-	Env             string `env:"ENV"`
-	GrpcGatewayPort string `env:"GW_PORT"`
-	Name            string `env:"NAME"`
-	Port            uint16 `env:"PORT"`
-	URL             string `env:"URL"`
-
-	DbDsn  string `env:"DB_DSN" secured:"true"`
-	JwtKey string `env:"JWT_KEY" secured:"true"`
+	CacheBackend         string `env:"CACHE_BACKEND"`
+	DbConnMaxIdleTime    string `env:"DB_CONN_MAX_IDLE_TIME"`
+	DbConnMaxLifetime    string `env:"DB_CONN_MAX_LIFETIME"`
+	DbMaxIdleConns       uint16 `env:"DB_MAX_IDLE_CONNS"`
+	DbMaxOpenConns       uint16 `env:"DB_MAX_OPEN_CONNS"`
+	DbStatementCacheMode string `env:"DB_STATEMENT_CACHE_MODE"`
+	DbStatementTimeout   string `env:"DB_STATEMENT_TIMEOUT"`
+	Debug                bool   `env:"DEBUG"`
+	Env                  string `env:"ENV"`
+	EventsAddr           string `env:"EVENTS_ADDR" optional:"true"`
+	EventsPublisher      string `env:"EVENTS_PUBLISHER" optional:"true"`
+	EventsTopic          string `env:"EVENTS_TOPIC" optional:"true"`
+	GrpcGatewayPort      string `env:"GW_PORT"`
+	IdempotencyTTL       string `env:"IDEMPOTENCY_TTL"`
+	LogRedactFields      string `env:"LOG_REDACT_FIELDS" optional:"true"`
+	Name                 string `env:"NAME"`
+	Port                 uint16 `env:"PORT"`
+	RateLimitBackend     string `env:"RATE_LIMIT_BACKEND"`
+	RateLimits           string `env:"RATE_LIMITS" optional:"true"`
+	TracingEndpoint      string `env:"TRACING_ENDPOINT" optional:"true"`
+	TracingExporter      string `env:"TRACING_EXPORTER" optional:"true"`
+	TransitKey           string `env:"TRANSIT_KEY" optional:"true"`
+	TrustedProxies       string `env:"TRUSTED_PROXIES" optional:"true"`
+	URL                  string `env:"URL"`
+	UserCacheTTL         string `env:"USER_CACHE_TTL"`
+	VaultAddr            string `env:"VAULT_ADDR"`
+	VaultPath            string `env:"VAULT_PATH"`
+	WebhookAllowedHosts  string `env:"WEBHOOK_ALLOWED_HOSTS" optional:"true"`
+	WorkerQueues         string `env:"WORKER_QUEUES"`
+
+	BlindIndexKey  string `env:"BLIND_INDEX_KEY" secured:"true"`
+	CacheAddr      string `env:"CACHE_ADDR" secured:"true" optional:"true"`
+	DbDsn          string `env:"DB_DSN" secured:"true"`
+	DbReplicaDsns  string `env:"DB_REPLICA_DSNS" secured:"true" optional:"true"`
+	EncryptionKeys string `env:"ENCRYPTION_KEYS" secured:"true"`
+	JwtKey         string `env:"JWT_KEY" secured:"true"`
+	LogHashKey     string `env:"LOG_HASH_KEY" secured:"true"`
+	VaultToken     string `env:"VAULT_TOKEN" secured:"true"`
 }
 
 // EnvKey stores parsed env keys values
@@ -65,36 +103,47 @@ func AbsPath(filename string) string {
 
 // Load reads the provided env file and maps the env key value to Key which is exported
 // and can be used across the app.
-func (c *Config) Load(ctx context.Context, filename string) (err error) {
+func (c *Config) Load(ctx context.Context, filename string) error {
+	key, err := readEnv(filename)
+	if err != nil {
+		return err
+	}
+
+	if err = c.resolveSecured(ctx, &key); err != nil {
+		return err
+	}
+
+	EnvKey = key
+	return nil
+}
+
+// readEnv reads the provided env file and parses the env keys, leaving the secured values unresolved. A missing key
+// is an error unless it's optional.
+func readEnv(filename string) (envKey, error) {
 	if filename == "" {
 		filename = defaultEnvFile
 	}
 
+	var key envKey
+
 	if err := godotenv.Load(AbsPath(filename)); err != nil {
-		return fmt.Errorf("load env: %v", err)
+		return key, fmt.Errorf("load env: %v", err)
 	}
 
-	var key envKey
-
 	keyType := reflect.TypeOf(key)
 
 	for i := 0; i < keyType.NumField(); i++ {
 		var (
-			field      = keyType.Field(i)
-			envTag     = field.Tag.Get("env")
-			securedTag = field.Tag.Get("secured")
+			field  = keyType.Field(i)
+			envTag = field.Tag.Get("env")
 		)
 
 		envValue, ok := os.LookupEnv(envTag)
 		if !ok {
-			return fmt.Errorf("env variable %q not found", envTag)
-		}
-
-		if securedTag != "" {
-			envValue, err = c.provider.Get(ctx, envValue)
-			if err != nil {
-				return err
+			if field.Tag.Get("optional") != "" {
+				continue
 			}
+			return key, fmt.Errorf("env variable %q not found", envTag)
 		}
 
 		var (
@@ -109,20 +158,54 @@ func (c *Config) Load(ctx context.Context, filename string) (err error) {
 		case reflect.Uint16:
 			val, err := strconv.Atoi(envValue)
 			if err != nil {
-				return fmt.Errorf("atoi: %v", err)
+				return key, fmt.Errorf("atoi: %v", err)
 			}
 			fieldValue.SetUint(uint64(val))
 		case reflect.String:
 			fieldValue.SetString(envValue)
 		default:
-			return fmt.Errorf("unsupported type %q for %q", kind, envTag)
+			return key, fmt.Errorf("unsupported type %q for %q", kind, envTag)
 		}
 	}
 
-	EnvKey = key
+	return key, nil
+}
+
+// resolveSecured replaces the secured values of key referencing a secret, such as "secret://bridge/jwt_key", by the
+// secret read from the provider. The other secured values, such as optional secrets left empty or the Vault token the
+// provider itself authenticates with, are used as they are.
+func (c *Config) resolveSecured(ctx context.Context, key *envKey) error {
+	var (
+		keyType  = reflect.TypeOf(*key)
+		keyValue = reflect.ValueOf(key).Elem()
+	)
+
+	for i := 0; i < keyType.NumField(); i++ {
+		field := keyType.Field(i)
+		if field.Tag.Get("secured") == "" {
+			continue
+		}
+
+		fieldValue := keyValue.Field(i)
+		if fieldValue.Kind() != reflect.String || !strings.HasPrefix(fieldValue.String(), vault.KeyPrefix) {
+			continue
+		}
+
+		secret, err := c.provider.Get(ctx, fieldValue.String())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetString(secret)
+	}
+
 	return nil
 }
 
+// Ping checks that the configuration provider is available.
+func (c *Config) Ping(ctx context.Context) error {
+	return c.provider.Ping(ctx)
+}
+
 // NewConfig initializes new Config
 func NewConfig(p Provider) *Config {
 	return &Config{
@@ -130,24 +213,25 @@ func NewConfig(p Provider) *Config {
 	}
 }
 
-// NewDefaultConfig creates a new Config instance with using vault as the default provider
+// NewDefaultConfig creates a new Config instance with using vault as the default provider. The provider is set up
+// using the Vault keys read from the env before the secured values are resolved.
 func NewDefaultConfig(ctx context.Context) (*Config, error) {
-	var (
-		vaultAddr  = os.Getenv("VAULT_ADDR")
-		vaultPath  = os.Getenv("VAULT_PATH")
-		vaultToken = os.Getenv("VAULT_TOKEN")
-	)
+	key, err := readEnv("")
+	if err != nil {
+		return nil, err
+	}
 
-	vaultProvider, err := vault.NewProvider(vaultAddr, vaultPath, vaultToken)
+	vaultProvider, err := vault.NewProvider(key.VaultAddr, key.VaultPath, key.VaultToken)
 	if err != nil {
 		return nil, err
 	}
 
 	config := NewConfig(vaultProvider)
 
-	if err = config.Load(ctx, ""); err != nil {
+	if err = config.resolveSecured(ctx, &key); err != nil {
 		return nil, err
 	}
 
+	EnvKey = key
 	return config, nil
 }
@@ -55,7 +55,38 @@ func TestConfig(t *testing.T) {
 	err := appConfig.Load(ctx, "")
 	require.NoError(t, err)
 
+	// The secrets are read from the provider, and the Vault token it authenticates with is used as is.
+	require.NotEmpty(t, config.EnvKey.JwtKey)
+	require.NotContains(t, config.EnvKey.JwtKey, vault.KeyPrefix)
+	require.Equal(t, os.Getenv("VAULT_TOKEN"), config.EnvKey.VaultToken)
+
 	appConfig, err = config.NewDefaultConfig(ctx)
 	require.NoError(t, err)
 	require.NotNil(t, appConfig)
 }
+
+func TestConfig_OptionalKeys(t *testing.T) {
+	var (
+		appConfig = config.NewConfig(testVaultProvider)
+		ctx       = context.Background()
+	)
+
+	unset := func(key string) {
+		value, ok := os.LookupEnv(key)
+		require.NoError(t, os.Unsetenv(key))
+
+		t.Cleanup(func() {
+			if ok {
+				_ = os.Setenv(key, value)
+			}
+		})
+	}
+
+	// The optional keys can be left out, the other ones can't.
+	unset("TRACING_EXPORTER")
+	require.NoError(t, appConfig.Load(ctx, "internal/config/testdata/empty.env"))
+	require.Empty(t, config.EnvKey.TracingExporter)
+
+	unset("DB_DSN")
+	require.Error(t, appConfig.Load(ctx, "internal/config/testdata/empty.env"))
+}
@@ -1,10 +1,12 @@
 package vault
 
 import (
+	"bridge/internal/tracing"
 	"context"
 	"errors"
 	"fmt"
 	vault "github.com/hashicorp/vault/api"
+	"go.opentelemetry.io/otel/attribute"
 	"strings"
 )
 
@@ -15,6 +17,7 @@ var ErrInvalidKey = errors.New("invalid key provided")
 const KeyPrefix = "secret://"
 
 type Provider struct {
+	client  *vault.Client
 	kv      *vault.KVv2
 	secrets map[string]map[string]string
 }
@@ -22,7 +25,7 @@ type Provider struct {
 // Get retrieves a value from vault using the KV engine. The actual key selected is determined by the value
 // separated by the forward slash. For example "secret://secret-path/database:user" will retrieve the key "database:user"
 // from the path "secret-path".
-func (p *Provider) Get(ctx context.Context, key string) (string, error) {
+func (p *Provider) Get(ctx context.Context, key string) (_ string, err error) {
 	providerWithKeys := strings.Split(key, KeyPrefix)
 	if len(providerWithKeys) != 2 {
 		return "", ErrInvalidKey
@@ -34,6 +37,18 @@ func (p *Provider) Get(ctx context.Context, key string) (string, error) {
 		keyName     = keyWithPath[1]
 	)
 
+	_, cached := p.secrets[secretPath]
+	ctx, span := tracing.StartSpan(
+		ctx,
+		"vault.Get",
+		attribute.String("vault.secret_path", secretPath),
+		attribute.String("vault.key", keyName),
+		attribute.Bool("vault.cached", cached),
+	)
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	if v, ok := p.secrets[secretPath]; ok {
 		secretValue, ok := v[keyName]
 		if !ok {
@@ -71,7 +86,7 @@ func (p *Provider) Get(ctx context.Context, key string) (string, error) {
 // Put adds a value to the vault using the KV engine. The actual key selected is determined by the value
 // separated by the forward slash. For example "secret://secret-path/database:user" will add the key "database:user"
 // on the path "secret-path".
-func (p *Provider) Put(ctx context.Context, key string, value string) error {
+func (p *Provider) Put(ctx context.Context, key string, value string) (err error) {
 	providerWithKeys := strings.Split(key, "//")
 	if len(providerWithKeys) != 2 {
 		return ErrInvalidKey
@@ -83,6 +98,16 @@ func (p *Provider) Put(ctx context.Context, key string, value string) error {
 		keyName     = keyWithPath[1]
 	)
 
+	ctx, span := tracing.StartSpan(
+		ctx,
+		"vault.Put",
+		attribute.String("vault.secret_path", secretPath),
+		attribute.String("vault.key", keyName),
+	)
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	kvSecret, err := p.kv.Get(ctx, secretPath)
 	if err != nil {
 		return fmt.Errorf("error reading secrets from vault - %w", err)
@@ -102,6 +127,20 @@ func (p *Provider) Put(ctx context.Context, key string, value string) error {
 	return nil
 }
 
+// Ping checks that vault is reachable and the token is still valid by looking up the token.
+func (p *Provider) Ping(ctx context.Context) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "vault.Ping")
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	if _, err = p.client.Auth().Token().LookupSelfWithContext(ctx); err != nil {
+		return fmt.Errorf("error looking up token - %w", err)
+	}
+
+	return nil
+}
+
 // NewProvider creates a new vault provider
 func NewProvider(addr, mountPath, token string) (*Provider, error) {
 	vaultConfig := vault.DefaultConfig()
@@ -115,6 +154,7 @@ func NewProvider(addr, mountPath, token string) (*Provider, error) {
 	client.SetToken(token)
 
 	return &Provider{
+		client:  client,
 		kv:      client.KVv2(mountPath),
 		secrets: make(map[string]map[string]string),
 	}, nil
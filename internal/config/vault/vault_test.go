@@ -66,3 +66,20 @@ func TestProvider_Get_Put(t *testing.T) {
 	asserts.NotNil(gotValue)
 	asserts.Equal(wantValue, gotValue)
 }
+
+func TestProvider_Ping(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+	)
+
+	provider, err := vault.NewProvider(testVaultClient.Address, testVaultClient.Path, testVaultClient.Token)
+	asserts.NoError(err)
+	asserts.NoError(provider.Ping(ctx))
+
+	provider, err = vault.NewProvider(testVaultClient.Address, testVaultClient.Path, "invalid-token")
+	asserts.NoError(err)
+	asserts.Error(provider.Ping(ctx))
+}
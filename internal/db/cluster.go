@@ -0,0 +1,253 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultHealthCheckInterval is the default interval between the health checks of the replicas.
+const DefaultHealthCheckInterval = 5 * time.Second
+
+// Cluster routes the queries of the repositories to the primary or to the read replicas.
+type Cluster interface {
+	// Primary returns the primary, for the reads which must not be routed to the replicas.
+	Primary() *sqlx.DB
+	// Writer returns the primary for writes. The reads made afterwards with a context of the same session are routed
+	// to the primary so that they see the writes.
+	Writer(ctx context.Context) *sqlx.DB
+	// Read runs fn, which must only read, on the next healthy replica in round-robin. fn runs on the primary instead
+	// if there are no healthy replicas or the context requires it. If fn fails because of the replica's connection,
+	// the replica is marked unhealthy until its next health check and fn is retried on the primary.
+	Read(ctx context.Context, fn func(q sqlx.QueryerContext) error) error
+	// Run checks the health of the replicas every interval until ctx is done.
+	Run(ctx context.Context)
+	// Close closes the replicas. The primary is left open.
+	Close() error
+}
+
+type primaryKey struct{}
+
+type sessionKey struct{}
+
+// WithPrimary returns a copy of ctx whose reads are routed to the primary.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// WithSession returns a copy of ctx whose reads are routed to the primary once a write was made with it, so that a
+// request reads its own writes.
+func WithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey{}, new(atomic.Bool))
+}
+
+// ReadsPrimary reports whether the reads made with ctx must be routed to the primary, because of WithPrimary or a
+// write made in the session of ctx.
+func ReadsPrimary(ctx context.Context) bool {
+	if primary, _ := ctx.Value(primaryKey{}).(bool); primary {
+		return true
+	}
+
+	wrote, ok := ctx.Value(sessionKey{}).(*atomic.Bool)
+	return ok && wrote.Load()
+}
+
+type replica struct {
+	name    string
+	db      *sqlx.DB
+	healthy atomic.Bool
+}
+
+type cluster struct {
+	l        zerolog.Logger
+	primary  *sqlx.DB
+	replicas []*replica
+	next     atomic.Uint64
+	interval time.Duration
+}
+
+// NewReplicas opens a connection pool for each of the comma separated replica dsns. Unlike NewConnection the
+// replicas aren't pinged, so that an unavailable replica doesn't prevent the application from starting; the health
+// checks of the Cluster route the reads away from it.
+func NewReplicas(dsns string, cfg Config) ([]*sqlx.DB, error) {
+	var replicas []*sqlx.DB
+	for _, dsn := range strings.Split(dsns, ",") {
+		dsn = strings.TrimSpace(dsn)
+		if dsn == "" {
+			continue
+		}
+
+		replica, err := open(dsn, cfg)
+		if err != nil {
+			for _, r := range replicas {
+				_ = r.Close()
+			}
+			return nil, fmt.Errorf("new replica %d: %w", len(replicas)+1, err)
+		}
+
+		replicas = append(replicas, replica)
+	}
+	return replicas, nil
+}
+
+// ReplicaName returns the name of the i-th replica, starting from 0, used in the logs and metrics.
+func ReplicaName(i int) string {
+	return fmt.Sprintf("replica-%d", i+1)
+}
+
+// NewCluster returns a Cluster of primary and replicas, which are health checked every interval once the cluster
+// runs. Reads are routed to the primary if there are no replicas.
+func NewCluster(l zerolog.Logger, primary *sqlx.DB, replicas []*sqlx.DB, interval time.Duration) Cluster {
+	c := &cluster{
+		l:        l.With().Str("component", "db cluster").Logger(),
+		primary:  primary,
+		interval: interval,
+	}
+
+	for i, db := range replicas {
+		r := &replica{name: ReplicaName(i), db: db}
+		r.healthy.Store(true)
+		c.replicas = append(c.replicas, r)
+	}
+
+	return c
+}
+
+func (c *cluster) Primary() *sqlx.DB {
+	return c.primary
+}
+
+func (c *cluster) Writer(ctx context.Context) *sqlx.DB {
+	if wrote, ok := ctx.Value(sessionKey{}).(*atomic.Bool); ok {
+		wrote.Store(true)
+	}
+	return c.primary
+}
+
+func (c *cluster) Read(ctx context.Context, fn func(q sqlx.QueryerContext) error) error {
+	if ReadsPrimary(ctx) {
+		return fn(c.primary)
+	}
+
+	r := c.pick()
+	if r == nil {
+		return fn(c.primary)
+	}
+
+	err := fn(r.db)
+	if err == nil || ctx.Err() != nil || !isConnectionError(err) {
+		return err
+	}
+
+	c.setHealthy(r, err)
+	return fn(c.primary)
+}
+
+// pick returns the next healthy replica, or nil if there are none.
+func (c *cluster) pick() *replica {
+	n := uint64(len(c.replicas))
+	if n == 0 {
+		return nil
+	}
+
+	start := c.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := c.replicas[(start+i)%n]; r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+func (c *cluster) Run(ctx context.Context) {
+	if len(c.replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check pings the replicas and updates their health.
+func (c *cluster) check(ctx context.Context) {
+	for _, r := range c.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, c.interval)
+		err := r.db.PingContext(pingCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+		c.setHealthy(r, err)
+	}
+}
+
+// setHealthy marks r healthy if err is nil and unhealthy otherwise, logging the changes.
+func (c *cluster) setHealthy(r *replica, err error) {
+	if r.healthy.Swap(err == nil) == (err == nil) {
+		return
+	}
+
+	if err != nil {
+		c.l.Warn().Err(err).Str("replica", r.name).Msg("replica unhealthy, reads are routed to the other replicas")
+		return
+	}
+	c.l.Info().Str("replica", r.name).Msg("replica healthy")
+}
+
+func (c *cluster) Close() error {
+	var errs []string
+	for _, r := range c.replicas {
+		if err := r.db.Close(); err != nil {
+			errs = append(errs, r.name+": "+err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("close replicas: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// isConnectionError reports whether err was caused by the connection to the database rather than by the query.
+func isConnectionError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "57P01", "57P02", "57P03": // admin_shutdown, crash_shutdown, cannot_connect_now
+			return true
+		case "40001": // serialization_failure, raised by hot standbys on conflicts with the recovery
+			return true
+		}
+
+		// connection_exception
+		return strings.HasPrefix(pgErr.Code, "08")
+	}
+
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &netErr)
+}
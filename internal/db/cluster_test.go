@@ -0,0 +1,194 @@
+package db_test
+
+import (
+	"bridge/internal/db"
+	"bridge/internal/logger"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// downNodes holds the names of the fake nodes which are unreachable.
+var downNodes sync.Map
+
+// fakeDriver connects to nodes named by the dsn, whose queries return the name of the node.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	if _, down := downNodes.Load(name); down {
+		return nil, driver.ErrBadConn
+	}
+	return &fakeConn{node: name}, nil
+}
+
+type fakeConn struct {
+	node string
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+func (c *fakeConn) Ping(context.Context) error {
+	if _, down := downNodes.Load(c.node); down {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (c *fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if _, down := downNodes.Load(c.node); down {
+		return nil, driver.ErrBadConn
+	}
+	return &fakeRows{node: c.node}, nil
+}
+
+type fakeRows struct {
+	node string
+	done bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"node"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+
+	r.done = true
+	dest[0] = r.node
+	return nil
+}
+
+func newFakeDB(t *testing.T, node string) *sqlx.DB {
+	conn := sqlx.NewDb(sql.OpenDB(&fakeConnector{node: node}), "fake")
+	t.Cleanup(func() {
+		_ = conn.Close()
+		downNodes.Delete(node)
+	})
+	return conn
+}
+
+type fakeConnector struct {
+	node string
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return fakeDriver{}.Open(c.node)
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+func readNode(ctx context.Context, t *testing.T, cluster db.Cluster) string {
+	var node string
+	err := cluster.Read(ctx, func(q sqlx.QueryerContext) error {
+		return q.QueryRowxContext(ctx, "SELECT node").Scan(&node)
+	})
+	assert.NoError(t, err)
+	return node
+}
+
+func TestCluster_Read(t *testing.T) {
+	asserts := assert.New(t)
+
+	var (
+		ctx      = context.Background()
+		primary  = newFakeDB(t, t.Name()+"/primary")
+		replica1 = newFakeDB(t, t.Name()+"/replica-1")
+		replica2 = newFakeDB(t, t.Name()+"/replica-2")
+		cluster  = db.NewCluster(logger.TestLogger, primary, []*sqlx.DB{replica1, replica2}, time.Hour)
+	)
+
+	reads := map[string]int{}
+	for i := 0; i < 4; i++ {
+		reads[readNode(ctx, t, cluster)]++
+	}
+
+	asserts.Equal(map[string]int{t.Name() + "/replica-1": 2, t.Name() + "/replica-2": 2}, reads)
+	asserts.Equal(t.Name()+"/primary", readNode(db.WithPrimary(ctx), t, cluster))
+}
+
+func TestCluster_ReadNoReplicas(t *testing.T) {
+	var (
+		primary = newFakeDB(t, t.Name()+"/primary")
+		cluster = db.NewCluster(logger.TestLogger, primary, nil, time.Hour)
+	)
+
+	assert.Equal(t, t.Name()+"/primary", readNode(context.Background(), t, cluster))
+}
+
+func TestCluster_ReadYourWrites(t *testing.T) {
+	asserts := assert.New(t)
+
+	var (
+		ctx     = db.WithSession(context.Background())
+		primary = newFakeDB(t, t.Name()+"/primary")
+		replica = newFakeDB(t, t.Name()+"/replica")
+		cluster = db.NewCluster(logger.TestLogger, primary, []*sqlx.DB{replica}, time.Hour)
+	)
+
+	asserts.Equal(t.Name()+"/replica", readNode(ctx, t, cluster))
+	asserts.Same(primary, cluster.Writer(ctx))
+	asserts.Equal(t.Name()+"/primary", readNode(ctx, t, cluster))
+
+	// Other sessions still read from the replica.
+	asserts.Equal(t.Name()+"/replica", readNode(db.WithSession(context.Background()), t, cluster))
+}
+
+func TestCluster_Fallback(t *testing.T) {
+	asserts := assert.New(t)
+
+	var (
+		ctx            = context.Background()
+		primary        = newFakeDB(t, t.Name()+"/primary")
+		replica        = newFakeDB(t, t.Name()+"/replica")
+		cluster        = db.NewCluster(logger.TestLogger, primary, []*sqlx.DB{replica}, 10*time.Millisecond)
+		runCtx, cancel = context.WithCancel(ctx)
+	)
+
+	downNodes.Store(t.Name()+"/replica", true)
+	asserts.Equal(t.Name()+"/primary", readNode(ctx, t, cluster))
+
+	// The replica is unhealthy until it's checked again.
+	downNodes.Delete(t.Name() + "/replica")
+	asserts.Equal(t.Name()+"/primary", readNode(ctx, t, cluster))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cluster.Run(runCtx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+
+	asserts.Eventually(func() bool {
+		return readNode(ctx, t, cluster) == t.Name()+"/replica"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -1,11 +1,14 @@
 package db
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
-
-	_ "github.com/lib/pq"
+	"strconv"
+	"time"
 )
 
 var ErrInvalidDSN = errors.New("db: dsn cannot be empty")
@@ -18,15 +21,173 @@ const (
 	UserPhoneNumber
 )
 
-// NewConnection attempt to create a database connection with the provided url
-func NewConnection(url string) (*sqlx.DB, error) {
+// The statement cache modes, which control how the queries are sent to the database. See pgx.QueryExecMode.
+const (
+	// StatementCacheModeStatement prepares each query once per connection and caches the prepared statement, so
+	// queries are executed in a single round trip afterwards.
+	StatementCacheModeStatement = "cache_statement"
+	// StatementCacheModeDescribe caches the description of each query per connection, so queries are executed in a
+	// single round trip afterwards without keeping prepared statements on the server.
+	StatementCacheModeDescribe = "cache_describe"
+	// StatementCacheModeDescribeExec describes each query before executing it, in two round trips.
+	StatementCacheModeDescribeExec = "describe_exec"
+	// StatementCacheModeExec executes each query with the extended protocol without describing it first. It's
+	// compatible with transaction pooling proxies such as PgBouncer.
+	StatementCacheModeExec = "exec"
+	// StatementCacheModeSimpleProtocol interpolates the arguments client side and uses the simple protocol.
+	StatementCacheModeSimpleProtocol = "simple_protocol"
+)
+
+var statementCacheModes = map[string]pgx.QueryExecMode{
+	StatementCacheModeStatement:      pgx.QueryExecModeCacheStatement,
+	StatementCacheModeDescribe:       pgx.QueryExecModeCacheDescribe,
+	StatementCacheModeDescribeExec:   pgx.QueryExecModeDescribeExec,
+	StatementCacheModeExec:           pgx.QueryExecModeExec,
+	StatementCacheModeSimpleProtocol: pgx.QueryExecModeSimpleProtocol,
+}
+
+// Config configures the connection pool. Zero values are replaced by the ones of DefaultConfig.
+type Config struct {
+	// MaxOpenConns is the maximum number of open connections.
+	MaxOpenConns int
+	// MaxIdleConns is the maximum number of idle connections kept in the pool.
+	MaxIdleConns int
+	// ConnMaxLifetime is the maximum amount of time a connection may be reused.
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime is the maximum amount of time a connection may be idle before being closed.
+	ConnMaxIdleTime time.Duration
+	// StatementCacheMode is one of the statement cache modes, such as StatementCacheModeStatement.
+	StatementCacheMode string
+	// StatementTimeout aborts the statements that take longer, server side.
+	StatementTimeout time.Duration
+}
+
+// DefaultConfig is the pool configuration used for the settings which aren't configured.
+var DefaultConfig = Config{
+	MaxOpenConns:       25,
+	MaxIdleConns:       10,
+	ConnMaxLifetime:    30 * time.Minute,
+	ConnMaxIdleTime:    5 * time.Minute,
+	StatementCacheMode: StatementCacheModeStatement,
+	StatementTimeout:   30 * time.Second,
+}
+
+// ParseConfig returns the pool configuration from the env values. Empty and zero values are replaced by the ones of
+// DefaultConfig.
+func ParseConfig(
+	maxOpenConns uint16,
+	maxIdleConns uint16,
+	connMaxLifetime string,
+	connMaxIdleTime string,
+	statementCacheMode string,
+	statementTimeout string,
+) (Config, error) {
+	cfg := Config{
+		MaxOpenConns:       int(maxOpenConns),
+		MaxIdleConns:       int(maxIdleConns),
+		StatementCacheMode: statementCacheMode,
+	}
+
+	durations := []struct {
+		name  string
+		value string
+		dest  *time.Duration
+	}{
+		{name: "connection max lifetime", value: connMaxLifetime, dest: &cfg.ConnMaxLifetime},
+		{name: "connection max idle time", value: connMaxIdleTime, dest: &cfg.ConnMaxIdleTime},
+		{name: "statement timeout", value: statementTimeout, dest: &cfg.StatementTimeout},
+	}
+
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			return Config{}, fmt.Errorf("db: invalid %s %q: %w", d.name, d.value, err)
+		}
+		*d.dest = v
+	}
+
+	if cfg.StatementCacheMode != "" {
+		if _, ok := statementCacheModes[cfg.StatementCacheMode]; !ok {
+			return Config{}, fmt.Errorf("db: unknown statement cache mode %q", cfg.StatementCacheMode)
+		}
+	}
+
+	return cfg.withDefaults(), nil
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxOpenConns == 0 {
+		c.MaxOpenConns = DefaultConfig.MaxOpenConns
+	}
+	if c.MaxIdleConns == 0 {
+		c.MaxIdleConns = DefaultConfig.MaxIdleConns
+	}
+	if c.MaxIdleConns > c.MaxOpenConns {
+		c.MaxIdleConns = c.MaxOpenConns
+	}
+	if c.ConnMaxLifetime == 0 {
+		c.ConnMaxLifetime = DefaultConfig.ConnMaxLifetime
+	}
+	if c.ConnMaxIdleTime == 0 {
+		c.ConnMaxIdleTime = DefaultConfig.ConnMaxIdleTime
+	}
+	if c.StatementCacheMode == "" {
+		c.StatementCacheMode = DefaultConfig.StatementCacheMode
+	}
+	if c.StatementTimeout == 0 {
+		c.StatementTimeout = DefaultConfig.StatementTimeout
+	}
+	return c
+}
+
+// NewConnection attempt to create a database connection pool with the provided url, using the pgx driver.
+// Statements are prepared and cached per connection according to the statement cache mode, so the repositories run
+// their queries directly instead of preparing them.
+func NewConnection(url string, cfg Config) (*sqlx.DB, error) {
+	db, err := open(url, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("new connection: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err = db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("new connection: %w", err)
+	}
+	return db, nil
+}
+
+// open returns a connection pool with the provided url without connecting to the database.
+func open(url string, cfg Config) (*sqlx.DB, error) {
 	if url == "" {
 		return nil, ErrInvalidDSN
 	}
 
-	db, err := sqlx.Connect("postgres", string(url))
+	cfg = cfg.withDefaults()
+
+	mode, ok := statementCacheModes[cfg.StatementCacheMode]
+	if !ok {
+		return nil, fmt.Errorf("unknown statement cache mode %q", cfg.StatementCacheMode)
+	}
+
+	connConfig, err := pgx.ParseConfig(url)
 	if err != nil {
-		return nil, fmt.Errorf("new connection: %w", err)
+		return nil, err
 	}
+
+	connConfig.DefaultQueryExecMode = mode
+	connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+
+	db := sqlx.NewDb(stdlib.OpenDB(*connConfig, stdlib.OptionAfterConnect(registerTypes)), "pgx")
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 	return db, nil
 }
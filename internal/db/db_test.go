@@ -0,0 +1,106 @@
+package db_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name               string
+		maxOpenConns       uint16
+		maxIdleConns       uint16
+		connMaxLifetime    string
+		connMaxIdleTime    string
+		statementCacheMode string
+		statementTimeout   string
+		want               db.Config
+		wantErr            bool
+	}{
+		{
+			name: "empty uses the defaults",
+			want: db.DefaultConfig,
+		},
+		{
+			name:               "configured",
+			maxOpenConns:       50,
+			maxIdleConns:       20,
+			connMaxLifetime:    "1h",
+			connMaxIdleTime:    "10m",
+			statementCacheMode: db.StatementCacheModeExec,
+			statementTimeout:   "5s",
+			want: db.Config{
+				MaxOpenConns:       50,
+				MaxIdleConns:       20,
+				ConnMaxLifetime:    time.Hour,
+				ConnMaxIdleTime:    10 * time.Minute,
+				StatementCacheMode: db.StatementCacheModeExec,
+				StatementTimeout:   5 * time.Second,
+			},
+		},
+		{
+			name:         "idle connections are capped to the open ones",
+			maxOpenConns: 4,
+			maxIdleConns: 8,
+			want: db.Config{
+				MaxOpenConns:       4,
+				MaxIdleConns:       4,
+				ConnMaxLifetime:    db.DefaultConfig.ConnMaxLifetime,
+				ConnMaxIdleTime:    db.DefaultConfig.ConnMaxIdleTime,
+				StatementCacheMode: db.DefaultConfig.StatementCacheMode,
+				StatementTimeout:   db.DefaultConfig.StatementTimeout,
+			},
+		},
+		{
+			name:             "invalid duration",
+			statementTimeout: "5",
+			wantErr:          true,
+		},
+		{
+			name:               "unknown statement cache mode",
+			statementCacheMode: "prepare",
+			wantErr:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := db.ParseConfig(
+				tt.maxOpenConns,
+				tt.maxIdleConns,
+				tt.connMaxLifetime,
+				tt.connMaxIdleTime,
+				tt.statementCacheMode,
+				tt.statementTimeout,
+			)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTryWrapEnumEncodePlan(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		m       = pgtype.NewMap()
+	)
+
+	m.TryWrapEncodePlanFuncs = append(
+		[]pgtype.TryWrapEncodePlanFunc{db.TryWrapEnumEncodePlan},
+		m.TryWrapEncodePlanFuncs...,
+	)
+
+	buf, err := m.Encode(pgtype.VarcharOID, pgtype.TextFormatCode, pb.User_SUSPENDED, nil)
+	asserts.NoError(err)
+	asserts.Equal("3", string(buf))
+}
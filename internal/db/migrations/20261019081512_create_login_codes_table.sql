@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS login_codes
+(
+    id          uuid primary key default gen_random_uuid(),
+    user_id     uuid        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    channel     varchar     NOT NULL,
+    code_hash   varchar     NOT NULL,
+    attempts    int         NOT NULL DEFAULT 0,
+    expires_at  timestamptz NOT NULL,
+    consumed_at timestamptz          DEFAULT NULL,
+    created_at  timestamptz          DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_codes_user_id_channel ON login_codes (user_id, channel);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS login_codes;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS role              varchar NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS status_reason     varchar     DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS status_expires_at timestamptz DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS user_status_history
+(
+    id          uuid primary key default gen_random_uuid(),
+    user_id     uuid    NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    from_status varchar NOT NULL,
+    to_status   varchar NOT NULL,
+    reason      varchar NOT NULL,
+    actor_id    uuid             DEFAULT NULL,
+    expires_at  timestamptz      DEFAULT NULL,
+    created_at  timestamptz      DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_status_history_user_id ON user_status_history (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_status_history;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS role,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status_expires_at;
+-- +goose StatementEnd
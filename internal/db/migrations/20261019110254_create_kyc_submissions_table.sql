@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS kyc_submissions
+(
+    id           uuid primary key default gen_random_uuid(),
+    user_id      uuid    NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    id_number    varchar NOT NULL,
+    kra_pin      varchar NOT NULL,
+    documents    jsonb   NOT NULL DEFAULT '[]'::jsonb,
+    status       varchar NOT NULL,
+    reviewer_id  uuid             DEFAULT NULL REFERENCES users (id),
+    review_notes varchar          DEFAULT NULL,
+    submitted_at timestamptz      DEFAULT current_timestamp,
+    reviewed_at  timestamptz      DEFAULT NULL,
+    updated_at   timestamptz      DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_kyc_submissions_user_id ON kyc_submissions (user_id);
+CREATE INDEX IF NOT EXISTS idx_kyc_submissions_status ON kyc_submissions (status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS kyc_submissions;
+-- +goose StatementEnd
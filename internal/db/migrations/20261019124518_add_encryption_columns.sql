@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS id_number_index        varchar DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS encryption_key_version integer NOT NULL DEFAULT 0;
+
+ALTER TABLE kyc_submissions
+    ADD COLUMN IF NOT EXISTS id_number_index        varchar DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS encryption_key_version integer NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_users_id_number_index ON users (id_number_index);
+CREATE INDEX IF NOT EXISTS idx_users_encryption_key_version ON users (encryption_key_version);
+CREATE INDEX IF NOT EXISTS idx_kyc_submissions_id_number_index ON kyc_submissions (id_number_index);
+CREATE INDEX IF NOT EXISTS idx_kyc_submissions_encryption_key_version ON kyc_submissions (encryption_key_version);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_kyc_submissions_encryption_key_version;
+DROP INDEX IF EXISTS idx_kyc_submissions_id_number_index;
+DROP INDEX IF EXISTS idx_users_encryption_key_version;
+DROP INDEX IF EXISTS idx_users_id_number_index;
+
+ALTER TABLE kyc_submissions
+    DROP COLUMN IF EXISTS encryption_key_version,
+    DROP COLUMN IF EXISTS id_number_index;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS encryption_key_version,
+    DROP COLUMN IF EXISTS id_number_index;
+-- +goose StatementEnd
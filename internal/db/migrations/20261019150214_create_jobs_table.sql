@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS jobs
+(
+    id           uuid primary key default gen_random_uuid(),
+    queue        varchar     NOT NULL,
+    type         varchar     NOT NULL,
+    payload      jsonb       NOT NULL DEFAULT '{}'::jsonb,
+    status       varchar     NOT NULL DEFAULT 'pending',
+    attempts     int         NOT NULL DEFAULT 0,
+    max_attempts int         NOT NULL,
+    last_error   varchar              DEFAULT NULL,
+    run_at       timestamptz NOT NULL DEFAULT current_timestamp,
+    locked_at    timestamptz          DEFAULT NULL,
+    created_at   timestamptz          DEFAULT current_timestamp,
+    updated_at   timestamptz          DEFAULT current_timestamp
+);
+
+-- Workers only look for pending jobs which are due on their queues.
+CREATE INDEX IF NOT EXISTS idx_jobs_queue_run_at ON jobs (queue, run_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_jobs_locked_at ON jobs (locked_at) WHERE status = 'running';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS jobs;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS outbox_events
+(
+    id             uuid primary key default gen_random_uuid(),
+    sequence       bigserial   NOT NULL UNIQUE,
+    aggregate_type varchar     NOT NULL,
+    aggregate_id   varchar     NOT NULL,
+    type           varchar     NOT NULL,
+    payload        jsonb       NOT NULL,
+    attempts       int         NOT NULL DEFAULT 0,
+    last_error     varchar              DEFAULT NULL,
+    created_at     timestamptz          DEFAULT current_timestamp,
+    published_at   timestamptz          DEFAULT NULL
+);
+
+-- The relay publishes the unpublished events in the order they were written.
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON outbox_events (sequence) WHERE published_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox_events;
+-- +goose StatementEnd
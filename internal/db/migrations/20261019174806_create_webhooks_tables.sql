@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS webhook_subscriptions
+(
+    id                   uuid primary key default gen_random_uuid(),
+    url                  varchar     NOT NULL,
+    event_types          varchar[]   NOT NULL,
+    secret               varchar     NOT NULL,
+    status               varchar     NOT NULL,
+    consecutive_failures int         NOT NULL DEFAULT 0,
+    created_by           uuid                 REFERENCES users (id) ON DELETE SET NULL,
+    created_at           timestamptz          DEFAULT current_timestamp,
+    updated_at           timestamptz          DEFAULT current_timestamp,
+    disabled_at          timestamptz          DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id              uuid primary key default gen_random_uuid(),
+    subscription_id uuid        NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id        uuid        NOT NULL,
+    event_type      varchar     NOT NULL,
+    payload         jsonb       NOT NULL,
+    status          varchar     NOT NULL,
+    attempts        int         NOT NULL DEFAULT 0,
+    response_status int                  DEFAULT NULL,
+    last_error      varchar              DEFAULT NULL,
+    replay_of       uuid                 REFERENCES webhook_deliveries (id) ON DELETE SET NULL,
+    delivered_at    timestamptz          DEFAULT NULL,
+    created_at      timestamptz          DEFAULT current_timestamp,
+    updated_at      timestamptz          DEFAULT current_timestamp
+);
+
+-- Events are relayed at least once, an event is only delivered once to every subscription unless it's replayed.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id_event_id
+    ON webhook_deliveries (subscription_id, event_id) WHERE replay_of IS NULL;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id_created_at
+    ON webhook_deliveries (subscription_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id          uuid primary key default gen_random_uuid(),
+    sequence    bigserial   NOT NULL UNIQUE,
+    actor_id    varchar     NOT NULL DEFAULT '',
+    actor_role  varchar     NOT NULL DEFAULT '',
+    action      varchar     NOT NULL,
+    target_type varchar     NOT NULL DEFAULT '',
+    target_id   varchar     NOT NULL DEFAULT '',
+    changes     jsonb       NOT NULL DEFAULT '[]',
+    method      varchar     NOT NULL DEFAULT '',
+    code        varchar     NOT NULL DEFAULT '',
+    request_id  varchar     NOT NULL DEFAULT '',
+    ip          varchar     NOT NULL DEFAULT '',
+    prev_hash   varchar     NOT NULL,
+    hash        varchar     NOT NULL,
+    created_at  timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events (actor_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events (target_type, target_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
+
+-- The audit trail is append-only, the hash chain detects changes made by bypassing the trigger.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE
+    ON audit_events
+    FOR EACH ROW
+EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE
+    ON audit_events
+    FOR EACH STATEMENT
+EXECUTE FUNCTION audit_events_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
+-- +goose StatementEnd
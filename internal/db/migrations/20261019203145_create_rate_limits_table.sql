@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS rate_limits
+(
+    key          varchar     NOT NULL,
+    window_start timestamptz NOT NULL,
+    count        int         NOT NULL DEFAULT 0,
+    expires_at   timestamptz NOT NULL,
+    PRIMARY KEY (key, window_start)
+);
+
+-- Expired windows are deleted periodically by the limiters.
+CREATE INDEX IF NOT EXISTS idx_rate_limits_expires_at ON rate_limits (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rate_limits;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    key           varchar primary key,
+    method        varchar     NOT NULL,
+    fingerprint   varchar     NOT NULL,
+    response_type varchar     NOT NULL DEFAULT '',
+    response      varchar              DEFAULT NULL,
+    completed_at  timestamptz          DEFAULT NULL,
+    created_at    timestamptz NOT NULL DEFAULT current_timestamp,
+    expires_at    timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
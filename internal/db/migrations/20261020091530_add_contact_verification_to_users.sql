@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The existing contacts were given at registration, like the contacts of the users created afterwards. Only the
+-- changed ones must be verified again.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email_verified        boolean NOT NULL DEFAULT true,
+    ADD COLUMN IF NOT EXISTS phone_number_verified boolean NOT NULL DEFAULT true;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_verified,
+    DROP COLUMN IF EXISTS phone_number_verified;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The calls in progress hold their key until locked_until, after which a retry can take it over.
+ALTER TABLE idempotency_keys
+    ADD COLUMN IF NOT EXISTS locked_until timestamptz DEFAULT NULL;
+
+UPDATE idempotency_keys
+SET locked_until = created_at
+WHERE completed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS locked_until;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strconv"
+)
+
+// registerTypes configures the type map of a new connection.
+func registerTypes(_ context.Context, conn *pgx.Conn) error {
+	m := conn.TypeMap()
+	m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{TryWrapEnumEncodePlan}, m.TryWrapEncodePlanFuncs...)
+	return nil
+}
+
+// TryWrapEnumEncodePlan encodes the protobuf enums as their numbers, the way database/sql converted them for lib/pq,
+// instead of their names since they implement fmt.Stringer.
+func TryWrapEnumEncodePlan(value any) (plan pgtype.WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	if e, ok := value.(protoreflect.Enum); ok {
+		return &enumEncodePlan{}, strconv.FormatInt(int64(e.Number()), 10), true
+	}
+	return nil, nil, false
+}
+
+type enumEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *enumEncodePlan) SetNext(next pgtype.EncodePlan) {
+	plan.next = next
+}
+
+func (plan *enumEncodePlan) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(strconv.FormatInt(int64(value.(protoreflect.Enum).Number()), 10), buf)
+}
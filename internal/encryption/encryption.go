@@ -0,0 +1,356 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidCiphertext is used when a value can't be parsed or authenticated.
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+	// ErrKeyVersionNotFound is used when a data key was wrapped by a key version the KeyManager doesn't have.
+	ErrKeyVersionNotFound = errors.New("key version not found")
+)
+
+const (
+	// ciphertextPrefix marks values encrypted by Cipher. Values without it are treated as legacy plaintext.
+	ciphertextPrefix = "enc:"
+
+	// DefaultDataKeyTTL is how long a data key is used for encryption before a new one is generated.
+	DefaultDataKeyTTL = 10 * time.Minute
+
+	// maxCachedDataKeys limits the number of unwrapped data keys kept in memory for decryption.
+	maxCachedDataKeys = 1024
+
+	keySize = 32
+)
+
+// DataKey is a data encryption key along with a copy wrapped by the KeyManager's key encryption key.
+type DataKey struct {
+	Plaintext  []byte
+	Ciphertext string
+}
+
+// KeyManager generates and unwraps data keys using a key encryption key that never leaves the manager.
+//
+// The methods mirror the Vault Transit secrets engine datakey, decrypt, keys and rotate endpoints. Wrapped data keys
+// use the Transit "<prefix>:v<version>:<payload>" format so the key version can be read without the manager.
+type KeyManager interface {
+	DecryptDataKey(ctx context.Context, ciphertext string) ([]byte, error)
+	GenerateDataKey(ctx context.Context) (*DataKey, error)
+	LatestVersion(ctx context.Context) (int, error)
+	Rotate(ctx context.Context) error
+}
+
+// Cipher encrypts individual fields using envelope encryption and computes blind indexes that allow exact lookups on
+// encrypted fields.
+//
+// Empty values are neither encrypted nor indexed. Decrypt returns values without the encryption prefix as they are
+// so rows written before encryption was enabled can still be read, and KeyVersion reports them as version 0 so they
+// are picked up by the Rotator.
+type Cipher interface {
+	BlindIndex(value string) string
+	Decrypt(ctx context.Context, value string) (string, error)
+	Encrypt(ctx context.Context, value string) (string, error)
+	KeyVersion(value string) int
+	LatestKeyVersion(ctx context.Context) (int, error)
+}
+
+type envelopeCipher struct {
+	dataKeyTTL time.Duration
+	indexKey   []byte
+	km         KeyManager
+
+	mu               sync.Mutex
+	current          *DataKey
+	currentExpiresAt time.Time
+	dataKeys         map[string][]byte
+}
+
+func (c *envelopeCipher) BlindIndex(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *envelopeCipher) Decrypt(ctx context.Context, value string) (string, error) {
+	if !strings.HasPrefix(value, ciphertextPrefix) {
+		return value, nil
+	}
+
+	wrappedKey, sealed, err := splitCiphertext(value)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := c.unwrap(ctx, wrappedKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(key, sealed)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func (c *envelopeCipher) Encrypt(ctx context.Context, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	dataKey, err := c.dataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := seal(dataKey.Plaintext, []byte(value))
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		encoding = base64.RawStdEncoding
+		b        strings.Builder
+	)
+
+	b.WriteString(ciphertextPrefix)
+	b.WriteString(encoding.EncodeToString([]byte(dataKey.Ciphertext)))
+	b.WriteString(":")
+	b.WriteString(encoding.EncodeToString(sealed))
+	return b.String(), nil
+}
+
+func (c *envelopeCipher) KeyVersion(value string) int {
+	if !strings.HasPrefix(value, ciphertextPrefix) {
+		return 0
+	}
+
+	wrappedKey, _, err := splitCiphertext(value)
+	if err != nil {
+		return 0
+	}
+
+	version, err := ParseKeyVersion(wrappedKey)
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+// LatestKeyVersion also drops the current data key if it was wrapped by an older version so values encrypted after
+// a rotation use the latest version without waiting for the data key to expire.
+func (c *envelopeCipher) LatestKeyVersion(ctx context.Context) (int, error) {
+	version, err := c.km.LatestVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current != nil {
+		if currentVersion, err := ParseKeyVersion(c.current.Ciphertext); err != nil || currentVersion < version {
+			c.current = nil
+		}
+	}
+
+	return version, nil
+}
+
+// dataKey returns the data key used for encryption, generating a new one once the current key expires.
+func (c *envelopeCipher) dataKey(ctx context.Context) (*DataKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current != nil && time.Now().Before(c.currentExpiresAt) {
+		return c.current, nil
+	}
+
+	dataKey, err := c.km.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("generate data key: %w", err)
+	}
+
+	c.current = dataKey
+	c.currentExpiresAt = time.Now().Add(c.dataKeyTTL)
+	c.cacheDataKey(dataKey.Ciphertext, dataKey.Plaintext)
+	return dataKey, nil
+}
+
+// unwrap returns the plaintext data key for a wrapped data key.
+func (c *envelopeCipher) unwrap(ctx context.Context, wrappedKey string) ([]byte, error) {
+	c.mu.Lock()
+	key, ok := c.dataKeys[wrappedKey]
+	c.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+
+	key, err := c.km.DecryptDataKey(ctx, wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt data key: %w", err)
+	}
+
+	c.mu.Lock()
+	c.cacheDataKey(wrappedKey, key)
+	c.mu.Unlock()
+	return key, nil
+}
+
+// cacheDataKey stores an unwrapped data key. The cache is cleared once it's full, which only happens after the data
+// key has been replaced maxCachedDataKeys times.
+func (c *envelopeCipher) cacheDataKey(wrappedKey string, key []byte) {
+	if len(c.dataKeys) >= maxCachedDataKeys {
+		c.dataKeys = make(map[string][]byte)
+	}
+	c.dataKeys[wrappedKey] = key
+}
+
+// ParseKeyVersion reads the key version from a wrapped data key in the "<prefix>:v<version>:<payload>" format.
+func ParseKeyVersion(ciphertext string) (int, error) {
+	parts := strings.SplitN(ciphertext, ":", 3)
+	if len(parts) != 3 || !strings.HasPrefix(parts[1], "v") {
+		return 0, ErrInvalidCiphertext
+	}
+
+	version, err := strconv.Atoi(strings.TrimPrefix(parts[1], "v"))
+	if err != nil || version < 1 {
+		return 0, ErrInvalidCiphertext
+	}
+
+	return version, nil
+}
+
+// splitCiphertext returns the wrapped data key and the sealed value of a Cipher encrypted value.
+func splitCiphertext(value string) (string, []byte, error) {
+	parts := strings.Split(strings.TrimPrefix(value, ciphertextPrefix), ":")
+	if len(parts) != 2 {
+		return "", nil, ErrInvalidCiphertext
+	}
+
+	encoding := base64.RawStdEncoding
+
+	wrappedKey, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return "", nil, ErrInvalidCiphertext
+	}
+
+	sealed, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, ErrInvalidCiphertext
+	}
+
+	return string(wrappedKey), sealed, nil
+}
+
+// seal encrypts the plaintext with AES-256-GCM and prepends the random nonce.
+func seal(key, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts a value produced by seal.
+func open(key, sealed []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// NewCipher creates a Cipher that wraps its data keys using the KeyManager and computes blind indexes with indexKey.
+func NewCipher(km KeyManager, indexKey []byte, dataKeyTTL time.Duration) (Cipher, error) {
+	if len(indexKey) < keySize {
+		return nil, fmt.Errorf("blind index key must be at least %d bytes", keySize)
+	}
+
+	if dataKeyTTL <= 0 {
+		dataKeyTTL = DefaultDataKeyTTL
+	}
+
+	return &envelopeCipher{
+		dataKeyTTL: dataKeyTTL,
+		dataKeys:   make(map[string][]byte),
+		indexKey:   indexKey,
+		km:         km,
+	}, nil
+}
+
+// NewConfiguredCipher creates the cipher used by the app for encrypting sensitive fields. The data keys are wrapped
+// using the Vault Transit key named transitKey, through the Vault at vaultAddr authenticated with vaultToken, if it's
+// set, otherwise using the keys keyring parsed by ParseKeys.
+func NewConfiguredCipher(vaultAddr, vaultToken, transitKey, keys, blindIndexKey string) (Cipher, error) {
+	indexKey, err := base64.StdEncoding.DecodeString(blindIndexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var km KeyManager
+	if transitKey != "" {
+		km, err = NewTransitKeyManager(vaultAddr, vaultToken, DefaultTransitMountPath, transitKey)
+	} else {
+		var keyring [][]byte
+		if keyring, err = ParseKeys(keys); err != nil {
+			return nil, err
+		}
+		km, err = NewLocalKeyManager(keyring...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCipher(km, indexKey, DefaultDataKeyTTL)
+}
@@ -0,0 +1,194 @@
+package encryption_test
+
+import (
+	"bridge/internal/encryption"
+	"bridge/internal/logger"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCipher_EncryptDecrypt(t *testing.T) {
+	var (
+		asserts   = assert.New(t)
+		ctx       = context.Background()
+		cipher, _ = encryption.NewTestCipher()
+		plaintext = "12345678"
+	)
+
+	ciphertext, err := cipher.Encrypt(ctx, plaintext)
+	asserts.NoError(err)
+	asserts.NotContains(ciphertext, plaintext)
+	asserts.Equal(1, cipher.KeyVersion(ciphertext))
+
+	// Every value uses a random nonce.
+	other, err := cipher.Encrypt(ctx, plaintext)
+	asserts.NoError(err)
+	asserts.NotEqual(ciphertext, other)
+
+	got, err := cipher.Decrypt(ctx, ciphertext)
+	asserts.NoError(err)
+	asserts.Equal(plaintext, got)
+
+	// A different cipher with the same keys can decrypt the value.
+	got, err = encryption.TestCipher.Decrypt(ctx, ciphertext)
+	asserts.NoError(err)
+	asserts.Equal(plaintext, got)
+
+	empty, err := cipher.Encrypt(ctx, "")
+	asserts.NoError(err)
+	asserts.Empty(empty)
+
+	legacy, err := cipher.Decrypt(ctx, plaintext)
+	asserts.NoError(err)
+	asserts.Equal(plaintext, legacy)
+	asserts.Zero(cipher.KeyVersion(plaintext))
+
+	tampered := ciphertext[:len(ciphertext)-2] + "AA"
+	_, err = cipher.Decrypt(ctx, tampered)
+	asserts.ErrorIs(err, encryption.ErrInvalidCiphertext)
+}
+
+func TestCipher_BlindIndex(t *testing.T) {
+	asserts := assert.New(t)
+
+	index := encryption.TestCipher.BlindIndex("a123456789z")
+	asserts.Len(index, 64)
+	asserts.Equal(index, encryption.TestCipher.BlindIndex(" A123456789Z "))
+	asserts.NotEqual(index, encryption.TestCipher.BlindIndex("A123456789Y"))
+	asserts.Empty(encryption.TestCipher.BlindIndex(""))
+}
+
+func TestCipher_Rotate(t *testing.T) {
+	var (
+		asserts    = assert.New(t)
+		ctx        = context.Background()
+		cipher, km = encryption.NewTestCipher()
+	)
+
+	v1, err := cipher.Encrypt(ctx, "12345678")
+	asserts.NoError(err)
+	asserts.Equal(1, cipher.KeyVersion(v1))
+
+	asserts.NoError(km.Rotate(ctx))
+
+	latestVersion, err := cipher.LatestKeyVersion(ctx)
+	asserts.NoError(err)
+	asserts.Equal(2, latestVersion)
+
+	v2, err := cipher.Encrypt(ctx, "12345678")
+	asserts.NoError(err)
+	asserts.Equal(2, cipher.KeyVersion(v2))
+
+	for _, ciphertext := range []string{v1, v2} {
+		got, err := cipher.Decrypt(ctx, ciphertext)
+		asserts.NoError(err)
+		asserts.Equal("12345678", got)
+	}
+
+	// TestCipher doesn't have the rotated key.
+	_, err = encryption.TestCipher.Decrypt(ctx, v2)
+	asserts.ErrorIs(err, encryption.ErrKeyVersionNotFound)
+}
+
+func TestNewLocalKeyManager(t *testing.T) {
+	asserts := assert.New(t)
+
+	_, err := encryption.NewLocalKeyManager()
+	asserts.Error(err)
+
+	_, err = encryption.NewLocalKeyManager([]byte("short"))
+	asserts.Error(err)
+
+	keys, err := encryption.ParseKeys(
+		"AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=, AgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgI=",
+	)
+	asserts.NoError(err)
+	asserts.Len(keys, 2)
+
+	km, err := encryption.NewLocalKeyManager(keys...)
+	asserts.NoError(err)
+
+	version, err := km.LatestVersion(context.Background())
+	asserts.NoError(err)
+	asserts.Equal(2, version)
+}
+
+func TestParseKeyVersion(t *testing.T) {
+	tests := []struct {
+		ciphertext string
+		version    int
+		wantErr    bool
+	}{
+		{ciphertext: "vault:v1:abc", version: 1},
+		{ciphertext: "local:v12:abc", version: 12},
+		{ciphertext: "vault:1:abc", wantErr: true},
+		{ciphertext: "vault:v0:abc", wantErr: true},
+		{ciphertext: "plaintext", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.ciphertext, func(t *testing.T) {
+			t.Parallel()
+
+			version, err := encryption.ParseKeyVersion(tt.ciphertext)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, encryption.ErrInvalidCiphertext)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+type testReencrypter struct {
+	remaining int
+	versions  []int
+	err       error
+}
+
+func (r *testReencrypter) Reencrypt(_ context.Context, latestVersion, limit int) (int, error) {
+	r.versions = append(r.versions, latestVersion)
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	n := limit
+	if r.remaining < limit {
+		n = r.remaining
+	}
+
+	r.remaining -= n
+	return n, nil
+}
+
+func TestRotator_Reencrypt(t *testing.T) {
+	var (
+		asserts    = assert.New(t)
+		ctx        = context.Background()
+		cipher, km = encryption.NewTestCipher()
+		users      = &testReencrypter{remaining: 25}
+		kyc        = &testReencrypter{remaining: 10}
+		rotator    = encryption.NewRotator(logger.TestLogger, cipher, 0, 10, users, kyc)
+	)
+
+	asserts.NoError(km.Rotate(ctx))
+
+	n, err := rotator.Reencrypt(ctx)
+	asserts.NoError(err)
+	asserts.Equal(35, n)
+	asserts.Zero(users.remaining)
+	asserts.Zero(kyc.remaining)
+	asserts.Equal([]int{2, 2, 2}, users.versions)
+	asserts.Equal([]int{2, 2}, kyc.versions)
+
+	failing := &testReencrypter{err: errors.New("connection reset")}
+	rotator = encryption.NewRotator(logger.TestLogger, cipher, 0, 10, failing)
+
+	_, err = rotator.Reencrypt(ctx)
+	asserts.EqualError(err, "connection reset")
+}
@@ -0,0 +1,140 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// localPrefix is used for data keys wrapped by the local KeyManager, in place of Vault Transit's "vault" prefix.
+const localPrefix = "local"
+
+// localKeyManager keeps a keyring of key encryption keys in memory. The key at index i is version i+1 and the last
+// key is used for wrapping new data keys.
+type localKeyManager struct {
+	mu   sync.RWMutex
+	keys [][]byte
+}
+
+func (m *localKeyManager) DecryptDataKey(_ context.Context, ciphertext string) ([]byte, error) {
+	version, err := ParseKeyVersion(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(ciphertext, ":", 3)
+	if parts[0] != localPrefix {
+		return nil, ErrInvalidCiphertext
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if version > len(m.keys) {
+		return nil, ErrKeyVersionNotFound
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return open(m.keys[version-1], sealed)
+}
+
+func (m *localKeyManager) GenerateDataKey(_ context.Context) (*DataKey, error) {
+	plaintext := make([]byte, keySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	version := len(m.keys)
+
+	sealed, err := seal(m.keys[version-1], plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataKey{
+		Plaintext:  plaintext,
+		Ciphertext: fmt.Sprintf("%s:v%d:%s", localPrefix, version, base64.StdEncoding.EncodeToString(sealed)),
+	}, nil
+}
+
+func (m *localKeyManager) LatestVersion(_ context.Context) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.keys), nil
+}
+
+// Rotate adds a random key to the keyring. The key only lives in memory so it's meant for tests, production keyrings
+// are rotated by appending a key to the configured keys.
+func (m *localKeyManager) Rotate(_ context.Context) error {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys = append(m.keys, key)
+	return nil
+}
+
+// ParseKeys decodes a comma separated list of base64 encoded 32 byte keys, ordered from the oldest to the latest.
+func ParseKeys(s string) ([][]byte, error) {
+	var keys [][]byte
+	for _, encoded := range strings.Split(s, ",") {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("decode key: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// NewLocalKeyManager creates a KeyManager using the provided keys, ordered from the oldest to the latest.
+func NewLocalKeyManager(keys ...[]byte) (KeyManager, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key is required")
+	}
+
+	for i, key := range keys {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("key version %d must be %d bytes", i+1, keySize)
+		}
+	}
+
+	return &localKeyManager{keys: keys}, nil
+}
+
+// TestCipher is a Cipher backed by a local KeyManager with fixed keys, used for tests.
+var TestCipher, _ = NewTestCipher()
+
+// NewTestCipher creates a Cipher using the same keys as TestCipher along with its KeyManager so tests can rotate
+// the key without affecting TestCipher.
+func NewTestCipher() (Cipher, KeyManager) {
+	km, err := NewLocalKeyManager(bytes.Repeat([]byte{1}, keySize))
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := NewCipher(km, bytes.Repeat([]byte{2}, keySize), DefaultDataKeyTTL)
+	if err != nil {
+		panic(err)
+	}
+
+	return c, km
+}
@@ -0,0 +1,112 @@
+package encryption
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+const (
+	// DefaultRotationInterval is how often the Rotator checks for rows encrypted with an old key version.
+	DefaultRotationInterval = time.Hour
+
+	// DefaultRotationBatchSize is the number of rows re-encrypted per query.
+	DefaultRotationBatchSize = 100
+)
+
+// Reencrypter re-encrypts at most limit rows whose encrypted fields use a key version older than latestVersion and
+// returns the number of rows updated.
+type Reencrypter interface {
+	Reencrypt(ctx context.Context, latestVersion, limit int) (int, error)
+}
+
+// Rotator re-encrypts data in the background once the key encryption key is rotated.
+//
+// Run blocks until ctx is cancelled, calling Reencrypt every interval. Reencrypt processes all the targets in batches
+// until none of them have rows left on an older key version and returns the number of rows updated.
+type Rotator interface {
+	Reencrypt(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
+
+type rotator struct {
+	batchSize int
+	cipher    Cipher
+	interval  time.Duration
+	l         zerolog.Logger
+	targets   []Reencrypter
+}
+
+func (r *rotator) Reencrypt(ctx context.Context) (int, error) {
+	latestVersion, err := r.cipher.LatestKeyVersion(ctx)
+	if err != nil {
+		r.l.Err(err).Msg("get latest key version")
+		return 0, err
+	}
+
+	l := r.l.With().Int("latest_version", latestVersion).Logger()
+
+	var total int
+	for _, target := range r.targets {
+		for {
+			n, err := target.Reencrypt(ctx, latestVersion, r.batchSize)
+			total += n
+
+			if err != nil {
+				l.Err(err).Int("reencrypted", total).Msg("reencrypt batch")
+				return total, err
+			}
+
+			if n < r.batchSize {
+				break
+			}
+		}
+	}
+
+	if total > 0 {
+		l.Info().Int("reencrypted", total).Msg("completed successfully")
+	}
+
+	return total, nil
+}
+
+func (r *rotator) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = r.Reencrypt(ctx)
+
+		select {
+		case <-ctx.Done():
+			r.l.Info().Msg("stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NewRotator creates a Rotator for the provided targets.
+func NewRotator(
+	l zerolog.Logger,
+	cipher Cipher,
+	interval time.Duration,
+	batchSize int,
+	targets ...Reencrypter,
+) Rotator {
+	if interval <= 0 {
+		interval = DefaultRotationInterval
+	}
+
+	if batchSize <= 0 {
+		batchSize = DefaultRotationBatchSize
+	}
+
+	return &rotator{
+		batchSize: batchSize,
+		cipher:    cipher,
+		interval:  interval,
+		l:         l.With().Str("action", "rotate encryption key").Logger(),
+		targets:   targets,
+	}
+}
@@ -0,0 +1,132 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	vault "github.com/hashicorp/vault/api"
+	"strconv"
+)
+
+// DefaultTransitMountPath is the path the Vault Transit secrets engine is mounted on by default.
+const DefaultTransitMountPath = "transit"
+
+// transitKeyManager wraps data keys using a named key in the Vault Transit secrets engine.
+type transitKeyManager struct {
+	key       string
+	logical   *vault.Logical
+	mountPath string
+}
+
+func (m *transitKeyManager) DecryptDataKey(ctx context.Context, ciphertext string) ([]byte, error) {
+	secret, err := m.logical.WriteWithContext(ctx, m.path("decrypt", m.key), map[string]interface{}{
+		"ciphertext": ciphertext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting data key - %w", err)
+	}
+
+	plaintext, err := stringField(secret, "plaintext")
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(plaintext)
+}
+
+func (m *transitKeyManager) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+	secret, err := m.logical.WriteWithContext(ctx, m.path("datakey", "plaintext", m.key), map[string]interface{}{
+		"bits": keySize * 8,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error generating data key - %w", err)
+	}
+
+	encoded, err := stringField(secret, "plaintext")
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := stringField(secret, "ciphertext")
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataKey{Plaintext: plaintext, Ciphertext: ciphertext}, nil
+}
+
+func (m *transitKeyManager) LatestVersion(ctx context.Context) (int, error) {
+	secret, err := m.logical.ReadWithContext(ctx, m.path("keys", m.key))
+	if err != nil {
+		return 0, fmt.Errorf("error reading key - %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return 0, fmt.Errorf("key %q not found", m.key)
+	}
+
+	switch v := secret.Data["latest_version"].(type) {
+	case json.Number:
+		version, err := v.Int64()
+		return int(version), err
+	case float64:
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("unexpected latest_version type %T", v)
+	}
+}
+
+func (m *transitKeyManager) Rotate(ctx context.Context) error {
+	if _, err := m.logical.WriteWithContext(ctx, m.path("keys", m.key, "rotate"), nil); err != nil {
+		return fmt.Errorf("error rotating key - %w", err)
+	}
+	return nil
+}
+
+func (m *transitKeyManager) path(segments ...string) string {
+	path := m.mountPath
+	for _, segment := range segments {
+		path += "/" + segment
+	}
+	return path
+}
+
+func stringField(secret *vault.Secret, key string) (string, error) {
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("empty response from vault")
+	}
+
+	v, ok := secret.Data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("%q in vault response is not a string", key)
+	}
+
+	return v, nil
+}
+
+// NewTransitKeyManager creates a KeyManager backed by the key named key on the Transit engine mounted on mountPath.
+func NewTransitKeyManager(addr, token, mountPath, key string) (KeyManager, error) {
+	vaultConfig := vault.DefaultConfig()
+	vaultConfig.Address = addr
+
+	client, err := vault.NewClient(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating vault client - %w", err)
+	}
+
+	client.SetToken(token)
+
+	return &transitKeyManager{
+		key:       key,
+		logical:   client.Logical(),
+		mountPath: mountPath,
+	}, nil
+}
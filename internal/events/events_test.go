@@ -0,0 +1,152 @@
+package events_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/events"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryOutbox is an in-memory repository.Outbox.
+type memoryOutbox struct {
+	mu        sync.Mutex
+	events    []*models.OutboxEvent
+	published map[string]bool
+}
+
+func (o *memoryOutbox) Publish(ctx context.Context, limit int, publish repository.PublishFunc) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var n int
+	for _, event := range o.events {
+		if n == limit {
+			break
+		}
+
+		if o.published[event.ID] {
+			continue
+		}
+
+		if err := publish(ctx, event); err != nil {
+			return n, err
+		}
+
+		o.published[event.ID] = true
+		n++
+	}
+
+	return n, nil
+}
+
+func newMemoryOutbox(n int) *memoryOutbox {
+	o := &memoryOutbox{published: make(map[string]bool)}
+
+	for i := 1; i <= n; i++ {
+		event, err := models.NewUserEvent(models.EventUserUpdated, &pb.User{ID: "42"})
+		if err != nil {
+			panic(err)
+		}
+
+		event.ID = strconv.Itoa(i)
+		event.Sequence = int64(i)
+		o.events = append(o.events, event)
+	}
+
+	return o
+}
+
+func TestRelay_Run(t *testing.T) {
+	var (
+		asserts   = assert.New(t)
+		outbox    = newMemoryOutbox(5)
+		publisher = events.NewChannelPublisher(10)
+		relay     = events.NewRelay(logger.TestLogger, outbox, publisher, time.Millisecond, 2)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+
+	for i := 1; i <= 5; i++ {
+		select {
+		case event := <-publisher.Events():
+			asserts.Equal(int64(i), event.Sequence)
+		case <-time.After(time.Second):
+			t.Fatal("event was not published")
+		}
+	}
+
+	cancel()
+	<-done
+
+	asserts.NoError(publisher.Close())
+	asserts.Error(publisher.Publish(context.Background(), outbox.events[0]))
+}
+
+func TestWriterPublisher(t *testing.T) {
+	var (
+		asserts   = assert.New(t)
+		buf       = &bytes.Buffer{}
+		publisher = events.NewWriterPublisher(buf)
+		event     = &models.OutboxEvent{
+			ID:            "1",
+			Sequence:      1,
+			AggregateType: models.AggregateUser,
+			AggregateID:   "42",
+			Type:          models.EventUserRegistered,
+			Payload:       json.RawMessage(`{"id":"42"}`),
+		}
+	)
+
+	asserts.NoError(publisher.Publish(context.Background(), event))
+	asserts.NoError(publisher.Close())
+
+	var envelope events.Envelope
+	asserts.NoError(json.NewDecoder(buf).Decode(&envelope))
+	asserts.Equal("user.registered", envelope.Type)
+	asserts.Equal("42", envelope.AggregateID)
+	asserts.JSONEq(`{"id":"42"}`, string(envelope.Payload))
+}
+
+func TestNewPublisher(t *testing.T) {
+	asserts := assert.New(t)
+
+	publisher, err := events.NewPublisher(events.Config{}, logger.TestLogger)
+	asserts.NoError(err)
+	asserts.NotNil(publisher)
+
+	_, err = events.NewPublisher(events.Config{Publisher: "carrier-pigeon"}, logger.TestLogger)
+	asserts.ErrorIs(err, events.ErrUnknownPublisher)
+}
+
+func TestMultiPublisher(t *testing.T) {
+	var (
+		asserts   = assert.New(t)
+		first     = events.NewChannelPublisher(1)
+		second    = events.NewChannelPublisher(1)
+		publisher = events.NewMultiPublisher(first, second)
+		event     = &models.OutboxEvent{ID: "1", Type: models.EventUserRegistered}
+	)
+
+	asserts.NoError(publisher.Publish(context.Background(), event))
+	asserts.Equal(event, <-first.Events())
+	asserts.Equal(event, <-second.Events())
+
+	asserts.NoError(publisher.Close())
+	asserts.Error(first.Publish(context.Background(), event))
+	asserts.Error(second.Publish(context.Background(), event))
+}
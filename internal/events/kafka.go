@@ -0,0 +1,59 @@
+//go:build kafka
+
+package events
+
+import (
+	"bridge/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func init() {
+	publisherFactories[PublisherKafka] = newKafkaPublisher
+}
+
+type kafkaPublisher struct {
+	w *kafka.Writer
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	data, err := json.Marshal(NewEnvelope(event))
+	if err != nil {
+		return err
+	}
+
+	// The events of an aggregate share a key so that they end up on the same partition, in order.
+	return p.w.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.AggregateType + ":" + event.AggregateID),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: "event_id", Value: []byte(event.ID)},
+			{Key: "event_type", Value: []byte(event.Type)},
+		},
+	})
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.w.Close()
+}
+
+func newKafkaPublisher(cfg Config) (Publisher, error) {
+	if cfg.Addr == "" || cfg.Topic == "" {
+		return nil, errors.New("events: kafka requires the brokers and the topic")
+	}
+
+	return &kafkaPublisher{
+		w: &kafka.Writer{
+			Addr:         kafka.TCP(strings.Split(cfg.Addr, ",")...),
+			Topic:        cfg.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			// Messages are written one at a time so that a failed event doesn't affect the others.
+			BatchSize: 1,
+		},
+	}, nil
+}
@@ -0,0 +1,62 @@
+//go:build nats
+
+package events
+
+import (
+	"bridge/internal/models"
+	"context"
+	"encoding/json"
+
+	"github.com/nats-io/nats.go"
+)
+
+func init() {
+	publisherFactories[PublisherNATS] = newNATSPublisher
+}
+
+type natsPublisher struct {
+	conn   *nats.Conn
+	prefix string
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	data, err := json.Marshal(NewEnvelope(event))
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(p.prefix + "." + string(event.Type))
+	msg.Data = data
+	// JetStream discards messages with an ID it has already stored.
+	msg.Header.Set(nats.MsgIdHdr, event.ID)
+
+	if err = p.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+
+	// The event is only published once the server has received it.
+	return p.conn.FlushWithContext(ctx)
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
+
+func newNATSPublisher(cfg Config) (Publisher, error) {
+	addr := cfg.Addr
+	if addr == "" {
+		addr = nats.DefaultURL
+	}
+
+	conn, err := nats.Connect(addr, nats.Name("bridge-outbox-relay"))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := cfg.Topic
+	if prefix == "" {
+		prefix = "bridge.events"
+	}
+
+	return &natsPublisher{conn: conn, prefix: prefix}, nil
+}
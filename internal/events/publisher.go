@@ -0,0 +1,228 @@
+package events
+
+import (
+	"bridge/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Supported publishers.
+const (
+	// PublisherLog logs the events without their payload. It's used when no publisher is configured.
+	PublisherLog = "log"
+	// PublisherFile appends the events as JSON lines to the file at Config.Addr.
+	PublisherFile = "file"
+	// PublisherNATS publishes the events to the NATS server at Config.Addr. It requires the nats build tag.
+	PublisherNATS = "nats"
+	// PublisherKafka publishes the events to the comma separated Kafka brokers at Config.Addr. It requires the kafka
+	// build tag.
+	PublisherKafka = "kafka"
+)
+
+// ErrUnknownPublisher is returned when the configured publisher isn't supported or wasn't built into the binary.
+var ErrUnknownPublisher = errors.New("events: unknown publisher")
+
+// Publisher delivers outbox events to the rest of the platform. Publish must only return nil once the event has been
+// accepted, since the event is then marked as published and never retried.
+type Publisher interface {
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+	Close() error
+}
+
+// Config configures the Publisher created by NewPublisher.
+type Config struct {
+	// Publisher is one of the supported publishers, PublisherLog is used if it's empty.
+	Publisher string
+	// Addr is the file path or the broker address, depending on the publisher.
+	Addr string
+	// Topic is the Kafka topic, or the prefix of the NATS subjects which are suffixed with the event type.
+	Topic string
+}
+
+// publisherFactory creates a Publisher. The broker adapters register theirs when they're built in.
+type publisherFactory func(cfg Config) (Publisher, error)
+
+var publisherFactories = map[string]publisherFactory{}
+
+// NewPublisher creates the Publisher set in cfg.
+func NewPublisher(cfg Config, l zerolog.Logger) (Publisher, error) {
+	switch name := strings.ToLower(cfg.Publisher); name {
+	case "", PublisherLog:
+		return NewLogPublisher(l), nil
+	case PublisherFile:
+		f, err := os.OpenFile(cfg.Addr, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("events: open file: %w", err)
+		}
+		return NewWriterPublisher(f), nil
+	default:
+		factory, ok := publisherFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownPublisher, cfg.Publisher)
+		}
+		return factory(cfg)
+	}
+}
+
+// Envelope is the message published for an event. The ID can be used by consumers to discard duplicates since events
+// are delivered at least once.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Sequence      int64           `json:"sequence"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// NewEnvelope wraps event for publishing.
+func NewEnvelope(event *models.OutboxEvent) Envelope {
+	return Envelope{
+		ID:            event.ID,
+		Type:          string(event.Type),
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Sequence:      event.Sequence,
+		OccurredAt:    event.CreatedAt,
+		Payload:       event.Payload,
+	}
+}
+
+type multiPublisher struct {
+	publishers []Publisher
+}
+
+func (p *multiPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *multiPublisher) Close() error {
+	var firstErr error
+	for _, publisher := range p.publishers {
+		if err := publisher.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// NewMultiPublisher creates a Publisher which publishes the events to every one of publishers in turn. An event is
+// published again to all of them if one fails, so they must tolerate duplicates.
+func NewMultiPublisher(publishers ...Publisher) Publisher {
+	return &multiPublisher{publishers: publishers}
+}
+
+type logPublisher struct {
+	l zerolog.Logger
+}
+
+func (p *logPublisher) Publish(_ context.Context, event *models.OutboxEvent) error {
+	p.l.Info().
+		Str("event_id", event.ID).
+		Str("type", string(event.Type)).
+		Str("aggregate_type", event.AggregateType).
+		Str("aggregate_id", event.AggregateID).
+		Int64("sequence", event.Sequence).
+		Msg("event published")
+	return nil
+}
+
+func (p *logPublisher) Close() error {
+	return nil
+}
+
+// NewLogPublisher creates a Publisher which logs the events. The payloads aren't logged since they contain PII.
+func NewLogPublisher(l zerolog.Logger) Publisher {
+	return &logPublisher{l: l.With().Str("component", "events").Logger()}
+}
+
+type writerPublisher struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	w   io.Writer
+}
+
+func (p *writerPublisher) Publish(_ context.Context, event *models.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.enc.Encode(NewEnvelope(event))
+}
+
+func (p *writerPublisher) Close() error {
+	if c, ok := p.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// NewWriterPublisher creates a Publisher which writes the event envelopes to w as JSON lines. w is closed by Close if
+// it's an io.Closer.
+func NewWriterPublisher(w io.Writer) Publisher {
+	return &writerPublisher{enc: json.NewEncoder(w), w: w}
+}
+
+// ChannelPublisher publishes the events to a channel for consumers running in the same process.
+type ChannelPublisher interface {
+	Publisher
+	// Events returns the channel the events are sent to. It's closed by Close.
+	Events() <-chan *models.OutboxEvent
+}
+
+type channelPublisher struct {
+	mu     sync.RWMutex
+	closed bool
+	events chan *models.OutboxEvent
+}
+
+func (p *channelPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return errors.New("events: publisher is closed")
+	}
+
+	select {
+	case p.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *channelPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.closed {
+		p.closed = true
+		close(p.events)
+	}
+	return nil
+}
+
+func (p *channelPublisher) Events() <-chan *models.OutboxEvent {
+	return p.events
+}
+
+// NewChannelPublisher creates a ChannelPublisher whose channel buffers size events. Publish blocks while the buffer
+// is full.
+func NewChannelPublisher(size int) ChannelPublisher {
+	return &channelPublisher{events: make(chan *models.OutboxEvent, size)}
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"bridge/internal/repository"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// DefaultRelayInterval is how long the relay waits before looking for new events once the outbox is drained.
+	DefaultRelayInterval = time.Second
+	// DefaultRelayBatchSize is the number of events published in a single transaction.
+	DefaultRelayBatchSize = 100
+)
+
+// Relay publishes the events written to the outbox.
+type Relay interface {
+	// Run publishes the events until ctx is done. Events are delivered at least once and in order per aggregate.
+	Run(ctx context.Context)
+}
+
+type relay struct {
+	batchSize int
+	interval  time.Duration
+	l         zerolog.Logger
+	publisher Publisher
+	repo      repository.Outbox
+}
+
+func (r *relay) Run(ctx context.Context) {
+	l := r.l.With().Str("action", "run").Logger()
+
+	for {
+		n, err := r.repo.Publish(ctx, r.batchSize, r.publisher.Publish)
+		if err != nil && ctx.Err() == nil {
+			l.Err(err).Msg("failed to publish events")
+		}
+
+		// A full batch means there are probably more events waiting.
+		if err == nil && n == r.batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// NewRelay creates a Relay publishing the events in repo using publisher. The defaults are used if interval or
+// batchSize are zero.
+func NewRelay(
+	l zerolog.Logger,
+	repo repository.Outbox,
+	publisher Publisher,
+	interval time.Duration,
+	batchSize int,
+) Relay {
+	if interval <= 0 {
+		interval = DefaultRelayInterval
+	}
+
+	if batchSize <= 0 {
+		batchSize = DefaultRelayBatchSize
+	}
+
+	return &relay{
+		batchSize: batchSize,
+		interval:  interval,
+		l:         l.With().Str("component", "outbox relay").Logger(),
+		publisher: publisher,
+		repo:      repo,
+	}
+}
@@ -3,12 +3,15 @@ package factory
 import (
 	"bridge/api/v1/pb"
 	cryptorand "crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"github.com/jaswdr/faker"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"log"
 	"math/rand"
+	"strings"
 	"time"
 )
 
@@ -40,10 +43,45 @@ func NewUser() *pb.User {
 		Meta: &pb.UserMeta{
 			KycData: &pb.KYCData{
 				IdNumber: f.Numerify("#######"),
-				KraPin:   f.RandomStringWithLength(8),
+				KraPin:   NewKraPin(f),
 			},
 		},
-		CreatedAt: timestamppb.New(time.Now()),
-		UpdatedAt: timestamppb.New(time.Now()),
+		CreatedAt:           timestamppb.New(time.Now()),
+		UpdatedAt:           timestamppb.New(time.Now()),
+		EmailVerified:       true,
+		PhoneNumberVerified: true,
+	}
+}
+
+// NewKraPin generates a KRA PIN in the format issued to individuals e.g. A123456789B.
+func NewKraPin(f faker.Faker) string {
+	return "A" + f.Numerify("#########") + strings.ToUpper(f.Lexify("?"))
+}
+
+func NewKYCDocument(docType pb.KYCDocument_Type) *pb.KYCDocument {
+	f := faker.NewWithSeed(rand.NewSource(Seed()))
+	checksum := sha256.Sum256([]byte(f.UUID().V4()))
+
+	return &pb.KYCDocument{
+		Type:        docType,
+		FileUrl:     "https://storage.example.com/kyc/" + f.UUID().V4() + ".jpg",
+		ContentType: "image/jpeg",
+		SizeBytes:   int64(f.IntBetween(1024, 1024*1024)),
+		Sha256:      hex.EncodeToString(checksum[:]),
+	}
+}
+
+func NewKYCSubmission(userID string) *pb.KYCSubmission {
+	f := faker.NewWithSeed(rand.NewSource(Seed()))
+
+	return &pb.KYCSubmission{
+		UserId:   userID,
+		IdNumber: f.Numerify("########"),
+		KraPin:   NewKraPin(f),
+		Documents: []*pb.KYCDocument{
+			NewKYCDocument(pb.KYCDocument_NATIONAL_ID_FRONT),
+			NewKYCDocument(pb.KYCDocument_NATIONAL_ID_BACK),
+		},
+		Status: pb.KYCSubmission_SUBMITTED,
 	}
 }
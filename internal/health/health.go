@@ -0,0 +1,211 @@
+package health
+
+import (
+	"bridge/services/auth"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// DefaultCheckInterval is how often the dependencies are checked for updating the grpc serving status.
+	DefaultCheckInterval = 10 * time.Second
+	// DefaultCheckTimeout is how long a single dependency check can take before it's considered failed.
+	DefaultCheckTimeout = 2 * time.Second
+)
+
+const (
+	statusOK           = "ok"
+	statusUnavailable  = "unavailable"
+	statusShuttingDown = "shutting down"
+)
+
+// CheckFunc reports whether a dependency such as the database is available.
+type CheckFunc func(ctx context.Context) error
+
+// Checker tracks the health of the app.
+//
+// The grpc.health.v1 service is served by GrpcServer with a status for every registered service. The services are
+// NOT_SERVING while any check is failing and after Shutdown is called.
+//
+// LivenessHandler serves /healthz and reports whether the process is up. ReadinessHandler serves /readyz and runs
+// the checks, responding with 503 if any of them fails or the app is shutting down.
+type Checker interface {
+	GrpcServer() healthpb.HealthServer
+	LivenessHandler() http.Handler
+	ReadinessHandler() http.Handler
+	// Run checks the dependencies every interval and updates the grpc serving status until ctx is done.
+	Run(ctx context.Context, interval time.Duration)
+	// Shutdown marks every service as NOT_SERVING so that clients and load balancers stop sending new requests
+	// while the open connections drain. The status can't change afterwards.
+	Shutdown()
+}
+
+// grpcServer is the grpc.health.v1 server. Health checks don't require authentication.
+type grpcServer struct {
+	*grpchealth.Server
+	auth.OverrideAuthFunc
+}
+
+type checker struct {
+	checks       map[string]CheckFunc
+	l            zerolog.Logger
+	services     []string
+	srv          *grpcServer
+	shuttingDown atomic.Bool
+	timeout      time.Duration
+}
+
+type response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func writeResponse(w http.ResponseWriter, code int, res response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// runChecks runs the checks concurrently and returns the failed ones.
+func (c *checker) runChecks(ctx context.Context) map[string]error {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed = make(map[string]error)
+	)
+
+	for name, check := range c.checks {
+		name, check := name, check
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			if err := check(checkCtx); err != nil {
+				mu.Lock()
+				failed[name] = err
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return failed
+}
+
+func (c *checker) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	c.srv.SetServingStatus("", status)
+	for _, service := range c.services {
+		c.srv.SetServingStatus(service, status)
+	}
+}
+
+// update runs the checks and updates the grpc serving status.
+func (c *checker) update(ctx context.Context) {
+	l := c.l.With().Str("action", "update serving status").Logger()
+
+	failed := c.runChecks(ctx)
+	for name, err := range failed {
+		l.Err(err).Str("check", name).Msg("check failed")
+	}
+
+	if len(failed) > 0 {
+		c.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		return
+	}
+
+	c.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+}
+
+func (c *checker) GrpcServer() healthpb.HealthServer {
+	return c.srv
+}
+
+func (c *checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, http.StatusOK, response{Status: statusOK})
+	})
+}
+
+func (c *checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.shuttingDown.Load() {
+			writeResponse(w, http.StatusServiceUnavailable, response{Status: statusShuttingDown})
+			return
+		}
+
+		var (
+			l      = c.l.With().Str("action", "readiness check").Logger()
+			failed = c.runChecks(r.Context())
+			res    = response{Status: statusOK, Checks: make(map[string]string, len(c.checks))}
+			code   = http.StatusOK
+		)
+
+		for name := range c.checks {
+			err, ok := failed[name]
+			if !ok {
+				res.Checks[name] = statusOK
+				continue
+			}
+
+			// The error isn't returned since the endpoint isn't authenticated.
+			l.Err(err).Str("check", name).Msg("check failed")
+			res.Checks[name] = statusUnavailable
+			res.Status = statusUnavailable
+			code = http.StatusServiceUnavailable
+		}
+
+		writeResponse(w, code, res)
+	})
+}
+
+func (c *checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if !c.shuttingDown.Load() {
+			c.update(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *checker) Shutdown() {
+	c.shuttingDown.Store(true)
+	c.srv.Shutdown()
+}
+
+// NewChecker creates a new Checker reporting the status of services. The services start as SERVING.
+func NewChecker(l zerolog.Logger, services []string, checks map[string]CheckFunc) Checker {
+	services = append([]string(nil), services...)
+	sort.Strings(services)
+
+	c := &checker{
+		checks:   checks,
+		l:        l.With().Str("component", "health").Logger(),
+		services: services,
+		srv:      &grpcServer{Server: grpchealth.NewServer()},
+		timeout:  DefaultCheckTimeout,
+	}
+	c.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+
+	return c
+}
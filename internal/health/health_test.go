@@ -0,0 +1,125 @@
+package health_test
+
+import (
+	"bridge/internal/health"
+	"bridge/internal/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testService = "api.v1.AuthService"
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func ready(t *testing.T, checker health.Checker) (int, readinessResponse) {
+	rec := httptest.NewRecorder()
+	checker.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var res readinessResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+
+	return rec.Code, res
+}
+
+func servingStatus(t *testing.T, checker health.Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	res, err := checker.GrpcServer().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	assert.NoError(t, err)
+
+	return res.GetStatus()
+}
+
+func TestChecker_Readiness(t *testing.T) {
+	var (
+		asserts      = assert.New(t)
+		vaultHealthy atomic.Bool
+	)
+
+	checker := health.NewChecker(logger.TestLogger, []string{testService}, map[string]health.CheckFunc{
+		"postgres": func(context.Context) error { return nil },
+		"vault": func(context.Context) error {
+			if vaultHealthy.Load() {
+				return nil
+			}
+			return errors.New("permission denied")
+		},
+	})
+
+	code, res := ready(t, checker)
+	asserts.Equal(http.StatusServiceUnavailable, code)
+	asserts.Equal("unavailable", res.Status)
+	asserts.Equal(map[string]string{"postgres": "ok", "vault": "unavailable"}, res.Checks)
+
+	vaultHealthy.Store(true)
+
+	code, res = ready(t, checker)
+	asserts.Equal(http.StatusOK, code)
+	asserts.Equal("ok", res.Status)
+	asserts.Equal(map[string]string{"postgres": "ok", "vault": "ok"}, res.Checks)
+
+	rec := httptest.NewRecorder()
+	checker.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	asserts.Equal(http.StatusOK, rec.Code)
+}
+
+func TestChecker_Run(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		healthy atomic.Bool
+	)
+
+	checker := health.NewChecker(logger.TestLogger, []string{testService}, map[string]health.CheckFunc{
+		"postgres": func(context.Context) error {
+			if healthy.Load() {
+				return nil
+			}
+			return errors.New("connection refused")
+		},
+	})
+
+	asserts.Equal(healthpb.HealthCheckResponse_SERVING, servingStatus(t, checker, testService))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go checker.Run(ctx, 10*time.Millisecond)
+
+	asserts.Eventually(func() bool {
+		return servingStatus(t, checker, testService) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+	asserts.Equal(healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, checker, ""))
+
+	healthy.Store(true)
+	asserts.Eventually(func() bool {
+		return servingStatus(t, checker, testService) == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestChecker_Shutdown(t *testing.T) {
+	asserts := assert.New(t)
+
+	checker := health.NewChecker(logger.TestLogger, []string{testService}, map[string]health.CheckFunc{
+		"postgres": func(context.Context) error { return nil },
+	})
+
+	asserts.Equal(healthpb.HealthCheckResponse_SERVING, servingStatus(t, checker, testService))
+
+	checker.Shutdown()
+
+	asserts.Equal(healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, checker, testService))
+	asserts.Equal(healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, checker, ""))
+
+	code, res := ready(t, checker)
+	asserts.Equal(http.StatusServiceUnavailable, code)
+	asserts.Equal("shutting down", res.Status)
+}
@@ -0,0 +1,167 @@
+// Package i18n localizes the errors returned to the clients.
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// HeaderAcceptLanguage is the metadata key of the locales preferred by the client, in the format of the HTTP
+// Accept-Language header which the gRPC-Gateway forwards.
+const HeaderAcceptLanguage = "accept-language"
+
+// The supported locales.
+const (
+	English = "en"
+	Swahili = "sw"
+)
+
+// DefaultLocale is the locale used when the client accepts none of the supported ones.
+const DefaultLocale = English
+
+// Locales are the supported locales.
+var Locales = []string{English, Swahili}
+
+// Negotiate returns the supported locale preferred by acceptLanguage, an Accept-Language header such as
+// "sw-KE,sw;q=0.9,en;q=0.8", or DefaultLocale if none is accepted. Regional variants match their language.
+func Negotiate(acceptLanguage string) string {
+	type preference struct {
+		locale string
+		q      float64
+	}
+
+	var prefs []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if i := strings.IndexByte(tag, '-'); i != -1 {
+			tag = tag[:i]
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			if v, ok := cutPrefix(strings.TrimSpace(param), "q="); ok {
+				parsed, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+
+		if q > 0 && supported(tag) {
+			prefs = append(prefs, preference{locale: tag, q: q})
+		}
+	}
+
+	if len(prefs) == 0 {
+		return DefaultLocale
+	}
+
+	// The order of the header breaks ties.
+	sort.SliceStable(prefs, func(i, j int) bool {
+		return prefs[i].q > prefs[j].q
+	})
+	return prefs[0].locale
+}
+
+// FromContext returns the locale negotiated from the HeaderAcceptLanguage metadata of the incoming ctx.
+func FromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return DefaultLocale
+	}
+	return Negotiate(strings.Join(md.Get(HeaderAcceptLanguage), ","))
+}
+
+// Message returns the message of the error with reason in locale, falling back to DefaultLocale. It returns false
+// if the reason isn't in the catalogue.
+func Message(locale, reason string) (string, bool) {
+	if msg, ok := messages[locale][reason]; ok {
+		return msg, true
+	}
+
+	msg, ok := messages[DefaultLocale][reason]
+	return msg, ok
+}
+
+// Localize returns err with a LocalizedMessage detail in locale, found in the catalogue by the reason of its
+// ErrorInfo detail, and the descriptions of its field violations translated. The message of err isn't changed, and
+// errors without a reason in the catalogue are returned as is.
+func Localize(locale string, err error) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var (
+		msg     string
+		found   bool
+		details []protoiface.MessageV1
+	)
+
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.LocalizedMessage:
+			// Errors are localized once.
+			return err
+		case *errdetails.ErrorInfo:
+			msg, found = Message(locale, d.GetReason())
+			details = append(details, d)
+		case *errdetails.BadRequest:
+			details = append(details, localizeBadRequest(locale, d))
+		case protoiface.MessageV1:
+			details = append(details, d)
+		}
+	}
+
+	if !found {
+		return err
+	}
+
+	details = append(details, &errdetails.LocalizedMessage{Locale: locale, Message: msg})
+
+	localized, detailsErr := status.New(s.Code(), s.Message()).WithDetails(details...)
+	if detailsErr != nil {
+		return err
+	}
+	return localized.Err()
+}
+
+// localizeBadRequest returns a copy of br with the descriptions of the field violations in locale.
+func localizeBadRequest(locale string, br *errdetails.BadRequest) *errdetails.BadRequest {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(br.GetFieldViolations()))
+	for _, v := range br.GetFieldViolations() {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.GetField(),
+			Description: Violation(locale, v.GetDescription()),
+		})
+	}
+	return &errdetails.BadRequest{FieldViolations: violations}
+}
+
+// supported reports whether locale is one of Locales.
+func supported(locale string) bool {
+	for _, l := range Locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// cutPrefix is strings.CutPrefix, which is not available in go 1.19.
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,175 @@
+package i18n_test
+
+import (
+	"bridge/internal/i18n"
+	"bridge/internal/rpc_error"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	asserts := assert.New(t)
+
+	for header, want := range map[string]string{
+		"":                          i18n.English,
+		"sw":                        i18n.Swahili,
+		"sw-KE,sw;q=0.9,en;q=0.8":   i18n.Swahili,
+		"en-GB,en;q=0.9,sw;q=0.8":   i18n.English,
+		"fr-FR,fr;q=0.9,sw;q=0.5":   i18n.Swahili,
+		"en;q=0.5, SW-ke;q=0.7":     i18n.Swahili,
+		"sw;q=0,en;q=0.1":           i18n.English,
+		"fr, de":                    i18n.English,
+		"*":                         i18n.English,
+		"sw;q=invalid,en;q=0.2":     i18n.English,
+		"en;q=0.8,sw;q=0.8,fr;q=1":  i18n.English,
+		"sw-TZ;q=0.8,en-US;q=0.79,": i18n.Swahili,
+	} {
+		asserts.Equal(want, i18n.Negotiate(header), header)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(i18n.HeaderAcceptLanguage, "sw-KE"))
+	asserts.Equal(i18n.Swahili, i18n.FromContext(ctx))
+	asserts.Equal(i18n.DefaultLocale, i18n.FromContext(context.Background()))
+}
+
+func TestMessage(t *testing.T) {
+	t.Parallel()
+
+	asserts := assert.New(t)
+
+	// Every error has a message in every locale, the English one being the error's message.
+	for _, err := range []error{
+		rpc_error.ErrResourceNotFound,
+		rpc_error.ErrAccountStatusChangeForbidden,
+		rpc_error.ErrAlreadyExists,
+		rpc_error.ErrCategoryExists,
+		rpc_error.ErrCategoryNotFound,
+		rpc_error.ErrConcurrentModification,
+		rpc_error.ErrConstraintViolation,
+		rpc_error.ErrContactAlreadyVerified,
+		rpc_error.ErrEmailExists,
+		rpc_error.ErrExpiredToken,
+		rpc_error.ErrIdempotencyKeyInProgress,
+		rpc_error.ErrIdempotencyKeyReused,
+		rpc_error.ErrInactiveAccount,
+		rpc_error.ErrInvalidAuthorizationScheme,
+		rpc_error.ErrInvalidEventType,
+		rpc_error.ErrInvalidIdempotencyKey,
+		rpc_error.ErrInvalidLoginCode,
+		rpc_error.ErrInvalidReference,
+		rpc_error.ErrInvalidStatusTransition,
+		rpc_error.ErrInvalidTimeRange,
+		rpc_error.ErrInvalidToken,
+		rpc_error.ErrInvalidWebhookURL,
+		rpc_error.ErrInvalidKYCTransition,
+		rpc_error.ErrKYCRequired,
+		rpc_error.ErrKYCSubmissionExists,
+		rpc_error.ErrKYCSubmissionNotFound,
+		rpc_error.ErrLoginCodeAttemptsExceeded,
+		rpc_error.ErrMissingAuthHeader,
+		rpc_error.ErrMissingCtxAuthMetadata,
+		rpc_error.ErrMissingMalformedToken,
+		rpc_error.ErrPasswordConfirmationMismatch,
+		rpc_error.ErrPermissionDenied,
+		rpc_error.ErrPhoneNumberExists,
+		rpc_error.ErrRateLimited,
+		rpc_error.ErrServerError,
+		rpc_error.ErrSuspendedAccount,
+		rpc_error.ErrTimeout,
+		rpc_error.ErrUnauthenticated,
+		rpc_error.ErrUnavailable,
+		rpc_error.ErrWebhookDeliveryNotFound,
+		rpc_error.ErrWebhookSubscriptionDisabled,
+		rpc_error.ErrWebhookSubscriptionNotFound,
+		rpc_error.NewValidationError(nil),
+	} {
+		reason := rpc_error.Reason(err)
+
+		msg, ok := i18n.Message(i18n.English, reason)
+		asserts.True(ok, reason)
+		asserts.Equal(status.Convert(err).Message(), msg, reason)
+
+		for _, locale := range i18n.Locales {
+			msg, ok = i18n.Message(locale, reason)
+			asserts.True(ok, reason)
+			asserts.NotEmpty(msg, reason)
+		}
+	}
+
+	// Unsupported locales fall back to English.
+	msg, ok := i18n.Message("fr", "EMAIL_EXISTS")
+	asserts.True(ok)
+	asserts.Equal("Email is already in use.", msg)
+
+	_, ok = i18n.Message(i18n.Swahili, "UNKNOWN_REASON")
+	asserts.False(ok)
+}
+
+func TestLocalize(t *testing.T) {
+	t.Parallel()
+
+	asserts := assert.New(t)
+
+	err := i18n.Localize(i18n.Swahili, rpc_error.ErrEmailExists)
+	asserts.Equal(rpc_error.ErrEmailExists.Error(), err.Error())
+	asserts.Equal("EMAIL_EXISTS", rpc_error.Reason(err))
+	asserts.Equal(i18n.Swahili, localizedMessage(err).GetLocale())
+	asserts.Equal("Barua pepe tayari inatumika.", localizedMessage(err).GetMessage())
+
+	// Errors are localized once.
+	asserts.Equal(i18n.Swahili, localizedMessage(i18n.Localize(i18n.English, err)).GetLocale())
+
+	err = i18n.Localize(i18n.Swahili, rpc_error.NewValidationError([]*errdetails.BadRequest_FieldViolation{
+		{Field: "name", Description: "value length must be at least 3 runes"},
+		{Field: "phone_number", Description: "value length must be 12 runes"},
+		{Field: "notes", Description: "value length must be between 3 and 1000 runes, inclusive"},
+		{Field: "documents[0].content_type", Description: "value must be in list [image/jpeg image/png]"},
+		{Field: "other", Description: "value must be a prime number"},
+	}))
+	asserts.Equal("Uthibitishaji wa ombi umeshindikana.", localizedMessage(err).GetMessage())
+	asserts.Equal([]string{
+		"urefu wa thamani lazima uwe angalau herufi 3",
+		"urefu wa thamani lazima uwe herufi 12",
+		"urefu wa thamani lazima uwe kati ya herufi 3 na 1000",
+		"thamani lazima iwe mojawapo ya [image/jpeg image/png]",
+		"value must be a prime number",
+	}, violationDescriptions(err))
+
+	// The descriptions are returned in English as they are.
+	err = i18n.Localize(i18n.English, rpc_error.NewValidationError([]*errdetails.BadRequest_FieldViolation{
+		{Field: "name", Description: "value length must be at least 3 runes"},
+	}))
+	asserts.Equal([]string{"value length must be at least 3 runes"}, violationDescriptions(err))
+
+	// Errors without a known reason are returned as is.
+	plain := status.Error(codes.Internal, "boom")
+	asserts.Equal(plain, i18n.Localize(i18n.Swahili, plain))
+}
+
+func localizedMessage(err error) *errdetails.LocalizedMessage {
+	for _, detail := range status.Convert(err).Details() {
+		if msg, ok := detail.(*errdetails.LocalizedMessage); ok {
+			return msg
+		}
+	}
+	return nil
+}
+
+func violationDescriptions(err error) []string {
+	var descriptions []string
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				descriptions = append(descriptions, v.GetDescription())
+			}
+		}
+	}
+	return descriptions
+}
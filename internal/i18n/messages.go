@@ -0,0 +1,96 @@
+package i18n
+
+// messages is the catalogue of the error messages by locale and ErrorInfo reason. The English messages are those of
+// the rpc_error sentinels.
+var messages = map[string]map[string]string{
+	English: {
+		"ACCOUNT_INACTIVE":                "Account has been deactivated.",
+		"ACCOUNT_STATUS_CHANGE_FORBIDDEN": "Account status cannot be changed through update.",
+		"ACCOUNT_SUSPENDED":               "Account has been suspended.",
+		"ALREADY_EXISTS":                  "Resource already exists.",
+		"CATEGORY_EXISTS":                 "Category already exists.",
+		"CATEGORY_NOT_FOUND":              "Category not found.",
+		"CONCURRENT_MODIFICATION":         "The resource was modified concurrently, try again.",
+		"CONSTRAINT_VIOLATION":            "The request violates a data constraint.",
+		"CONTACT_ALREADY_VERIFIED":        "The email or phone number is already verified.",
+		"EMAIL_EXISTS":                    "Email is already in use.",
+		"IDEMPOTENCY_KEY_IN_PROGRESS":     "A request with the same idempotency key is in progress.",
+		"IDEMPOTENCY_KEY_REUSED":          "Idempotency key was used for a different request.",
+		"INTERNAL_ERROR":                  "Internal server error.",
+		"INVALID_AUTHORIZATION_SCHEME":    "Invalid authorization scheme provided.",
+		"INVALID_EVENT_TYPE":              "Unknown event type provided.",
+		"INVALID_IDEMPOTENCY_KEY":         "Invalid idempotency key provided.",
+		"INVALID_KYC_TRANSITION":          "KYC submission has already been reviewed.",
+		"INVALID_LOGIN_CODE":              "Invalid or expired login code.",
+		"INVALID_REFERENCE":               "A referenced resource does not exist or is still in use.",
+		"INVALID_STATUS_TRANSITION":       "Account status transition is not allowed.",
+		"INVALID_TIME_RANGE":              "Invalid time range provided.",
+		"INVALID_TOKEN":                   "Invalid access token provided.",
+		"INVALID_WEBHOOK_URL":             "Webhook URL must use https and a public address.",
+		"KYC_REQUIRED":                    "An approved KYC verification is required.",
+		"KYC_SUBMISSION_EXISTS":           "A KYC submission is already pending or approved.",
+		"KYC_SUBMISSION_NOT_FOUND":        "KYC submission not found.",
+		"LOGIN_CODE_ATTEMPTS_EXCEEDED":    "Too many failed attempts, request a new login code.",
+		"MALFORMED_TOKEN":                 "Malformed authorization token.",
+		"MISSING_AUTH_HEADER":             "Missing authorization header.",
+		"MISSING_AUTH_METADATA":           "Missing context authentication metadata.",
+		"PASSWORD_CONFIRMATION_MISMATCH":  "The password confirmation does not match.",
+		"PERMISSION_DENIED":               "You are not allowed to perform this action.",
+		"PHONE_NUMBER_EXISTS":             "Phone number is already in use.",
+		"RATE_LIMITED":                    "Too many requests, try again later.",
+		"RESOURCE_NOT_FOUND":              "Resource not found.",
+		"TIMEOUT":                         "The request timed out, try again later.",
+		"TOKEN_EXPIRED":                   "Expired access token provided.",
+		"UNAUTHENTICATED":                 "Unauthenticated",
+		"UNAVAILABLE":                     "The service is temporarily unavailable, try again later.",
+		"VALIDATION_FAILED":               "Request validation failed.",
+		"WEBHOOK_DELIVERY_NOT_FOUND":      "Webhook delivery not found.",
+		"WEBHOOK_SUBSCRIPTION_DISABLED":   "Webhook subscription is disabled.",
+		"WEBHOOK_SUBSCRIPTION_NOT_FOUND":  "Webhook subscription not found.",
+	},
+	Swahili: {
+		"ACCOUNT_INACTIVE":                "Akaunti imezimwa.",
+		"ACCOUNT_STATUS_CHANGE_FORBIDDEN": "Hali ya akaunti haiwezi kubadilishwa kupitia usasishaji.",
+		"ACCOUNT_SUSPENDED":               "Akaunti imesimamishwa.",
+		"ALREADY_EXISTS":                  "Rasilimali tayari ipo.",
+		"CATEGORY_EXISTS":                 "Kategoria tayari ipo.",
+		"CATEGORY_NOT_FOUND":              "Kategoria haikupatikana.",
+		"CONCURRENT_MODIFICATION":         "Rasilimali ilibadilishwa na ombi lingine kwa wakati mmoja, jaribu tena.",
+		"CONSTRAINT_VIOLATION":            "Ombi linakiuka kizuizi cha data.",
+		"CONTACT_ALREADY_VERIFIED":        "Barua pepe au nambari ya simu tayari imethibitishwa.",
+		"EMAIL_EXISTS":                    "Barua pepe tayari inatumika.",
+		"IDEMPOTENCY_KEY_IN_PROGRESS":     "Ombi lenye ufunguo huo wa idempotency bado linashughulikiwa.",
+		"IDEMPOTENCY_KEY_REUSED":          "Ufunguo wa idempotency ulitumika kwa ombi tofauti.",
+		"INTERNAL_ERROR":                  "Hitilafu ya ndani ya seva.",
+		"INVALID_AUTHORIZATION_SCHEME":    "Mpango wa uidhinishaji uliotolewa si sahihi.",
+		"INVALID_EVENT_TYPE":              "Aina ya tukio iliyotolewa haijulikani.",
+		"INVALID_IDEMPOTENCY_KEY":         "Ufunguo wa idempotency uliotolewa si sahihi.",
+		"INVALID_KYC_TRANSITION":          "Ombi la KYC tayari limekaguliwa.",
+		"INVALID_LOGIN_CODE":              "Msimbo wa kuingia si sahihi au muda wake umeisha.",
+		"INVALID_REFERENCE":               "Rasilimali inayorejelewa haipo au bado inatumika.",
+		"INVALID_STATUS_TRANSITION":       "Mabadiliko hayo ya hali ya akaunti hayaruhusiwi.",
+		"INVALID_TIME_RANGE":              "Kipindi cha muda kilichotolewa si sahihi.",
+		"INVALID_TOKEN":                   "Tokeni ya ufikiaji iliyotolewa si sahihi.",
+		"INVALID_WEBHOOK_URL":             "URL ya webhook lazima itumie https na anwani ya umma.",
+		"KYC_REQUIRED":                    "Uthibitishaji wa KYC ulioidhinishwa unahitajika.",
+		"KYC_SUBMISSION_EXISTS":           "Ombi la KYC tayari linasubiri au limeidhinishwa.",
+		"KYC_SUBMISSION_NOT_FOUND":        "Ombi la KYC halikupatikana.",
+		"LOGIN_CODE_ATTEMPTS_EXCEEDED":    "Majaribio mengi yameshindikana, omba msimbo mpya wa kuingia.",
+		"MALFORMED_TOKEN":                 "Tokeni ya uidhinishaji ina muundo mbaya.",
+		"MISSING_AUTH_HEADER":             "Kichwa cha uidhinishaji hakipo.",
+		"MISSING_AUTH_METADATA":           "Metadata ya uthibitishaji haipo.",
+		"PASSWORD_CONFIRMATION_MISMATCH":  "Uthibitisho wa nenosiri haulingani.",
+		"PERMISSION_DENIED":               "Huruhusiwi kufanya kitendo hiki.",
+		"PHONE_NUMBER_EXISTS":             "Nambari ya simu tayari inatumika.",
+		"RATE_LIMITED":                    "Maombi ni mengi mno, jaribu tena baadaye.",
+		"RESOURCE_NOT_FOUND":              "Rasilimali haikupatikana.",
+		"TIMEOUT":                         "Muda wa ombi umekwisha, jaribu tena baadaye.",
+		"TOKEN_EXPIRED":                   "Muda wa tokeni ya ufikiaji iliyotolewa umeisha.",
+		"UNAUTHENTICATED":                 "Hujathibitishwa.",
+		"UNAVAILABLE":                     "Huduma haipatikani kwa sasa, jaribu tena baadaye.",
+		"VALIDATION_FAILED":               "Uthibitishaji wa ombi umeshindikana.",
+		"WEBHOOK_DELIVERY_NOT_FOUND":      "Uwasilishaji wa webhook haukupatikana.",
+		"WEBHOOK_SUBSCRIPTION_DISABLED":   "Usajili wa webhook umezimwa.",
+		"WEBHOOK_SUBSCRIPTION_NOT_FOUND":  "Usajili wa webhook haukupatikana.",
+	},
+}
@@ -0,0 +1,118 @@
+package i18n
+
+import "regexp"
+
+// violationRule translates the descriptions of the field violations produced by a protoc-gen-validate rule.
+type violationRule struct {
+	pattern *regexp.Regexp
+	// templates are the descriptions by locale, expanded with the submatches of pattern.
+	templates map[string]string
+}
+
+// violationRules cover the protoc-gen-validate rules used in the protos. The descriptions of other rules are returned
+// in English.
+var violationRules = []violationRule{
+	{
+		regexp.MustCompile(`^value length must be (\d+) runes$`),
+		map[string]string{Swahili: "urefu wa thamani lazima uwe herufi $1"},
+	},
+	{
+		regexp.MustCompile(`^value length must be at least (\d+) runes$`),
+		map[string]string{Swahili: "urefu wa thamani lazima uwe angalau herufi $1"},
+	},
+	{
+		regexp.MustCompile(`^value length must be at most (\d+) runes$`),
+		map[string]string{Swahili: "urefu wa thamani usizidi herufi $1"},
+	},
+	{
+		regexp.MustCompile(`^value length must be between (\d+) and (\d+) runes, inclusive$`),
+		map[string]string{Swahili: "urefu wa thamani lazima uwe kati ya herufi $1 na $2"},
+	},
+	{
+		regexp.MustCompile(`^value must be a valid email address$`),
+		map[string]string{Swahili: "thamani lazima iwe anwani halali ya barua pepe"},
+	},
+	{
+		regexp.MustCompile(`^value must be a valid URI$`),
+		map[string]string{Swahili: "thamani lazima iwe URI halali"},
+	},
+	{
+		regexp.MustCompile(`^value must be a valid UUID$`),
+		map[string]string{Swahili: "thamani lazima iwe UUID halali"},
+	},
+	{
+		regexp.MustCompile(`^value must be absolute$`),
+		map[string]string{Swahili: "thamani lazima iwe URI kamili"},
+	},
+	{
+		regexp.MustCompile(`^value does not match regex pattern (.+)$`),
+		map[string]string{Swahili: "thamani hailingani na muundo $1"},
+	},
+	{
+		regexp.MustCompile(`^value is required$`),
+		map[string]string{Swahili: "thamani inahitajika"},
+	},
+	{
+		regexp.MustCompile(`^value is not a valid timestamp$`),
+		map[string]string{Swahili: "thamani si muhuri halali wa muda"},
+	},
+	{
+		regexp.MustCompile(`^value must be greater than now$`),
+		map[string]string{Swahili: "thamani lazima iwe wakati ujao"},
+	},
+	{
+		regexp.MustCompile(`^value must be greater than or equal to (.+)$`),
+		map[string]string{Swahili: "thamani lazima iwe kubwa kuliko au sawa na $1"},
+	},
+	{
+		regexp.MustCompile(`^value must be inside range (.+)$`),
+		map[string]string{Swahili: "thamani lazima iwe ndani ya kipindi $1"},
+	},
+	{
+		regexp.MustCompile(`^value must be in list \[(.*)\]$`),
+		map[string]string{Swahili: "thamani lazima iwe mojawapo ya [$1]"},
+	},
+	{
+		regexp.MustCompile(`^value must not be in list \[(.*)\]$`),
+		map[string]string{Swahili: "thamani haipaswi kuwa mojawapo ya [$1]"},
+	},
+	{
+		regexp.MustCompile(`^value must be one of the defined enum values$`),
+		map[string]string{Swahili: "thamani lazima iwe mojawapo ya thamani zilizobainishwa"},
+	},
+	{
+		regexp.MustCompile(`^value must contain at least (\d+) item\(s\)$`),
+		map[string]string{Swahili: "thamani lazima iwe na angalau vipengele $1"},
+	},
+	{
+		regexp.MustCompile(`^value must contain between (\d+) and (\d+) items, inclusive$`),
+		map[string]string{Swahili: "thamani lazima iwe na kati ya vipengele $1 na $2"},
+	},
+	{
+		regexp.MustCompile(`^repeated value must contain unique items$`),
+		map[string]string{Swahili: "vipengele vya thamani lazima visirudiwe"},
+	},
+	{
+		regexp.MustCompile(`^embedded message failed validation$`),
+		map[string]string{Swahili: "ujumbe uliopachikwa haukupita uthibitishaji"},
+	},
+	{
+		regexp.MustCompile(`^oneof value cannot be a typed-nil$`),
+		map[string]string{Swahili: "thamani ya oneof haiwezi kuwa nil"},
+	},
+}
+
+// Violation returns description, the description of a field violation returned by protoc-gen-validate, in locale.
+func Violation(locale, description string) string {
+	for _, rule := range violationRules {
+		template, ok := rule.templates[locale]
+		if !ok {
+			continue
+		}
+
+		if match := rule.pattern.FindStringSubmatchIndex(description); match != nil {
+			return string(rule.pattern.ExpandString(nil, template, description, match))
+		}
+	}
+	return description
+}
@@ -0,0 +1,261 @@
+package idempotency
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/internal/utils"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Headers exchanged with the clients.
+const (
+	// HeaderKey is the metadata key of the idempotency key chosen by the client for a call and its retries.
+	HeaderKey = "idempotency-key"
+	// HeaderReplayed is set on the responses replayed from an earlier call.
+	HeaderReplayed = "idempotent-replayed"
+)
+
+const (
+	// DefaultTTL is how long the responses are kept for the retries.
+	DefaultTTL = 24 * time.Hour
+	// DefaultCleanupInterval is how often the expired keys are deleted.
+	DefaultCleanupInterval = time.Hour
+	// Lease is how long a call holds its key. The call is canceled once its lease lapses, so that a retry can take
+	// over the key without running concurrently, for example after the process handling the call crashed.
+	Lease = time.Minute
+)
+
+// maxKeyLength is the length of the longest idempotency key accepted, which fits a UUID or a ULID with a prefix.
+const maxKeyLength = 255
+
+// writeTimeout is the deadline for recording the outcome of a call, which may outlive the call's context.
+const writeTimeout = 5 * time.Second
+
+// Methods are the RPCs creating resources which clients retry on flaky networks.
+var Methods = []string{
+	"/api.v1.AuthService/Register",
+	"/api.v1.UserService/Create",
+	"/api.v1.WebhookService/CreateWebhookSubscription",
+}
+
+// Call runs the handler of a call.
+type Call func(ctx context.Context) (interface{}, error)
+
+// Guard makes the calls to the configured methods idempotent for the clients sending HeaderKey.
+type Guard interface {
+	// Do runs call for a call to fullMethod with req, unless a call with the same idempotency key was made already.
+	// The response of that call is then returned along with true, as long as req is the same. The calls without an
+	// idempotency key and to the other methods are always run.
+	//
+	// The keys are scoped to the method and the authenticated user, if any. Since the requests must match, a key
+	// can't be used to replay the response of another client's call without knowing its request.
+	Do(ctx context.Context, fullMethod string, req interface{}, call Call) (interface{}, bool, error)
+	// Run deletes the expired keys until ctx is done.
+	Run(ctx context.Context)
+}
+
+type guard struct {
+	cleanupInterval time.Duration
+	l               zerolog.Logger
+	methods         map[string]struct{}
+	repo            repository.Idempotency
+	ttl             time.Duration
+}
+
+func (g *guard) Do(ctx context.Context, fullMethod string, req interface{}, call Call) (interface{}, bool, error) {
+	if _, ok := g.methods[fullMethod]; !ok {
+		resp, err := call(ctx)
+		return resp, false, err
+	}
+
+	key := keyFromContext(ctx)
+	if key == "" {
+		resp, err := call(ctx)
+		return resp, false, err
+	}
+
+	l := logger.FromContext(ctx, g.l).With().Str("action", "idempotent call").Logger()
+
+	if len(key) > maxKeyLength {
+		l.Error().Int("length", len(key)).Msg("idempotency key too long")
+		return nil, false, rpc_error.ErrInvalidIdempotencyKey
+	}
+
+	msg, ok := req.(proto.Message)
+	if !ok {
+		resp, err := call(ctx)
+		return resp, false, err
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		l.Err(err).Msg("failed to marshal request")
+		return nil, false, rpc_error.ErrServerError
+	}
+
+	var (
+		sum         = sha256.Sum256(data)
+		now         = time.Now()
+		lockedUntil = now.Add(Lease)
+		record      = &models.IdempotencyRecord{
+			Key:         scopedKey(ctx, fullMethod, key),
+			Method:      fullMethod,
+			Fingerprint: hex.EncodeToString(sum[:]),
+			LockedUntil: &lockedUntil,
+			ExpiresAt:   now.Add(g.ttl),
+		}
+	)
+
+	existing, err := g.repo.Claim(ctx, record)
+	if err != nil {
+		l.Err(err).Msg("failed to claim idempotency key")
+		return nil, false, rpc_error.ErrServerError
+	}
+
+	if existing != nil {
+		resp, err := replay(existing, record.Fingerprint)
+		if err != nil {
+			l.Err(err).Msg("failed to replay response")
+			return nil, false, err
+		}
+
+		l.Info().Msg("response replayed")
+		return resp, true, nil
+	}
+
+	callCtx, cancelCall := context.WithDeadline(ctx, lockedUntil)
+	resp, err := call(callCtx)
+	cancelCall()
+
+	// The outcome is recorded even if the client went away, so that its retry doesn't find the key in progress.
+	writeCtx, cancel := context.WithTimeout(utils.WithoutCancel(ctx), writeTimeout)
+	defer cancel()
+
+	if err != nil {
+		if releaseErr := g.repo.Release(writeCtx, record.Key); releaseErr != nil {
+			l.Err(releaseErr).Msg("failed to release idempotency key")
+		}
+		return nil, false, err
+	}
+
+	if err = g.complete(writeCtx, record.Key, resp); err != nil {
+		l.Err(err).Msg("failed to store response")
+
+		// Retries run the call again rather than failing until the key expires.
+		if releaseErr := g.repo.Release(writeCtx, record.Key); releaseErr != nil {
+			l.Err(releaseErr).Msg("failed to release idempotency key")
+		}
+	}
+
+	return resp, false, nil
+}
+
+// complete stores resp as the response of the call holding key.
+func (g *guard) complete(ctx context.Context, key string, resp interface{}) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return rpc_error.ErrServerError
+	}
+
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return g.repo.Complete(ctx, key, string(proto.MessageName(msg)), data)
+}
+
+func (g *guard) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := g.repo.DeleteExpired(ctx, now); err != nil {
+				g.l.Err(err).Msg("failed to delete expired keys")
+			}
+		}
+	}
+}
+
+// NewGuard creates a Guard for the calls to methods, keeping the responses in repo for ttl, or DefaultTTL if it's
+// zero.
+func NewGuard(l zerolog.Logger, repo repository.Idempotency, ttl time.Duration, methods ...string) Guard {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	m := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		m[method] = struct{}{}
+	}
+
+	return &guard{
+		cleanupInterval: DefaultCleanupInterval,
+		l:               l.With().Str("component", "idempotency").Logger(),
+		methods:         m,
+		repo:            repo,
+		ttl:             ttl,
+	}
+}
+
+// replay returns the response stored for the call made with the same key as the call whose request has fingerprint.
+func replay(record *models.IdempotencyRecord, fingerprint string) (interface{}, error) {
+	if record.Fingerprint != fingerprint {
+		return nil, rpc_error.ErrIdempotencyKeyReused
+	}
+
+	if record.CompletedAt == nil {
+		return nil, rpc_error.ErrIdempotencyKeyInProgress
+	}
+
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(record.ResponseType))
+	if err != nil {
+		return nil, rpc_error.ErrServerError
+	}
+
+	msg := mt.New().Interface()
+	if err = proto.Unmarshal(record.Response, msg); err != nil {
+		return nil, rpc_error.ErrServerError
+	}
+
+	return msg, nil
+}
+
+// keyFromContext returns the idempotency key sent by the client.
+func keyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(HeaderKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// scopedKey returns the key identifying the calls to fullMethod made by the user in ctx with key.
+func scopedKey(ctx context.Context, fullMethod, key string) string {
+	var userID string
+	if r, ok := logger.RequestFromContext(ctx); ok {
+		userID = r.UserID()
+	}
+
+	sum := sha256.Sum256([]byte(fullMethod + "\n" + userID + "\n" + key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,239 @@
+package idempotency_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/idempotency"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/rpc_error"
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testMethod = "/api.v1.UserService/Create"
+
+// memoryIdempotencyRepo is an in-memory repository.Idempotency.
+type memoryIdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]*models.IdempotencyRecord
+}
+
+func (r *memoryIdempotencyRepo) Claim(
+	_ context.Context,
+	record *models.IdempotencyRecord,
+) (*models.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if existing, ok := r.records[record.Key]; ok && existing.ExpiresAt.After(now) &&
+		(existing.CompletedAt != nil || existing.LockedUntil == nil || existing.LockedUntil.After(now)) {
+		clone := *existing
+		return &clone, nil
+	}
+
+	clone := *record
+	r.records[record.Key] = &clone
+	return nil, nil
+}
+
+func (r *memoryIdempotencyRepo) Complete(_ context.Context, key, responseType string, response []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[key]
+	if !ok || record.CompletedAt != nil {
+		return sql.ErrNoRows
+	}
+
+	now := time.Now()
+	record.ResponseType, record.Response, record.CompletedAt = responseType, response, &now
+	return nil
+}
+
+func (r *memoryIdempotencyRepo) Release(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record, ok := r.records[key]; ok && record.CompletedAt == nil {
+		delete(r.records, key)
+	}
+	return nil
+}
+
+func (r *memoryIdempotencyRepo) DeleteExpired(context.Context, time.Time) (int, error) {
+	return 0, nil
+}
+
+func keyCtx(key, userID string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotency.HeaderKey, key))
+
+	r := &logger.Request{ID: "request"}
+	r.SetUserID(userID)
+
+	return logger.ContextWithRequest(ctx, r)
+}
+
+func TestGuard(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		repo    = &memoryIdempotencyRepo{records: map[string]*models.IdempotencyRecord{}}
+		guard   = idempotency.NewGuard(logger.TestLogger, repo, time.Hour, testMethod)
+		req     = &pb.CreateUserRequest{Name: "Jane Doe", Email: "jane@example.com"}
+		calls   int
+	)
+
+	call := func(context.Context) (interface{}, error) {
+		calls++
+		return &pb.CreateUserResponse{User: &pb.User{ID: strings.Repeat("a", calls), Name: "Jane Doe"}}, nil
+	}
+
+	ctx := keyCtx("key-1", "admin-1")
+
+	first, replayed, err := guard.Do(ctx, testMethod, req, call)
+	asserts.NoError(err)
+	asserts.False(replayed)
+
+	// Retries get the stored response without running the call again.
+	second, replayed, err := guard.Do(ctx, testMethod, proto.Clone(req), call)
+	asserts.NoError(err)
+	asserts.True(replayed)
+	asserts.Equal(1, calls)
+	asserts.True(proto.Equal(first.(proto.Message), second.(proto.Message)))
+
+	// The key can't be reused for a different request.
+	other := &pb.CreateUserRequest{Name: "John Doe", Email: "john@example.com"}
+	_, _, err = guard.Do(ctx, testMethod, other, call)
+	asserts.ErrorIs(err, rpc_error.ErrIdempotencyKeyReused)
+
+	// The keys are scoped to the user and the method.
+	_, replayed, err = guard.Do(keyCtx("key-1", "admin-2"), testMethod, req, call)
+	asserts.NoError(err)
+	asserts.False(replayed)
+	asserts.Equal(2, calls)
+
+	// Calls without a key or to other methods always run.
+	_, replayed, err = guard.Do(context.Background(), testMethod, req, call)
+	asserts.NoError(err)
+	asserts.False(replayed)
+
+	_, replayed, err = guard.Do(ctx, "/api.v1.UserService/Update", req, call)
+	asserts.NoError(err)
+	asserts.False(replayed)
+	asserts.Equal(4, calls)
+
+	_, _, err = guard.Do(keyCtx(strings.Repeat("k", 256), "admin-1"), testMethod, req, call)
+	asserts.ErrorIs(err, rpc_error.ErrInvalidIdempotencyKey)
+}
+
+func TestGuard_FailedAndConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		repo    = &memoryIdempotencyRepo{records: map[string]*models.IdempotencyRecord{}}
+		guard   = idempotency.NewGuard(logger.TestLogger, repo, time.Hour, testMethod)
+		req     = &pb.CreateUserRequest{Name: "Jane Doe", Email: "jane@example.com"}
+		ctx     = keyCtx("key-2", "")
+	)
+
+	// Failed calls release the key so that they can be retried.
+	_, _, err := guard.Do(ctx, testMethod, req, func(context.Context) (interface{}, error) {
+		return nil, rpc_error.ErrServerError
+	})
+	asserts.ErrorIs(err, rpc_error.ErrServerError)
+
+	var (
+		started = make(chan struct{})
+		finish  = make(chan struct{})
+		done    = make(chan error)
+	)
+
+	go func() {
+		_, _, err := guard.Do(ctx, testMethod, req, func(context.Context) (interface{}, error) {
+			close(started)
+			<-finish
+			return &pb.CreateUserResponse{User: &pb.User{ID: "user-1"}}, nil
+		})
+		done <- err
+	}()
+
+	<-started
+
+	// Retries made while the call is running are rejected.
+	_, _, err = guard.Do(ctx, testMethod, req, func(context.Context) (interface{}, error) {
+		t.Fatal("call ran concurrently")
+		return nil, nil
+	})
+	asserts.ErrorIs(err, rpc_error.ErrIdempotencyKeyInProgress)
+
+	close(finish)
+	asserts.NoError(<-done)
+
+	resp, replayed, err := guard.Do(ctx, testMethod, req, nil)
+	asserts.NoError(err)
+	asserts.True(replayed)
+	asserts.Equal("user-1", resp.(*pb.CreateUserResponse).User.ID)
+}
+
+func TestGuard_AbandonedCall(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		repo    = &memoryIdempotencyRepo{records: map[string]*models.IdempotencyRecord{}}
+		guard   = idempotency.NewGuard(logger.TestLogger, repo, time.Hour, testMethod)
+		req     = &pb.CreateUserRequest{Name: "Jane Doe", Email: "jane@example.com"}
+		ctx     = keyCtx("key-3", "")
+		started = make(chan struct{})
+	)
+
+	// The process handling the call crashed, so it neither completed nor released the key.
+	go func() {
+		_, _, _ = guard.Do(ctx, testMethod, req, func(ctx context.Context) (interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	}()
+
+	<-started
+
+	_, _, err := guard.Do(ctx, testMethod, req, nil)
+	asserts.ErrorIs(err, rpc_error.ErrIdempotencyKeyInProgress)
+
+	// The retries take over the key once the lease of the abandoned call lapsed.
+	repo.mu.Lock()
+	for _, record := range repo.records {
+		asserts.WithinDuration(time.Now().Add(idempotency.Lease), *record.LockedUntil, time.Second)
+
+		lapsed := time.Now().Add(-time.Second)
+		record.LockedUntil = &lapsed
+	}
+	repo.mu.Unlock()
+
+	var deadline time.Time
+	resp, replayed, err := guard.Do(ctx, testMethod, req, func(ctx context.Context) (interface{}, error) {
+		// The call is canceled before its lease lapses, so that it can't overlap with the next takeover.
+		deadline, _ = ctx.Deadline()
+		return &pb.CreateUserResponse{User: &pb.User{ID: "user-1"}}, nil
+	})
+	asserts.NoError(err)
+	asserts.False(replayed)
+	asserts.Equal("user-1", resp.(*pb.CreateUserResponse).User.ID)
+	asserts.WithinDuration(time.Now().Add(idempotency.Lease), deadline, time.Second)
+
+	resp, replayed, err = guard.Do(ctx, testMethod, req, nil)
+	asserts.NoError(err)
+	asserts.True(replayed)
+	asserts.Equal("user-1", resp.(*pb.CreateUserResponse).User.ID)
+}
@@ -0,0 +1,140 @@
+package interceptors
+
+import (
+	"bridge/internal/logger"
+	"context"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"time"
+)
+
+const (
+	// HeaderRequestID is the metadata key used for propagating the request ID. It's returned to the client in the
+	// response header.
+	HeaderRequestID = "x-request-id"
+
+	maxRequestIDLen = 128
+)
+
+// requestID returns the request ID sent by the client, or a new one if it's missing or invalid.
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(HeaderRequestID); len(values) > 0 && validRequestID(values[0]) {
+			return values[0]
+		}
+	}
+	return uuid.NewString()
+}
+
+// validRequestID reports whether id is safe to log and return to the client.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// startRequest attaches the request and its logger to ctx and propagates the request ID to outgoing calls.
+func startRequest(
+	ctx context.Context,
+	l zerolog.Logger,
+	fullMethod string,
+	trustedProxies []*net.IPNet,
+) (context.Context, *logger.Request) {
+	r := &logger.Request{ID: requestID(ctx), Method: fullMethod, TrustedProxies: trustedProxies}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		r.Peer = p.Addr.String()
+	}
+
+	ctx = logger.ContextWithRequest(ctx, r)
+	ctx = metadata.AppendToOutgoingContext(ctx, HeaderRequestID, r.ID)
+	ctx = logger.FromContext(ctx, l).WithContext(ctx)
+
+	return ctx, r
+}
+
+// logRequest writes the access log line for a completed request.
+func logRequest(ctx context.Context, l zerolog.Logger, start time.Time, err error) {
+	l = logger.FromContext(ctx, l)
+	code := status.Code(err)
+
+	var e *zerolog.Event
+	switch code {
+	case codes.OK:
+		e = l.Info()
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented, codes.Unavailable,
+		codes.DeadlineExceeded:
+		e = l.Error().Err(err)
+	default:
+		e = l.Warn().Err(err)
+	}
+
+	e.Dur("duration", time.Since(start)).
+		Str("code", code.String()).
+		Msg("finished call")
+}
+
+func (u *unaryInterceptor) UnaryServerAccessLog(
+	l zerolog.Logger,
+	trustedProxies []*net.IPNet,
+) grpc.UnaryServerInterceptor {
+	l = l.With().Str("action", "access log").Str("kind", "unary").Logger()
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		ctx, r := startRequest(ctx, l, info.FullMethod, trustedProxies)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(HeaderRequestID, r.ID))
+
+		resp, err := handler(ctx, req)
+		logRequest(ctx, l, start, err)
+
+		return resp, err
+	}
+}
+
+func (s *streamInterceptor) StreamServerAccessLog(
+	l zerolog.Logger,
+	trustedProxies []*net.IPNet,
+) grpc.StreamServerInterceptor {
+	l = l.With().Str("action", "access log").Str("kind", "stream").Logger()
+
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		ctx, r := startRequest(stream.Context(), l, info.FullMethod, trustedProxies)
+		_ = stream.SetHeader(metadata.Pairs(HeaderRequestID, r.ID))
+
+		wrapped := WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+
+		err := handler(srv, wrapped)
+		logRequest(ctx, l, start, err)
+
+		return err
+	}
+}
@@ -0,0 +1,212 @@
+package interceptors_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/interceptors"
+	"bridge/internal/logger"
+	"bridge/internal/rpc_error"
+	"bytes"
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"sync"
+	"testing"
+)
+
+// syncBuffer is a bytes.Buffer that's safe to write from the server goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// requestAuthServer records the request attached to the context and fails logins without a password.
+type requestAuthServer struct {
+	pb.UnimplementedAuthServiceServer
+	l       zerolog.Logger
+	request *logger.Request
+}
+
+func (s *requestAuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	s.request, _ = logger.RequestFromContext(ctx)
+	logger.SetUserID(ctx, "user-1")
+
+	l := logger.FromContext(ctx, s.l)
+	l.Info().Msg("handled login")
+
+	if req.Password == "" {
+		return nil, rpc_error.ErrUnauthenticated
+	}
+	return &pb.LoginResponse{}, nil
+}
+
+func TestUnaryServerAccessLog(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		buf     = &syncBuffer{}
+		l       = zerolog.New(buf)
+		authSrv = &requestAuthServer{l: l}
+	)
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptors.NewUnaryServerInterceptors().UnaryServerAccessLog(l, nil),
+	))
+	pb.RegisterAuthServiceServer(srv, authSrv)
+
+	lis, err := net.Listen("tcp", ":0")
+	asserts.NoError(err)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	asserts.NoError(err)
+	t.Cleanup(func() {
+		_ = cc.Close()
+	})
+
+	client := pb.NewAuthServiceClient(cc)
+
+	tests := []struct {
+		name      string
+		requestID string
+		generated bool
+		password  string
+		code      codes.Code
+		level     string
+	}{
+		{
+			name:      "generated request id",
+			generated: true,
+			password:  "password",
+			code:      codes.OK,
+			level:     `"level":"info"`,
+		},
+		{
+			name:      "propagated request id",
+			requestID: "req-123",
+			password:  "password",
+			code:      codes.OK,
+			level:     `"level":"info"`,
+		},
+		{
+			name:      "invalid request id",
+			requestID: "<script>",
+			generated: true,
+			password:  "password",
+			code:      codes.OK,
+			level:     `"level":"info"`,
+		},
+		{
+			name:      "client error",
+			requestID: "req-456",
+			code:      codes.Unauthenticated,
+			level:     `"level":"warn"`,
+		},
+	}
+
+	// The subtests share the server and the log buffer so they don't run in parallel.
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.requestID != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, interceptors.HeaderRequestID, tt.requestID)
+			}
+
+			var header metadata.MD
+			_, err := client.Login(
+				ctx,
+				&pb.LoginRequest{Email: "jane@example.com", Password: tt.password},
+				grpc.Header(&header),
+			)
+			asserts.Equal(tt.code, status.Code(err))
+
+			ids := header.Get(interceptors.HeaderRequestID)
+			if !asserts.Len(ids, 1) {
+				return
+			}
+
+			requestID := ids[0]
+			if tt.generated {
+				asserts.NotEqual(tt.requestID, requestID)
+				asserts.NotEmpty(requestID)
+			} else {
+				asserts.Equal(tt.requestID, requestID)
+			}
+
+			asserts.Equal(requestID, authSrv.request.ID)
+			asserts.Equal("/api.v1.AuthService/Login", authSrv.request.Method)
+			asserts.NotEmpty(authSrv.request.Peer)
+
+			out := buf.String()
+			asserts.Contains(out, `"request_id":"`+requestID+`"`)
+			asserts.Contains(out, `"user_id":"user-1"`)
+			asserts.Contains(out, `"code":"`+tt.code.String()+`"`)
+			asserts.Contains(out, `"duration":`)
+			asserts.Contains(out, tt.level)
+		})
+	}
+}
+
+func TestStreamServerAccessLog(t *testing.T) {
+	var (
+		asserts     = assert.New(t)
+		buf         = &syncBuffer{}
+		l           = zerolog.New(buf)
+		interceptor = interceptors.NewStreamServerInterceptors().StreamServerAccessLog(l, nil)
+		ctx         = metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs(interceptors.HeaderRequestID, "req-789"),
+		)
+		stream = &headerServerStream{testServerStream: testServerStream{ctx: ctx}}
+	)
+
+	var got *logger.Request
+	err := interceptor(nil, stream, testStreamInfo, func(_ interface{}, stream grpc.ServerStream) error {
+		got, _ = logger.RequestFromContext(stream.Context())
+		return rpc_error.ErrServerError
+	})
+	asserts.ErrorIs(err, rpc_error.ErrServerError)
+
+	if asserts.NotNil(got) {
+		asserts.Equal("req-789", got.ID)
+		asserts.Equal(testStreamInfo.FullMethod, got.Method)
+	}
+	asserts.Equal([]string{"req-789"}, stream.header.Get(interceptors.HeaderRequestID))
+
+	out := buf.String()
+	asserts.Contains(out, `"level":"error"`)
+	asserts.Contains(out, `"request_id":"req-789"`)
+	asserts.Contains(out, `"code":"Internal"`)
+}
+
+// headerServerStream records the headers set on the stream.
+type headerServerStream struct {
+	testServerStream
+	header metadata.MD
+}
+
+func (s *headerServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
@@ -0,0 +1,42 @@
+package interceptors
+
+import (
+	"bridge/internal/audit"
+	"context"
+	"google.golang.org/grpc"
+)
+
+func (u *unaryInterceptor) UnaryServerAudit(auditor audit.Auditor) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, trail := auditor.WithTrail(ctx, info.FullMethod)
+
+		resp, err := handler(ctx, req)
+		auditor.Audit(ctx, trail, err)
+
+		return resp, err
+	}
+}
+
+func (s *streamInterceptor) StreamServerAudit(auditor audit.Auditor) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, trail := auditor.WithTrail(stream.Context(), info.FullMethod)
+
+		wrapped := WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+
+		err := handler(srv, wrapped)
+		auditor.Audit(ctx, trail, err)
+
+		return err
+	}
+}
@@ -0,0 +1,126 @@
+package interceptors_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/audit"
+	"bridge/internal/interceptors"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/ratelimit"
+	"bridge/internal/rpc_error"
+	"bridge/services/auth"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryAuditRepo is an in-memory repository.Audit.
+type memoryAuditRepo struct {
+	mu     sync.Mutex
+	events []*pb.AuditEvent
+}
+
+func (r *memoryAuditRepo) Append(_ context.Context, events ...*pb.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func (r *memoryAuditRepo) List(context.Context, models.AuditFilter) ([]*pb.AuditEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*pb.AuditEvent(nil), r.events...), nil
+}
+
+func (r *memoryAuditRepo) Verify(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestUnaryServerAudit(t *testing.T) {
+	const method = "/api.v1.AuthService/Login"
+
+	var (
+		asserts = assert.New(t)
+		repo    = &memoryAuditRepo{}
+		auditor = audit.NewAuditor(logger.TestLogger, repo, logger.NewRedactor(nil), method)
+		limiter = ratelimit.NewLimiter(logger.TestLogger, ratelimit.NewMemoryBackend(0), map[string]ratelimit.Policy{
+			method: {Key: ratelimit.KeyUser, Limit: 1, Window: time.Minute},
+		})
+		admin = &pb.User{ID: "4f0c3a52-1f7b-4f4e-8d8e-1b2a3c4d5e6f", Role: pb.User_ADMIN}
+		unary = interceptors.NewUnaryServerInterceptors()
+	)
+
+	authenticate := func(ctx context.Context) (context.Context, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if len(md.Get("authorization")) == 0 {
+			return nil, rpc_error.ErrUnauthenticated
+		}
+
+		logger.SetUserID(ctx, admin.ID)
+		return auth.ContextWithUser(ctx, admin), nil
+	}
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		unary.UnaryServerAccessLog(logger.TestLogger, nil),
+		unary.UnaryServerAudit(auditor),
+		unary.UnaryServerAuthenticator(authenticate),
+		unary.UnaryServerRateLimit(limiter),
+	))
+	pb.RegisterAuthServiceServer(srv, &requestAuthServer{l: logger.TestLogger})
+
+	lis, err := net.Listen("tcp", ":0")
+	asserts.NoError(err)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	asserts.NoError(err)
+	t.Cleanup(func() {
+		_ = cc.Close()
+	})
+
+	var (
+		client = pb.NewAuthServiceClient(cc)
+		req    = &pb.LoginRequest{Email: "jane@example.com", Password: "password"}
+		ctx    = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+	)
+
+	// The calls rejected by the authenticator and the rate limiter are audited along with the allowed ones.
+	_, err = client.Login(context.Background(), req)
+	asserts.Equal(codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Login(ctx, req)
+	asserts.NoError(err)
+
+	_, err = client.Login(ctx, req)
+	asserts.Equal(codes.ResourceExhausted, status.Code(err))
+
+	events, err := repo.List(context.Background(), models.AuditFilter{})
+	asserts.NoError(err)
+
+	if asserts.Len(events, 3) {
+		asserts.Equal(codes.Unauthenticated.String(), events[0].Code)
+		asserts.Empty(events[0].ActorId)
+
+		asserts.Equal(codes.OK.String(), events[1].Code)
+		asserts.Equal(admin.ID, events[1].ActorId)
+
+		asserts.Equal(codes.ResourceExhausted.String(), events[2].Code)
+		asserts.Equal(admin.ID, events[2].ActorId)
+		asserts.Equal(pb.User_ADMIN.String(), events[2].ActorRole)
+	}
+}
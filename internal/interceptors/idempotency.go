@@ -0,0 +1,27 @@
+package interceptors
+
+import (
+	"bridge/internal/idempotency"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func (u *unaryInterceptor) UnaryServerIdempotency(guard idempotency.Guard) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, replayed, err := guard.Do(ctx, info.FullMethod, req, func(ctx context.Context) (interface{}, error) {
+			return handler(ctx, req)
+		})
+
+		if replayed {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(idempotency.HeaderReplayed, "true"))
+		}
+
+		return resp, err
+	}
+}
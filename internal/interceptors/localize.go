@@ -0,0 +1,31 @@
+package interceptors
+
+import (
+	"bridge/internal/i18n"
+	"context"
+	"google.golang.org/grpc"
+)
+
+func (u *unaryInterceptor) UnaryServerLocalizer() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, i18n.Localize(i18n.FromContext(ctx), err)
+		}
+		return resp, nil
+	}
+}
+
+func (s *streamInterceptor) StreamServerLocalizer() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, stream); err != nil {
+			return i18n.Localize(i18n.FromContext(stream.Context()), err)
+		}
+		return nil
+	}
+}
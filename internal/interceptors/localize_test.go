@@ -0,0 +1,60 @@
+package interceptors_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/i18n"
+	"bridge/internal/interceptors"
+	"bridge/internal/rpc_error"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestUnaryServerLocalizer(t *testing.T) {
+	var (
+		asserts   = assert.New(t)
+		unary     = interceptors.NewUnaryServerInterceptors()
+		localizer = unary.UnaryServerLocalizer()
+		validator = unary.UnaryServerValidator()
+		info      = &grpc.UnaryServerInfo{FullMethod: "/api.v1.AuthService/Register"}
+		ctx       = metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs(i18n.HeaderAcceptLanguage, "sw-KE,en;q=0.5"),
+		)
+	)
+
+	localized := func(err error) *errdetails.LocalizedMessage {
+		for _, detail := range status.Convert(err).Details() {
+			if msg, ok := detail.(*errdetails.LocalizedMessage); ok {
+				return msg
+			}
+		}
+		return nil
+	}
+
+	_, err := localizer(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, rpc_error.ErrPhoneNumberExists
+	})
+	asserts.Equal(rpc_error.ErrPhoneNumberExists.Error(), err.Error())
+	asserts.Equal("Nambari ya simu tayari inatumika.", localized(err).GetMessage())
+
+	validate := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return validator(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+	}
+
+	_, err = localizer(ctx, &pb.RegisterRequest{Name: "Jane Doe"}, info, validate)
+	asserts.Equal(i18n.Swahili, localized(err).GetLocale())
+	asserts.Equal("thamani lazima iwe anwani halali ya barua pepe", fieldViolations(t, err)["email"])
+
+	resp, err := localizer(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return &pb.RegisterResponse{}, nil
+	})
+	asserts.NoError(err)
+	asserts.NotNil(resp)
+}
@@ -0,0 +1,41 @@
+package interceptors
+
+import (
+	"bridge/internal/metrics"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+func (u *unaryInterceptor) UnaryServerMetrics() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+		metrics.ObserveGrpc(metrics.GrpcTypeUnary, info.FullMethod, status.Code(err), time.Since(start))
+
+		return resp, err
+	}
+}
+
+func (s *streamInterceptor) StreamServerMetrics() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		err := handler(srv, stream)
+		metrics.ObserveGrpc(metrics.GrpcTypeStream, info.FullMethod, status.Code(err), time.Since(start))
+
+		return err
+	}
+}
@@ -0,0 +1,55 @@
+package interceptors
+
+import (
+	"bridge/internal/ratelimit"
+	"bridge/internal/rpc_error"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"math"
+	"strconv"
+	"time"
+)
+
+// HeaderRetryAfter is the header used for returning the number of seconds a rate limited client should wait before
+// calling again. The gRPC-Gateway returns it as the Retry-After header of the 429 response.
+const HeaderRetryAfter = "retry-after"
+
+// retryAfterHeader returns the header of a call rejected by the rate limiter, rounded up to the second.
+func retryAfterHeader(retryAfter time.Duration) metadata.MD {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return metadata.Pairs(HeaderRetryAfter, strconv.Itoa(seconds))
+}
+
+func (u *unaryInterceptor) UnaryServerRateLimit(limiter ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if res := limiter.Limit(ctx, info.FullMethod); !res.Allowed {
+			_ = grpc.SetHeader(ctx, retryAfterHeader(res.RetryAfter))
+			return nil, rpc_error.ErrRateLimited
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (s *streamInterceptor) StreamServerRateLimit(limiter ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if res := limiter.Limit(stream.Context(), info.FullMethod); !res.Allowed {
+			_ = stream.SetHeader(retryAfterHeader(res.RetryAfter))
+			return rpc_error.ErrRateLimited
+		}
+		return handler(srv, stream)
+	}
+}
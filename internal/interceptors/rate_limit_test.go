@@ -0,0 +1,102 @@
+package interceptors_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/interceptors"
+	"bridge/internal/logger"
+	"bridge/internal/ratelimit"
+	"bridge/internal/rpc_error"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestUnaryServerRateLimit(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		limiter = ratelimit.NewLimiter(logger.TestLogger, ratelimit.NewMemoryBackend(0), map[string]ratelimit.Policy{
+			"/api.v1.AuthService/Login": {Key: ratelimit.KeyIP, Limit: 2, Window: time.Minute},
+		})
+		unary = interceptors.NewUnaryServerInterceptors()
+	)
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		unary.UnaryServerAccessLog(logger.TestLogger, nil),
+		unary.UnaryServerRateLimit(limiter),
+	))
+	pb.RegisterAuthServiceServer(srv, &requestAuthServer{l: logger.TestLogger})
+
+	lis, err := net.Listen("tcp", ":0")
+	asserts.NoError(err)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	asserts.NoError(err)
+	t.Cleanup(func() {
+		_ = cc.Close()
+	})
+
+	var (
+		client = pb.NewAuthServiceClient(cc)
+		req    = &pb.LoginRequest{Email: "jane@example.com", Password: "password"}
+	)
+
+	for i := 0; i < 2; i++ {
+		_, err = client.Login(context.Background(), req)
+		asserts.NoError(err)
+	}
+
+	var header metadata.MD
+	_, err = client.Login(context.Background(), req, grpc.Header(&header))
+	asserts.Equal(codes.ResourceExhausted, status.Code(err))
+	asserts.EqualError(err, rpc_error.ErrRateLimited.Error())
+
+	if asserts.Len(header.Get(interceptors.HeaderRetryAfter), 1) {
+		seconds, err := strconv.Atoi(header.Get(interceptors.HeaderRetryAfter)[0])
+		asserts.NoError(err)
+		asserts.GreaterOrEqual(seconds, 1)
+		asserts.LessOrEqual(seconds, 30)
+	}
+
+	// Clients behind the gateway are limited separately.
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-forwarded-for", "203.0.113.7")
+	_, err = client.Login(ctx, req)
+	asserts.NoError(err)
+}
+
+func TestStreamServerRateLimit(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		limiter = ratelimit.NewLimiter(logger.TestLogger, ratelimit.NewMemoryBackend(0), map[string]ratelimit.Policy{
+			testStreamInfo.FullMethod: {Key: ratelimit.KeyIP, Limit: 1, Window: time.Hour},
+		})
+		interceptor = interceptors.NewStreamServerInterceptors().StreamServerRateLimit(limiter)
+		ctx         = logger.ContextWithRequest(context.Background(), &logger.Request{Peer: "198.51.100.20:443"})
+		stream      = &headerServerStream{testServerStream: testServerStream{ctx: ctx}}
+		handled     int
+	)
+
+	handler := func(interface{}, grpc.ServerStream) error {
+		handled++
+		return nil
+	}
+
+	asserts.NoError(interceptor(nil, stream, testStreamInfo, handler))
+
+	err := interceptor(nil, stream, testStreamInfo, handler)
+	asserts.ErrorIs(err, rpc_error.ErrRateLimited)
+	asserts.Equal(1, handled)
+	asserts.Len(stream.header.Get(interceptors.HeaderRetryAfter), 1)
+}
@@ -0,0 +1,26 @@
+package interceptors
+
+import (
+	"bridge/internal/db"
+	"context"
+	"google.golang.org/grpc"
+)
+
+func (u *unaryInterceptor) UnaryServerReadYourWrites() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		return handler(db.WithSession(ctx), req)
+	}
+}
+
+func (s *streamInterceptor) StreamServerReadYourWrites() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := WrapServerStream(stream)
+		wrapped.WrappedContext = db.WithSession(stream.Context())
+		return handler(srv, wrapped)
+	}
+}
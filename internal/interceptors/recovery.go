@@ -0,0 +1,91 @@
+package interceptors
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/metrics"
+	"bridge/internal/rpc_error"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// HeaderIncidentID is the trailer used for returning the incident ID of a recovered panic to the client.
+const HeaderIncidentID = "x-incident-id"
+
+// recoverPanic logs the recovered value with its stack, counts it in the metrics and returns the incident ID along
+// with the error returned to the client.
+func recoverPanic(
+	ctx context.Context,
+	l zerolog.Logger,
+	grpcType, fullMethod string,
+	r interface{},
+) (string, error) {
+	metrics.ObservePanicRecovered(grpcType, fullMethod)
+
+	// The request-scoped logger already has the method when the access log interceptor runs first.
+	if _, ok := logger.RequestFromContext(ctx); ok {
+		l = logger.FromContext(ctx, l)
+	} else {
+		l = l.With().Str("method", fullMethod).Logger()
+	}
+
+	var (
+		incidentID = uuid.NewString()
+		err        = errors.WithStack(fmt.Errorf("panic: %v", r))
+	)
+
+	l.Error().
+		Stack().
+		Err(err).
+		Str("incident_id", incidentID).
+		Msg("recovered from panic")
+
+	return incidentID, rpc_error.ErrServerError
+}
+
+func (u *unaryInterceptor) UnaryServerRecovery(l zerolog.Logger) grpc.UnaryServerInterceptor {
+	l = l.With().Str("action", "recover unary rpc").Logger()
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				var incidentID string
+				incidentID, err = recoverPanic(ctx, l, metrics.GrpcTypeUnary, info.FullMethod, r)
+				_ = grpc.SetTrailer(ctx, metadata.Pairs(HeaderIncidentID, incidentID))
+				resp = nil
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func (s *streamInterceptor) StreamServerRecovery(l zerolog.Logger) grpc.StreamServerInterceptor {
+	l = l.With().Str("action", "recover stream rpc").Logger()
+
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				var incidentID string
+				incidentID, err = recoverPanic(stream.Context(), l, metrics.GrpcTypeStream, info.FullMethod, r)
+				stream.SetTrailer(metadata.Pairs(HeaderIncidentID, incidentID))
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
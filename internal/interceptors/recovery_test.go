@@ -0,0 +1,118 @@
+package interceptors_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/interceptors"
+	"bridge/internal/logger"
+	"bridge/internal/metrics"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type panickingAuthServer struct {
+	pb.UnimplementedAuthServiceServer
+}
+
+func (s *panickingAuthServer) Login(context.Context, *pb.LoginRequest) (*pb.LoginResponse, error) {
+	var u *pb.User
+	return &pb.LoginResponse{AccessToken: u.ID}, nil
+}
+
+// trailerServerStream records the trailers set on the stream.
+type trailerServerStream struct {
+	testServerStream
+	trailer metadata.MD
+}
+
+func (s *trailerServerStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+// scrapeMetrics returns the metrics in the Prometheus exposition format.
+func scrapeMetrics(t *testing.T) string {
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestUnaryServerRecovery(t *testing.T) {
+	asserts := assert.New(t)
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptors.NewUnaryServerInterceptors().UnaryServerRecovery(logger.TestLogger),
+	))
+	pb.RegisterAuthServiceServer(srv, &panickingAuthServer{})
+
+	lis, err := net.Listen("tcp", ":0")
+	asserts.NoError(err)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	asserts.NoError(err)
+	t.Cleanup(func() {
+		_ = cc.Close()
+	})
+
+	var (
+		client  = pb.NewAuthServiceClient(cc)
+		trailer metadata.MD
+	)
+
+	res, err := client.Login(
+		context.Background(),
+		&pb.LoginRequest{Email: "jane@example.com", Password: "password"},
+		grpc.Trailer(&trailer),
+	)
+	asserts.Nil(res)
+	asserts.Equal(codes.Internal, status.Code(err))
+	asserts.Len(trailer.Get(interceptors.HeaderIncidentID), 1)
+	asserts.NotEmpty(trailer.Get(interceptors.HeaderIncidentID)[0])
+	asserts.Contains(
+		scrapeMetrics(t),
+		`bridge_grpc_server_panics_recovered_total{grpc_method="Login",grpc_service="api.v1.AuthService",`+
+			`grpc_type="unary"} 1`,
+	)
+
+	// The server keeps serving after a panic.
+	_, err = client.Login(context.Background(), &pb.LoginRequest{})
+	asserts.Equal(codes.Internal, status.Code(err))
+}
+
+func TestStreamServerRecovery(t *testing.T) {
+	var (
+		asserts     = assert.New(t)
+		interceptor = interceptors.NewStreamServerInterceptors().StreamServerRecovery(logger.TestLogger)
+		stream      = &trailerServerStream{testServerStream: testServerStream{ctx: context.Background()}}
+	)
+
+	err := interceptor(nil, stream, testStreamInfo, func(interface{}, grpc.ServerStream) error {
+		panic("stream handler panic")
+	})
+	asserts.Equal(codes.Internal, status.Code(err))
+	asserts.Len(stream.trailer.Get(interceptors.HeaderIncidentID), 1)
+	asserts.Contains(
+		scrapeMetrics(t),
+		`bridge_grpc_server_panics_recovered_total{grpc_method="Stream",grpc_service="api.v1.TestService",`+
+			`grpc_type="stream"} 1`,
+	)
+
+	err = interceptor(nil, stream, testStreamInfo, func(interface{}, grpc.ServerStream) error {
+		return nil
+	})
+	asserts.NoError(err)
+}
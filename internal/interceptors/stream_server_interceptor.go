@@ -0,0 +1,138 @@
+package interceptors
+
+import (
+	"bridge/internal/audit"
+	"bridge/internal/ratelimit"
+	"bridge/services/auth"
+	"bridge/services/kyc"
+	"context"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"net"
+)
+
+// StreamServerInterceptor provides a hook to intercept the execution of a streaming RPC on the server.
+//
+// StreamServerValidator returns a new stream server interceptor that validates every message received from the
+// client. Invalid messages will be rejected with `InvalidArgument` when they are received by the handler.
+//
+// StreamServerAuthenticator returns a new stream server interceptor that authenticates the stream before the handler
+// runs, honouring auth.ServiceAuthFuncOverride like UnaryServerAuthenticator. The authenticated context is available
+// to the handler through the stream's Context.
+//
+// Unauthenticated streams will be rejected with `Unauthenticated` before reaching any userspace handlers.
+//
+// StreamServerKYCEnforcer returns a new stream server interceptor that requires an approved KYC submission for the
+// methods configured on the kyc.Enforcer. It must run after StreamServerAuthenticator.
+//
+// StreamServerRateLimit returns a new stream server interceptor that counts the streams like UnaryServerRateLimit. It
+// must run after StreamServerAuthenticator.
+//
+// StreamServerAudit returns a new stream server interceptor that audits the stream like UnaryServerAudit. It must run
+// before StreamServerRecovery and StreamServerAuthenticator.
+//
+// StreamServerLocalizer returns a new stream server interceptor that localizes the errors like UnaryServerLocalizer.
+//
+// StreamServerReadYourWrites returns a new stream server interceptor that starts a database session for every stream
+// like UnaryServerReadYourWrites.
+//
+// StreamServerRecovery returns a new stream server interceptor that recovers from panics like UnaryServerRecovery.
+//
+// StreamServerAccessLog returns a new stream server interceptor that assigns request IDs and logs completed streams
+// like UnaryServerAccessLog.
+//
+// StreamServerMetrics returns a new stream server interceptor that records streams like UnaryServerMetrics.
+//
+// StreamServerTracing returns a new stream server interceptor that starts a span for every stream like
+// UnaryServerTracing.
+type StreamServerInterceptor interface {
+	StreamServerValidator() grpc.StreamServerInterceptor
+	StreamServerAuthenticator(authFunc auth.AuthenticatorFunc) grpc.StreamServerInterceptor
+	StreamServerKYCEnforcer(enforcer kyc.Enforcer) grpc.StreamServerInterceptor
+	StreamServerRateLimit(limiter ratelimit.Limiter) grpc.StreamServerInterceptor
+	StreamServerAudit(auditor audit.Auditor) grpc.StreamServerInterceptor
+	StreamServerLocalizer() grpc.StreamServerInterceptor
+	StreamServerReadYourWrites() grpc.StreamServerInterceptor
+	StreamServerRecovery(l zerolog.Logger) grpc.StreamServerInterceptor
+	StreamServerAccessLog(l zerolog.Logger, trustedProxies []*net.IPNet) grpc.StreamServerInterceptor
+	StreamServerMetrics() grpc.StreamServerInterceptor
+	StreamServerTracing() grpc.StreamServerInterceptor
+}
+
+type streamInterceptor struct{}
+
+// WrappedServerStream is a grpc.ServerStream that allows interceptors to replace the stream's context.
+type WrappedServerStream struct {
+	grpc.ServerStream
+	// WrappedContext is the context returned by Context.
+	WrappedContext context.Context
+}
+
+// Context returns the wrapper's WrappedContext, overwriting the nested grpc.ServerStream.Context().
+func (w *WrappedServerStream) Context() context.Context {
+	return w.WrappedContext
+}
+
+// WrapServerStream returns a WrappedServerStream, reusing the stream if it's already wrapped.
+func WrapServerStream(stream grpc.ServerStream) *WrappedServerStream {
+	if existing, ok := stream.(*WrappedServerStream); ok {
+		return existing
+	}
+	return &WrappedServerStream{ServerStream: stream, WrappedContext: stream.Context()}
+}
+
+// validatingServerStream validates every message received on the stream.
+type validatingServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(m)
+}
+
+func (s *streamInterceptor) StreamServerValidator() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingServerStream{ServerStream: stream})
+	}
+}
+
+func (s *streamInterceptor) StreamServerAuthenticator(authFunc auth.AuthenticatorFunc) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		var newCtx context.Context
+		var err error
+
+		if overrideSrv, ok := srv.(auth.ServiceAuthFuncOverride); ok {
+			newCtx, err = overrideSrv.AuthenticatorFuncOverride(stream.Context(), info.FullMethod)
+		} else {
+			newCtx, err = authFunc(stream.Context())
+		}
+		if err != nil {
+			return err
+		}
+
+		if u, ok := auth.UserFromContext(newCtx); ok {
+			audit.SetActor(newCtx, u)
+		}
+
+		wrapped := WrapServerStream(stream)
+		wrapped.WrappedContext = newCtx
+		return handler(srv, wrapped)
+	}
+}
+
+func (s *streamInterceptor) StreamServerKYCEnforcer(enforcer kyc.Enforcer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := enforcer.Enforce(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// NewStreamServerInterceptors creates a new instance of StreamServerInterceptor
+func NewStreamServerInterceptors() StreamServerInterceptor {
+	return &streamInterceptor{}
+}
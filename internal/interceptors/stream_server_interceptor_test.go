@@ -0,0 +1,143 @@
+package interceptors_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/interceptors"
+	"bridge/internal/rpc_error"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+type ctxKey struct{}
+
+// testServerStream is a grpc.ServerStream that receives a copy of msg.
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	msg proto.Message
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testServerStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.msg)
+	return nil
+}
+
+type testOverrideServer struct{}
+
+func (s *testOverrideServer) AuthenticatorFuncOverride(ctx context.Context, _ string) (context.Context, error) {
+	return context.WithValue(ctx, ctxKey{}, "override"), nil
+}
+
+type testEnforcer struct {
+	err error
+}
+
+func (e *testEnforcer) Enforce(context.Context, string) error {
+	return e.err
+}
+
+var testStreamInfo = &grpc.StreamServerInfo{FullMethod: "/api.v1.TestService/Stream", IsClientStream: true}
+
+func TestStreamServerValidator(t *testing.T) {
+	var (
+		asserts     = assert.New(t)
+		interceptor = interceptors.NewStreamServerInterceptors().StreamServerValidator()
+	)
+
+	tests := []struct {
+		name string
+		msg  *pb.LoginRequest
+		code codes.Code
+	}{
+		{
+			name: "valid message",
+			msg:  &pb.LoginRequest{Email: "jane@example.com", Password: "password"},
+			code: codes.OK,
+		},
+		{
+			name: "invalid message",
+			msg:  &pb.LoginRequest{Email: "jane", Password: "password"},
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stream := &testServerStream{ctx: context.Background(), msg: tt.msg}
+			err := interceptor(nil, stream, testStreamInfo, func(_ interface{}, stream grpc.ServerStream) error {
+				return stream.RecvMsg(&pb.LoginRequest{})
+			})
+
+			asserts.Equal(tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestStreamServerAuthenticator(t *testing.T) {
+	var (
+		asserts  = assert.New(t)
+		authFunc = func(ctx context.Context) (context.Context, error) {
+			return context.WithValue(ctx, ctxKey{}, "authenticated"), nil
+		}
+		interceptor = interceptors.NewStreamServerInterceptors().StreamServerAuthenticator(authFunc)
+		stream      = &testServerStream{ctx: context.Background()}
+	)
+
+	var got interface{}
+	handler := func(_ interface{}, stream grpc.ServerStream) error {
+		got = stream.Context().Value(ctxKey{})
+		return nil
+	}
+
+	asserts.NoError(interceptor(nil, stream, testStreamInfo, handler))
+	asserts.Equal("authenticated", got)
+
+	asserts.NoError(interceptor(&testOverrideServer{}, stream, testStreamInfo, handler))
+	asserts.Equal("override", got)
+
+	var called bool
+	interceptor = interceptors.NewStreamServerInterceptors().StreamServerAuthenticator(
+		func(ctx context.Context) (context.Context, error) {
+			return nil, rpc_error.ErrUnauthenticated
+		},
+	)
+
+	err := interceptor(nil, stream, testStreamInfo, func(interface{}, grpc.ServerStream) error {
+		called = true
+		return nil
+	})
+	asserts.ErrorIs(err, rpc_error.ErrUnauthenticated)
+	asserts.False(called)
+}
+
+func TestStreamServerKYCEnforcer(t *testing.T) {
+	var (
+		asserts            = assert.New(t)
+		stream             = &testServerStream{ctx: context.Background()}
+		streamInterceptors = interceptors.NewStreamServerInterceptors()
+		handler            = func(interface{}, grpc.ServerStream) error { return nil }
+	)
+
+	err := streamInterceptors.StreamServerKYCEnforcer(&testEnforcer{})(nil, stream, testStreamInfo, handler)
+	asserts.NoError(err)
+
+	err = streamInterceptors.StreamServerKYCEnforcer(&testEnforcer{err: rpc_error.ErrKYCRequired})(
+		nil,
+		stream,
+		testStreamInfo,
+		handler,
+	)
+	asserts.ErrorIs(err, rpc_error.ErrKYCRequired)
+}
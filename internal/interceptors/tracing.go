@@ -0,0 +1,14 @@
+package interceptors
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+func (u *unaryInterceptor) UnaryServerTracing() grpc.UnaryServerInterceptor {
+	return otelgrpc.UnaryServerInterceptor()
+}
+
+func (s *streamInterceptor) StreamServerTracing() grpc.StreamServerInterceptor {
+	return otelgrpc.StreamServerInterceptor()
+}
@@ -1,11 +1,15 @@
 package interceptors
 
 import (
+	"bridge/internal/audit"
+	"bridge/internal/idempotency"
+	"bridge/internal/ratelimit"
 	"bridge/services/auth"
+	"bridge/services/kyc"
 	"context"
+	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"net"
 )
 
 // UnaryServerInterceptor provides a hook to intercept the execution of a unary RPC on the server.
@@ -16,38 +20,65 @@ import (
 // UnaryServerAuthenticator returns a new unary server interceptor that authenticates incoming messages.
 //
 // Invalid messages will be rejected with `Unauthenticated` before reaching any userspace handlers.
+//
+// UnaryServerKYCEnforcer returns a new unary server interceptor that requires an approved KYC submission for the
+// methods configured on the kyc.Enforcer. It must run after UnaryServerAuthenticator.
+//
+// UnaryServerRateLimit returns a new unary server interceptor that counts the calls to the methods with a policy on the
+// ratelimit.Limiter. Calls over the limit will be rejected with `ResourceExhausted` and the number of seconds to wait
+// in the HeaderRetryAfter header. It must run after UnaryServerAuthenticator so that calls can be counted by user.
+//
+// UnaryServerIdempotency returns a new unary server interceptor that replays the response of the calls retried with
+// the same idempotency key to the methods configured on the idempotency.Guard, with the HeaderReplayed header set.
+// Reusing a key for a different request will be rejected with `InvalidArgument`. It must run after
+// UnaryServerAuthenticator since the keys are scoped to the user. Streams aren't covered since their responses can't
+// be replayed.
+//
+// UnaryServerAudit returns a new unary server interceptor that attaches an audit trail to the call, on which the
+// handler records its actions using audit.Record, and writes the audit event of the call once it completes if it
+// failed or recorded nothing. It must run before UnaryServerRecovery and the
+// interceptors rejecting calls, such as UnaryServerAuthenticator and UnaryServerRateLimit, so that the rejected calls
+// are audited too. UnaryServerAuthenticator records the actor on the trail.
+//
+// UnaryServerLocalizer returns a new unary server interceptor that adds a LocalizedMessage detail to the errors, in
+// the locale negotiated from the i18n.HeaderAcceptLanguage header, and translates their field violations. It must run
+// before UnaryServerRecovery so that the errors of recovered panics are localized.
+//
+// UnaryServerReadYourWrites returns a new unary server interceptor that starts a database session for every call, so
+// that the reads made after a write are routed to the primary rather than to a replica which may lag behind. It must
+// run before the interceptors which access the database.
+//
+// UnaryServerRecovery returns a new unary server interceptor that recovers from panics in the handler and the
+// interceptors after it. The panic is logged with its stack and the client receives `Internal` with an incident ID
+// in the HeaderIncidentID trailer.
+//
+// UnaryServerAccessLog returns a new unary server interceptor that assigns each call a request ID, or propagates the
+// one sent in HeaderRequestID, and attaches a request-scoped logger to the context. A line with the method, peer,
+// user ID, duration and status code is logged once the call completes. The client's address is read from the
+// X-Forwarded-For entries added by trustedProxies, see logger.ClientIP. It must be the first interceptor in the chain.
+//
+// UnaryServerMetrics returns a new unary server interceptor that records the number and latency of calls by method
+// and status code. It must run before UnaryServerRecovery so that panics are recorded as `Internal`.
+//
+// UnaryServerTracing returns a new unary server interceptor that starts a span for every call, continuing the W3C
+// trace context sent by the client. It must run before UnaryServerAccessLog so that the access log has the trace ID.
 type UnaryServerInterceptor interface {
 	UnaryServerValidator() grpc.UnaryServerInterceptor
 	UnaryServerAuthenticator(authFunc auth.AuthenticatorFunc) grpc.UnaryServerInterceptor
+	UnaryServerKYCEnforcer(enforcer kyc.Enforcer) grpc.UnaryServerInterceptor
+	UnaryServerRateLimit(limiter ratelimit.Limiter) grpc.UnaryServerInterceptor
+	UnaryServerIdempotency(guard idempotency.Guard) grpc.UnaryServerInterceptor
+	UnaryServerAudit(auditor audit.Auditor) grpc.UnaryServerInterceptor
+	UnaryServerLocalizer() grpc.UnaryServerInterceptor
+	UnaryServerReadYourWrites() grpc.UnaryServerInterceptor
+	UnaryServerRecovery(l zerolog.Logger) grpc.UnaryServerInterceptor
+	UnaryServerAccessLog(l zerolog.Logger, trustedProxies []*net.IPNet) grpc.UnaryServerInterceptor
+	UnaryServerMetrics() grpc.UnaryServerInterceptor
+	UnaryServerTracing() grpc.UnaryServerInterceptor
 }
 
 type unaryInterceptor struct{}
 
-// The validate interface starting with protoc-gen-validate v0.6.0.
-// See https://github.com/envoyproxy/protoc-gen-validate/pull/455.
-type validator interface {
-	Validate(all bool) error
-}
-
-// The validate interface prior to protoc-gen-validate v0.6.0.
-type validatorLegacy interface {
-	Validate() error
-}
-
-func validate(req interface{}) error {
-	switch v := req.(type) {
-	case validatorLegacy:
-		if err := v.Validate(); err != nil {
-			return status.Error(codes.InvalidArgument, err.Error())
-		}
-	case validator:
-		if err := v.Validate(false); err != nil {
-			return status.Error(codes.InvalidArgument, err.Error())
-		}
-	}
-	return nil
-}
-
 func (u *unaryInterceptor) UnaryServerValidator() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -75,10 +106,23 @@ func (u *unaryInterceptor) UnaryServerAuthenticator(authFunc auth.AuthenticatorF
 		if err != nil {
 			return nil, err
 		}
+
+		if u, ok := auth.UserFromContext(newCtx); ok {
+			audit.SetActor(newCtx, u)
+		}
 		return handler(newCtx, req)
 	}
 }
 
+func (u *unaryInterceptor) UnaryServerKYCEnforcer(enforcer kyc.Enforcer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := enforcer.Enforce(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
 // NewUnaryServerInterceptors creates a new instance of UnaryServerInterceptor
 func NewUnaryServerInterceptors() UnaryServerInterceptor {
 	return &unaryInterceptor{}
@@ -0,0 +1,142 @@
+package interceptors
+
+import (
+	"bridge/internal/rpc_error"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+	"unicode"
+)
+
+// The validate interface of protoc-gen-validate v0.6.8 and later, collecting every violation.
+type validatorAll interface {
+	ValidateAll() error
+}
+
+// The validate interface starting with protoc-gen-validate v0.6.0.
+// See https://github.com/envoyproxy/protoc-gen-validate/pull/455.
+type validator interface {
+	Validate(all bool) error
+}
+
+// The validate interface prior to protoc-gen-validate v0.6.0.
+type validatorLegacy interface {
+	Validate() error
+}
+
+// validationError is implemented by the errors of a single field violation.
+type validationError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// multiError is implemented by the errors holding every violation of a message.
+type multiError interface {
+	AllErrors() []error
+}
+
+// validate validates req, returning an InvalidArgument error with a BadRequest detail listing the violations of
+// every field.
+func validate(req interface{}) error {
+	var err error
+	switch v := req.(type) {
+	case validatorAll:
+		err = v.ValidateAll()
+	case validator:
+		err = v.Validate(true)
+	case validatorLegacy:
+		err = v.Validate()
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	var md protoreflect.MessageDescriptor
+	if msg, ok := req.(proto.Message); ok {
+		md = msg.ProtoReflect().Descriptor()
+	}
+
+	return rpc_error.NewValidationError(fieldViolations(err, md, ""))
+}
+
+// fieldViolations returns the violations held by err, a validation error of a message described by md. The fields
+// are named by their JSON names and prefixed with prefix, the path of the message within the request.
+func fieldViolations(
+	err error,
+	md protoreflect.MessageDescriptor,
+	prefix string,
+) []*errdetails.BadRequest_FieldViolation {
+	switch e := err.(type) {
+	case multiError:
+		var violations []*errdetails.BadRequest_FieldViolation
+		for _, err := range e.AllErrors() {
+			violations = append(violations, fieldViolations(err, md, prefix)...)
+		}
+		return violations
+	case validationError:
+		// Repeated and map fields are suffixed with the index or key of the item.
+		name, index := e.Field(), ""
+		if i := strings.IndexByte(name, '['); i != -1 {
+			name, index = name[:i], name[i:]
+		}
+
+		path := name
+		fd := findField(md, name)
+		if fd != nil {
+			path = fd.JSONName()
+		}
+		path = prefix + path + index
+
+		// The violations of embedded messages are reported for their own fields.
+		switch e.Cause().(type) {
+		case validationError, multiError:
+			if fd != nil && fd.Message() != nil {
+				return fieldViolations(e.Cause(), fd.Message(), path+".")
+			}
+		}
+
+		return []*errdetails.BadRequest_FieldViolation{{Field: path, Description: e.Reason()}}
+	}
+
+	return []*errdetails.BadRequest_FieldViolation{{Field: strings.TrimSuffix(prefix, "."), Description: err.Error()}}
+}
+
+// findField returns the field of md whose Go name is name, or nil if there is none.
+func findField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if md == nil {
+		return nil
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); goName(string(fd.Name())) == name {
+			return fd
+		}
+	}
+	return nil
+}
+
+// goName returns the name of the Go struct field generated for the proto field name.
+func goName(name string) string {
+	var (
+		b     strings.Builder
+		upper = true
+	)
+
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+		}
+		b.WriteRune(r)
+		upper = unicode.IsDigit(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package interceptors_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/interceptors"
+	"bridge/internal/rpc_error"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// fieldViolations returns the fields violated in err along with their descriptions.
+func fieldViolations(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	violations := map[string]string{}
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				violations[v.GetField()] = v.GetDescription()
+			}
+		}
+	}
+	return violations
+}
+
+func TestUnaryServerValidator(t *testing.T) {
+	var (
+		asserts     = assert.New(t)
+		interceptor = interceptors.NewUnaryServerInterceptors().UnaryServerValidator()
+		info        = &grpc.UnaryServerInfo{FullMethod: "/api.v1.TestService/Unary"}
+		handled     int
+	)
+
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		handled++
+		return nil, nil
+	}
+
+	_, err := interceptor(context.Background(), &pb.RegisterRequest{
+		Name:            "Jane Doe",
+		Email:           "jane@example.com",
+		PhoneNumber:     "+25470000000",
+		Password:        "password",
+		ConfirmPassword: "password",
+	}, info, handler)
+	asserts.NoError(err)
+	asserts.Equal(1, handled)
+
+	// Every violated field is returned, named by its JSON name.
+	_, err = interceptor(context.Background(), &pb.RegisterRequest{
+		Email:           "jane",
+		Password:        "password",
+		ConfirmPassword: "pass",
+	}, info, handler)
+	asserts.Equal(codes.InvalidArgument, status.Code(err))
+	asserts.Equal(rpc_error.ReasonValidationFailed, rpc_error.Reason(err))
+	asserts.Equal(map[string]string{
+		"name":            "value length must be at least 3 runes",
+		"email":           "value must be a valid email address",
+		"phone_number":    "value length must be 12 runes",
+		"confirmPassword": "value length must be at least 8 runes",
+	}, fieldViolations(t, err))
+
+	// The violations of embedded and repeated messages are returned for their own fields.
+	_, err = interceptor(context.Background(), &pb.SubmitKYCRequest{
+		IdNumber: "12345678",
+		KraPin:   "A123456789B",
+		Documents: []*pb.KYCDocument{{
+			Type:        pb.KYCDocument_NATIONAL_ID_FRONT,
+			FileUrl:     "https://example.com/id.png",
+			ContentType: "image/gif",
+			SizeBytes:   1024,
+			Sha256:      "abc",
+		}},
+	}, info, handler)
+	asserts.Equal(map[string]string{
+		"documents[0].content_type": "value must be in list [image/jpeg image/png application/pdf]",
+		"documents[0].sha256":       "value does not match regex pattern \"^[a-f0-9]{64}$\"",
+	}, fieldViolations(t, err))
+	asserts.Equal(1, handled)
+}
@@ -0,0 +1,206 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultStopTimeout is how long a component without a Timeout has to stop.
+const DefaultStopTimeout = 10 * time.Second
+
+// Exit codes returned by Manager.Run.
+const (
+	// ExitOK is returned when every component stopped cleanly after a shutdown request.
+	ExitOK = 0
+	// ExitComponentFailure is returned when a component failed while running.
+	ExitComponentFailure = 1
+	// ExitShutdownFailure is returned when a component failed to stop or didn't stop before its deadline.
+	ExitShutdownFailure = 2
+)
+
+// ErrStopTimeout is returned when a component doesn't stop before its deadline.
+var ErrStopTimeout = errors.New("lifecycle: component did not stop before the deadline")
+
+// Component is a part of the app started and stopped by the Manager, for example a server, a background worker or
+// a database connection.
+type Component struct {
+	// Name identifies the component in the logs.
+	Name string
+	// Start runs the component in its own goroutine until ctx is canceled. Servers block until they're stopped and
+	// must return nil once stopped. An error returned before shutdown starts stops the app.
+	//
+	// Start is optional, components that are already running such as connections only need Stop.
+	Start func(ctx context.Context) error
+	// Stop stops the component, returning once ctx is done at the latest. After Stop returns the context passed to
+	// Start is canceled and the Manager waits for Start to return.
+	//
+	// Stop is optional, background workers only need their Start context canceled.
+	Stop func(ctx context.Context) error
+	// Timeout is the deadline for stopping the component. DefaultStopTimeout is used if it's zero.
+	Timeout time.Duration
+}
+
+// Manager starts components in the order they're registered and stops them in reverse order, so that a component
+// is stopped before the components it depends on.
+type Manager interface {
+	// Register adds components, which are started when Run is called.
+	Register(components ...Component)
+	// Run starts the components and blocks until ctx is done or a component fails. The components are then stopped
+	// in reverse order, each with its own deadline, and the exit code for the process is returned.
+	Run(ctx context.Context) int
+}
+
+type running struct {
+	Component
+	cancel context.CancelFunc
+	done   chan struct{}
+	// err is the error returned by Start and failed reports whether it was handled as a failure. They're set before
+	// done is closed.
+	err    error
+	failed bool
+}
+
+type manager struct {
+	mu         sync.Mutex
+	components []Component
+	l          zerolog.Logger
+}
+
+func (m *manager) Register(components ...Component) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.components = append(m.components, components...)
+}
+
+func (m *manager) Run(ctx context.Context) int {
+	m.mu.Lock()
+	components := append([]Component(nil), m.components...)
+	m.mu.Unlock()
+
+	var (
+		l        = m.l.With().Str("action", "run").Logger()
+		failures = make(chan error, len(components))
+		started  = make([]*running, 0, len(components))
+		stopping = make(chan struct{})
+	)
+
+	for _, c := range components {
+		r := &running{Component: c, done: make(chan struct{})}
+
+		var runCtx context.Context
+		runCtx, r.cancel = context.WithCancel(context.Background())
+
+		if c.Start == nil {
+			close(r.done)
+		} else {
+			l.Info().Str("component", c.Name).Msg("starting component")
+
+			go func() {
+				defer close(r.done)
+
+				r.err = r.Start(runCtx)
+				if r.err == nil {
+					return
+				}
+
+				select {
+				case <-stopping:
+				default:
+					r.failed = true
+					failures <- fmt.Errorf("%s: %w", r.Name, r.err)
+				}
+			}()
+		}
+
+		started = append(started, r)
+	}
+
+	code := ExitOK
+
+	select {
+	case <-ctx.Done():
+		l.Info().Msg("shutdown requested")
+	case err := <-failures:
+		l.Err(err).Msg("component failed, shutting down")
+		code = ExitComponentFailure
+	}
+
+	close(stopping)
+
+	if !m.stop(started) && code == ExitOK {
+		code = ExitShutdownFailure
+	}
+
+	l.Info().Int("exit_code", code).Msg("shutdown completed")
+	return code
+}
+
+// stop stops the components in reverse order and reports whether they all stopped cleanly.
+func (m *manager) stop(started []*running) bool {
+	ok := true
+
+	for i := len(started) - 1; i >= 0; i-- {
+		r := started[i]
+		l := m.l.With().Str("action", "stop").Str("component", r.Name).Logger()
+
+		timeout := r.Timeout
+		if timeout <= 0 {
+			timeout = DefaultStopTimeout
+		}
+
+		start := time.Now()
+		if err := stopComponent(r, timeout); err != nil {
+			l.Err(err).Dur("timeout", timeout).Msg("failed to stop component")
+			ok = false
+			continue
+		}
+
+		l.Info().Dur("duration", time.Since(start)).Msg("component stopped")
+	}
+
+	return ok
+}
+
+func stopComponent(r *running, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var err error
+	if r.Stop != nil {
+		err = r.Stop(ctx)
+	}
+
+	r.cancel()
+
+	// Start may have returned as the deadline passed, which isn't a timeout.
+	select {
+	case <-r.done:
+	default:
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return ErrStopTimeout
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Start failing while stopping is only reported now since it wasn't handled as a failure.
+	if !r.failed {
+		return r.err
+	}
+	return nil
+}
+
+// NewManager creates a new Manager.
+func NewManager(l zerolog.Logger) Manager {
+	return &manager{l: l}
+}
@@ -0,0 +1,123 @@
+package lifecycle_test
+
+import (
+	"bridge/internal/lifecycle"
+	"bridge/internal/logger"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder records the order the components are stopped in.
+type recorder struct {
+	mu      sync.Mutex
+	stopped []string
+}
+
+func (r *recorder) component(name string) lifecycle.Component {
+	return lifecycle.Component{
+		Name: name,
+		Stop: func(context.Context) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			r.stopped = append(r.stopped, name)
+			return nil
+		},
+	}
+}
+
+func TestManager_Run(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		rec     = &recorder{}
+		lm      = lifecycle.NewManager(logger.TestLogger)
+		started = make(chan struct{})
+		worker  = make(chan struct{})
+	)
+
+	lm.Register(rec.component("database"))
+	lm.Register(lifecycle.Component{
+		Name: "worker",
+		Start: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			close(worker)
+			return nil
+		},
+	})
+	lm.Register(rec.component("server"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	asserts.Equal(lifecycle.ExitOK, lm.Run(ctx))
+	asserts.Equal([]string{"server", "database"}, rec.stopped)
+
+	// The worker's context is canceled when it's stopped.
+	select {
+	case <-worker:
+	default:
+		t.Error("worker was not stopped")
+	}
+}
+
+func TestManager_Run_ComponentFailure(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		rec     = &recorder{}
+		lm      = lifecycle.NewManager(logger.TestLogger)
+	)
+
+	lm.Register(
+		rec.component("database"),
+		lifecycle.Component{
+			Name: "server",
+			Start: func(context.Context) error {
+				return errors.New("address already in use")
+			},
+		},
+	)
+
+	asserts.Equal(lifecycle.ExitComponentFailure, lm.Run(context.Background()))
+	asserts.Equal([]string{"database"}, rec.stopped)
+}
+
+func TestManager_Run_ShutdownFailure(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		rec     = &recorder{}
+		lm      = lifecycle.NewManager(logger.TestLogger)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	lm.Register(
+		rec.component("database"),
+		lifecycle.Component{
+			Name: "stuck worker",
+			Start: func(context.Context) error {
+				select {}
+			},
+			Timeout: 10 * time.Millisecond,
+		},
+		lifecycle.Component{
+			Name: "server",
+			Stop: func(context.Context) error {
+				return errors.New("connection reset")
+			},
+		},
+	)
+
+	asserts.Equal(lifecycle.ExitShutdownFailure, lm.Run(ctx))
+
+	// The remaining components are stopped after a failure.
+	asserts.Equal([]string{"database"}, rec.stopped)
+}
@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+// headerForwardedFor is set by the gRPC-Gateway to the X-Forwarded-For header of the HTTP request followed by the
+// address of the HTTP client.
+const headerForwardedFor = "x-forwarded-for"
+
+// defaultTrustedProxies are the proxies trusted when none are configured, which is the loopback interface where the
+// gRPC-Gateway runs.
+var defaultTrustedProxies = []*net.IPNet{
+	{IP: net.IPv4(127, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv6loopback, Mask: net.CIDRMask(128, 128)},
+}
+
+type requestCtxKey struct{}
+
+// Request holds the values added to every log line written while handling a request.
+//
+// The user ID is set once the request is authenticated, which happens after the Request is attached to the context,
+// so it's guarded for concurrent access.
+type Request struct {
+	ID     string
+	Method string
+	Peer   string
+	// TrustedProxies are the networks of the proxies whose X-Forwarded-For entries are trusted, the loopback interface
+	// if it's empty.
+	TrustedProxies []*net.IPNet
+
+	mu     sync.RWMutex
+	userID string
+}
+
+// SetUserID records the authenticated user making the request.
+func (r *Request) SetUserID(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.userID = id
+}
+
+// UserID returns the authenticated user making the request, if any.
+func (r *Request) UserID() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.userID
+}
+
+// ContextWithRequest attaches the request to ctx.
+func ContextWithRequest(ctx context.Context, r *Request) context.Context {
+	return context.WithValue(ctx, requestCtxKey{}, r)
+}
+
+// RequestFromContext returns the request attached to ctx.
+func RequestFromContext(ctx context.Context) (*Request, bool) {
+	r, ok := ctx.Value(requestCtxKey{}).(*Request)
+	return r, ok
+}
+
+// SetUserID records the authenticated user on the request attached to ctx, if any.
+func SetUserID(ctx context.Context, id string) {
+	if r, ok := RequestFromContext(ctx); ok {
+		r.SetUserID(id)
+	}
+}
+
+// ClientIP returns the IP address of the client making the request attached to ctx. The X-Forwarded-For entries are
+// read from the last one, appended by the gRPC-Gateway, for as long as the address they were received from is one of
+// the request's trusted proxies. The entries before it are sent by the client and can't be trusted.
+func ClientIP(ctx context.Context) string {
+	r, ok := RequestFromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(r.Peer)
+	if err != nil {
+		host = r.Peer
+	}
+
+	proxies := r.TrustedProxies
+	if len(proxies) == 0 {
+		proxies = defaultTrustedProxies
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !containsIP(proxies, ip) {
+		return host
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return host
+	}
+
+	// The gateway adds its value last, after the metadata forwarded from the client's headers.
+	values := md.Get(headerForwardedFor)
+	if len(values) == 0 || values[len(values)-1] == "" {
+		return host
+	}
+
+	entries := strings.Split(values[len(values)-1], ",")
+	for i := len(entries) - 1; i >= 0; i-- {
+		if ip = net.ParseIP(strings.TrimSpace(entries[i])); ip == nil {
+			return ""
+		}
+
+		if !containsIP(proxies, ip) {
+			break
+		}
+	}
+
+	return ip.String()
+}
+
+// containsIP reports whether ip is in one of networks.
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, n := range networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTrustedProxies parses a comma separated list of the CIDRs or IP addresses of the trusted proxies, for example
+// "10.0.0.0/8,192.0.2.10".
+func ParseTrustedProxies(s string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if !strings.Contains(part, "/") {
+			ip := net.ParseIP(part)
+			if ip == nil {
+				return nil, fmt.Errorf("logger: invalid trusted proxy %q", part)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(part)
+		if err != nil {
+			return nil, fmt.Errorf("logger: invalid trusted proxy %q", part)
+		}
+		networks = append(networks, n)
+	}
+
+	return networks, nil
+}
+
+// FromContext returns l with the request ID, method, peer and user ID of the request attached to ctx, and the trace
+// and span IDs of the span in ctx. Outside a request and a span, for example in background jobs, l is returned as is.
+//
+// Services and repositories keep their own logger with fields such as the service name, so the request fields are
+// added to that logger rather than replacing it with the one attached using zerolog.Logger.WithContext.
+func FromContext(ctx context.Context, l zerolog.Logger) zerolog.Logger {
+	var (
+		r, hasRequest = RequestFromContext(ctx)
+		sc            = trace.SpanContextFromContext(ctx)
+	)
+
+	if !hasRequest && !sc.IsValid() {
+		return l
+	}
+
+	c := l.With()
+
+	if hasRequest {
+		c = c.Str("request_id", r.ID).
+			Str("method", r.Method).
+			Str("peer", r.Peer)
+
+		if userID := r.UserID(); userID != "" {
+			c = c.Str("user_id", userID)
+		}
+	}
+
+	if sc.IsValid() {
+		c = c.Str("trace_id", sc.TraceID().String()).
+			Str("span_id", sc.SpanID().String())
+	}
+
+	return c.Logger()
+}
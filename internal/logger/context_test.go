@@ -0,0 +1,89 @@
+package logger_test
+
+import (
+	"bridge/internal/logger"
+	"bytes"
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		buf     bytes.Buffer
+		l       = zerolog.New(&buf).With().Str("category", "svc").Logger()
+	)
+
+	noRequestLogger := logger.FromContext(context.Background(), l)
+	noRequestLogger.Info().Msg("no request")
+	asserts.Equal(`{"level":"info","category":"svc","message":"no request"}`+"\n", buf.String())
+
+	buf.Reset()
+	ctx := logger.ContextWithRequest(context.Background(), &logger.Request{
+		ID:     "req-123",
+		Method: "/api.v1.AuthService/Login",
+		Peer:   "127.0.0.1:5000",
+	})
+
+	requestLogger := logger.FromContext(ctx, l)
+	requestLogger.Info().Msg("anonymous")
+	asserts.Contains(buf.String(), `"request_id":"req-123"`)
+	asserts.Contains(buf.String(), `"method":"/api.v1.AuthService/Login"`)
+	asserts.Contains(buf.String(), `"peer":"127.0.0.1:5000"`)
+	asserts.Contains(buf.String(), `"category":"svc"`)
+	asserts.NotContains(buf.String(), "user_id")
+
+	buf.Reset()
+	logger.SetUserID(ctx, "user-1")
+	requestLogger = logger.FromContext(ctx, l)
+	requestLogger.Info().Msg("authenticated")
+	asserts.Contains(buf.String(), `"user_id":"user-1"`)
+
+	// SetUserID is a no-op outside a request.
+	logger.SetUserID(context.Background(), "user-1")
+}
+
+func TestFromContext_Span(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		buf     bytes.Buffer
+		l       = zerolog.New(&buf)
+		tp      = sdktrace.NewTracerProvider()
+	)
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+
+	spanLogger := logger.FromContext(ctx, l)
+	spanLogger.Info().Msg("in span")
+
+	asserts.Contains(buf.String(), `"trace_id":"`+span.SpanContext().TraceID().String()+`"`)
+	asserts.Contains(buf.String(), `"span_id":"`+span.SpanContext().SpanID().String()+`"`)
+	asserts.NotContains(buf.String(), "request_id")
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	asserts := assert.New(t)
+
+	proxies, err := logger.ParseTrustedProxies(" 10.0.0.0/8, 192.0.2.10,, 2001:db8::1")
+	asserts.NoError(err)
+	if asserts.Len(proxies, 3) {
+		asserts.Equal("10.0.0.0/8", proxies[0].String())
+		asserts.Equal("192.0.2.10/32", proxies[1].String())
+		asserts.Equal("2001:db8::1/128", proxies[2].String())
+	}
+
+	proxies, err = logger.ParseTrustedProxies("")
+	asserts.NoError(err)
+	asserts.Empty(proxies)
+
+	for _, s := range []string{"10.0.0.0/33", "proxy.internal"} {
+		_, err = logger.ParseTrustedProxies(s)
+		asserts.Error(err, s)
+	}
+}
@@ -15,8 +15,9 @@ import (
 var once sync.Once
 var log zerolog.Logger
 
-// NewLogger creates a new zerolog.Logger
-func NewLogger() zerolog.Logger {
+// NewLogger creates a new zerolog.Logger masking the fields listed in redactFields, parsed with ParseRedactFields, on
+// top of the proto field options.
+func NewLogger(redactFields string) zerolog.Logger {
 	once.Do(func() {
 		zerolog.ErrorStackMarshaler = pkgerrors.MarshalStack
 		zerolog.TimeFieldFormat = time.RFC3339Nano
@@ -47,6 +48,14 @@ func NewLogger() zerolog.Logger {
 			Timestamp().
 			Caller().
 			Logger()
+
+		fields, err := ParseRedactFields(redactFields)
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid LOG_REDACT_FIELDS, redacting using the proto field options only")
+			return
+		}
+
+		SetRedactor(NewRedactor(fields))
 	})
 
 	return log
@@ -0,0 +1,238 @@
+package logger
+
+import (
+	"bridge/api/v1/pb"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// hashPrefix marks values replaced by their keyed hash.
+const hashPrefix = "hmac:"
+
+// partialVisibleChars is the number of trailing characters kept by a partial mask.
+const partialVisibleChars = 4
+
+// Redactor masks sensitive fields of proto messages before they are logged.
+//
+// The strategy for a field is read from the configured fields, by full name (api.v1.User.email) and then by name
+// (email), falling back to the field's (api.v1.redact) option. Redact returns a redacted copy and leaves the provided
+// message untouched.
+type Redactor interface {
+	Redact(m proto.Message) proto.Message
+}
+
+type redactor struct {
+	fields map[string]pb.Redaction
+}
+
+func (r *redactor) Redact(m proto.Message) proto.Message {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return m
+	}
+
+	clone := proto.Clone(m)
+	r.redactMessage(clone.ProtoReflect())
+	return clone
+}
+
+func (r *redactor) redactMessage(msg protoreflect.Message) {
+	var fields []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		var (
+			strategy = r.strategy(fd)
+			v        = msg.Get(fd)
+		)
+
+		switch {
+		case strategy == pb.Redaction_REDACTION_DROP:
+			msg.Clear(fd)
+		case strategy != pb.Redaction_REDACTION_NONE:
+			if fd.Kind() != protoreflect.StringKind || fd.IsList() || fd.IsMap() {
+				msg.Clear(fd)
+				continue
+			}
+			msg.Set(fd, protoreflect.ValueOfString(Mask(strategy, v.String())))
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				r.redactMessage(mv.Message())
+				return true
+			})
+		case fd.IsList():
+			if fd.Message() == nil {
+				continue
+			}
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				r.redactMessage(list.Get(i).Message())
+			}
+		case fd.Message() != nil:
+			r.redactMessage(v.Message())
+		}
+	}
+}
+
+func (r *redactor) strategy(fd protoreflect.FieldDescriptor) pb.Redaction {
+	if strategy, ok := r.fields[string(fd.FullName())]; ok {
+		return strategy
+	}
+
+	if strategy, ok := r.fields[string(fd.Name())]; ok {
+		return strategy
+	}
+
+	strategy, _ := proto.GetExtension(fd.Options(), pb.E_Redact).(pb.Redaction)
+	return strategy
+}
+
+// Mask applies the redaction strategy to a single value. It's meant for sensitive values logged outside a proto
+// message, for example Str("email", logger.Mask(pb.Redaction_REDACTION_PARTIAL, email)).
+func Mask(strategy pb.Redaction, value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch strategy {
+	case pb.Redaction_REDACTION_NONE:
+		return value
+	case pb.Redaction_REDACTION_HASH:
+		return maskHash(value)
+	case pb.Redaction_REDACTION_PARTIAL:
+		return maskPartial(value)
+	default:
+		return ""
+	}
+}
+
+// maskHash replaces value by its HMAC-SHA256 using the key set with SetHashKey, so that the same value can be
+// correlated across the logs without being recoverable by hashing the few possible values, such as ID numbers. The
+// value is dropped when no key is set.
+func maskHash(value string) string {
+	hashKeyMu.RLock()
+	key := hashKey
+	hashKeyMu.RUnlock()
+
+	if len(key) == 0 {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hashPrefix + hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// maskPartial keeps the first character and the domain of an email, or the last partialVisibleChars characters of
+// any other value as long as at least half of it stays masked.
+func maskPartial(value string) string {
+	if at := strings.LastIndex(value, "@"); at > 0 {
+		local := []rune(value[:at])
+		return string(local[0]) + strings.Repeat("*", len(local)-1) + value[at:]
+	}
+
+	runes := []rune(value)
+
+	visible := partialVisibleChars
+	if len(runes) < 2*partialVisibleChars {
+		visible = 0
+	}
+
+	return strings.Repeat("*", len(runes)-visible) + string(runes[len(runes)-visible:])
+}
+
+// ParseRedactFields parses a comma separated list of field:strategy pairs, for example
+// "password:drop,api.v1.User.email:partial,id_number:hash". The strategies are none, hash, partial and drop.
+func ParseRedactFields(s string) (map[string]pb.Redaction, error) {
+	fields := make(map[string]pb.Redaction)
+	if strings.TrimSpace(s) == "" {
+		return fields, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		field, name, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid redact field %q", pair)
+		}
+
+		strategy, ok := pb.Redaction_value["REDACTION_"+strings.ToUpper(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction strategy %q for %q", name, field)
+		}
+
+		fields[field] = pb.Redaction(strategy)
+	}
+
+	return fields, nil
+}
+
+// NewRedactor creates a Redactor using the provided field strategies on top of the proto field options.
+func NewRedactor(fields map[string]pb.Redaction) Redactor {
+	return &redactor{fields: fields}
+}
+
+var (
+	redactorMu     sync.RWMutex
+	activeRedactor = NewRedactor(nil)
+
+	hashKeyMu sync.RWMutex
+	hashKey   []byte
+)
+
+// SetRedactor replaces the Redactor applied to proto messages logged using zerolog's Interface.
+func SetRedactor(r Redactor) {
+	redactorMu.Lock()
+	defer redactorMu.Unlock()
+
+	activeRedactor = r
+}
+
+// ActiveRedactor returns the Redactor set with SetRedactor, which holds the fields configured in LOG_REDACT_FIELDS.
+func ActiveRedactor() Redactor {
+	redactorMu.RLock()
+	defer redactorMu.RUnlock()
+
+	return activeRedactor
+}
+
+// SetHashKey sets the secret key of the values masked using REDACTION_HASH. Until it's set, these values are dropped.
+func SetHashKey(key []byte) {
+	hashKeyMu.Lock()
+	defer hashKeyMu.Unlock()
+
+	hashKey = key
+}
+
+// marshalInterface is used as zerolog.InterfaceMarshalFunc so every proto message logged using Interface is
+// redacted, regardless of the logger it's logged with.
+func marshalInterface(v any) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return json.Marshal(v)
+	}
+
+	redactorMu.RLock()
+	r := activeRedactor
+	redactorMu.RUnlock()
+
+	return protojson.Marshal(r.Redact(m))
+}
+
+func init() {
+	zerolog.InterfaceMarshalFunc = marshalInterface
+}
@@ -0,0 +1,175 @@
+package logger_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bytes"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+const (
+	testEmail       = "jane.doe@example.com"
+	testPhoneNumber = "254712345678"
+	testPassword    = "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"
+	testIDNumber    = "12345678"
+	testKraPin      = "A123456789Z"
+	testHashKey     = "test-log-hash-key"
+)
+
+func TestMain(m *testing.M) {
+	logger.SetHashKey([]byte(testHashKey))
+	os.Exit(m.Run())
+}
+
+func testUser() *pb.User {
+	return &pb.User{
+		ID:          "5b1f6b0e-4f3c-4b5e-9a57-8c1f0f7d2a11",
+		Name:        "Jane Doe",
+		Email:       testEmail,
+		PhoneNumber: testPhoneNumber,
+		Password:    testPassword,
+		Meta: &pb.UserMeta{
+			KycData: &pb.KYCData{
+				IdNumber: testIDNumber,
+				KraPin:   testKraPin,
+			},
+		},
+	}
+}
+
+func TestRedactor_ProtoFieldOptions(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		buf     bytes.Buffer
+		l       = zerolog.New(&buf)
+		u       = testUser()
+	)
+
+	l.Info().
+		Interface("user", u).
+		Interface("req", &pb.RegisterRequest{
+			Email:           testEmail,
+			PhoneNumber:     testPhoneNumber,
+			Password:        "super-secret-password",
+			ConfirmPassword: "super-secret-password",
+		}).
+		Interface("submission", &pb.KYCSubmission{
+			IdNumber:  testIDNumber,
+			KraPin:    testKraPin,
+			Documents: []*pb.KYCDocument{{FileUrl: "https://files.example.com/id.png?signature=abc"}},
+		}).
+		Interface("res", &pb.LoginResponse{User: u, AccessToken: "v2.local.token"}).
+		Msg("redacted")
+
+	out := buf.String()
+	for _, secret := range []string{
+		testEmail,
+		testPhoneNumber,
+		testPassword,
+		testIDNumber,
+		testKraPin,
+		"super-secret-password",
+		"signature=abc",
+		"v2.local.token",
+	} {
+		asserts.NotContains(out, secret)
+	}
+
+	asserts.Contains(out, "j*******@example.com")
+	asserts.Contains(out, "********5678")
+	asserts.Contains(out, logger.Mask(pb.Redaction_REDACTION_HASH, testIDNumber))
+	asserts.Contains(out, "Jane Doe")
+
+	// The logged message isn't modified.
+	asserts.Equal(testPassword, u.Password)
+	asserts.Equal(testIDNumber, u.Meta.KycData.IdNumber)
+}
+
+func TestRedactor_ConfiguredFields(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		buf     bytes.Buffer
+		l       = zerolog.New(&buf)
+	)
+
+	fields, err := logger.ParseRedactFields("name:drop, api.v1.User.email:none")
+	asserts.NoError(err)
+
+	logger.SetRedactor(logger.NewRedactor(fields))
+	defer logger.SetRedactor(logger.NewRedactor(nil))
+
+	l.Info().Interface("user", testUser()).Msg("redacted")
+
+	out := buf.String()
+	asserts.NotContains(out, "Jane Doe")
+	asserts.NotContains(out, testPassword)
+	asserts.Contains(out, testEmail)
+}
+
+func TestRedactor_NonProtoValues(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		buf     bytes.Buffer
+		l       = zerolog.New(&buf)
+	)
+
+	l.Info().Interface("values", map[string]int{"attempts": 3}).Msg("not a proto")
+	asserts.Contains(buf.String(), `"values":{"attempts":3}`)
+}
+
+func TestParseRedactFields(t *testing.T) {
+	asserts := assert.New(t)
+
+	fields, err := logger.ParseRedactFields("")
+	asserts.NoError(err)
+	asserts.Empty(fields)
+
+	fields, err = logger.ParseRedactFields("password:drop,api.v1.User.email:PARTIAL,id_number:hash")
+	asserts.NoError(err)
+	asserts.Equal(map[string]pb.Redaction{
+		"password":          pb.Redaction_REDACTION_DROP,
+		"api.v1.User.email": pb.Redaction_REDACTION_PARTIAL,
+		"id_number":         pb.Redaction_REDACTION_HASH,
+	}, fields)
+
+	_, err = logger.ParseRedactFields("password")
+	asserts.Error(err)
+
+	_, err = logger.ParseRedactFields("password:scramble")
+	asserts.Error(err)
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy pb.Redaction
+		value    string
+		want     string
+	}{
+		{name: "none", strategy: pb.Redaction_REDACTION_NONE, value: testEmail, want: testEmail},
+		{name: "partial email", strategy: pb.Redaction_REDACTION_PARTIAL, value: "jo@example.com", want: "j*@example.com"},
+		{name: "partial phone", strategy: pb.Redaction_REDACTION_PARTIAL, value: testPhoneNumber, want: "********5678"},
+		{name: "partial short value", strategy: pb.Redaction_REDACTION_PARTIAL, value: "123456", want: "******"},
+		{name: "hash", strategy: pb.Redaction_REDACTION_HASH, value: testIDNumber, want: "hmac:d5d8a8ea6d27673e"},
+		{name: "drop", strategy: pb.Redaction_REDACTION_DROP, value: testPassword, want: ""},
+		{name: "empty", strategy: pb.Redaction_REDACTION_HASH, value: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, logger.Mask(tt.strategy, tt.value))
+		})
+	}
+}
+
+func TestMask_HashWithoutKey(t *testing.T) {
+	logger.SetHashKey(nil)
+	defer logger.SetHashKey([]byte(testHashKey))
+
+	assert.Empty(t, logger.Mask(pb.Redaction_REDACTION_HASH, testIDNumber))
+}
@@ -0,0 +1,203 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+)
+
+const namespace = "bridge"
+
+// Login methods used for labelling the login counters.
+const (
+	LoginMethodPassword = "password"
+	LoginMethodCode     = "code"
+)
+
+// Login results used for labelling the login counters.
+const (
+	LoginSucceeded = "succeeded"
+	LoginFailed    = "failed"
+)
+
+// Cache results used for labelling the cache lookup counters.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// grpc types used for labelling the grpc server metrics.
+const (
+	GrpcTypeUnary  = "unary"
+	GrpcTypeStream = "stream"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "lookups_total",
+		Help:      "Total number of cache lookups by cache and result.",
+	}, []string{"cache", "result"})
+
+	grpcHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handled_total",
+		Help:      "Total number of RPCs completed on the server, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+
+	grpcHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handling_seconds",
+		Help:      "Response latency of RPCs handled by the server.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "gateway",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests handled by the gRPC-Gateway.",
+	}, []string{"code", "method"})
+
+	httpRequestSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "gateway",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests handled by the gRPC-Gateway.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"code", "method"})
+
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "logins_total",
+		Help:      "Total number of login attempts by method, result and failure reason.",
+	}, []string{"method", "result", "reason"})
+
+	panicsRecovered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "panics_recovered_total",
+		Help:      "Total number of panics recovered while handling RPCs.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+
+	rateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "rate_limited_total",
+		Help:      "Total number of RPCs rejected by the rate limiter.",
+	}, []string{"grpc_service", "grpc_method"})
+
+	registrations = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "registrations_total",
+		Help:      "Total number of users registered.",
+	})
+
+	tokenVerificationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "token_verification_failures_total",
+		Help:      "Total number of access tokens that failed verification by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		cacheLookups,
+		grpcHandled,
+		grpcHandlingSeconds,
+		httpRequests,
+		httpRequestSeconds,
+		logins,
+		panicsRecovered,
+		rateLimited,
+		registrations,
+		tokenVerificationFailures,
+	)
+}
+
+// Handler returns the http.Handler serving the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// Registry returns the registry the metrics are registered on.
+func Registry() *prometheus.Registry {
+	return registry
+}
+
+// RegisterDBStats exports the connection pool stats of db, labelled with dbName.
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// splitMethodName splits a grpc full method name into the service and method names.
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", "unknown"
+}
+
+// ObserveCacheLookup records a lookup in cache, with result CacheHit or CacheMiss.
+func ObserveCacheLookup(cache, result string) {
+	cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+// ObserveGrpc records a completed RPC.
+func ObserveGrpc(grpcType, fullMethod string, code codes.Code, d time.Duration) {
+	service, method := splitMethodName(fullMethod)
+
+	grpcHandled.WithLabelValues(grpcType, service, method, code.String()).Inc()
+	grpcHandlingSeconds.WithLabelValues(grpcType, service, method, code.String()).Observe(d.Seconds())
+}
+
+// InstrumentHandler records the number and latency of the requests handled by h.
+func InstrumentHandler(h http.Handler) http.Handler {
+	return promhttp.InstrumentHandlerDuration(httpRequestSeconds, promhttp.InstrumentHandlerCounter(httpRequests, h))
+}
+
+// ObserveLogin records a login attempt using method. The reason is empty for successful logins.
+func ObserveLogin(method, result, reason string) {
+	logins.WithLabelValues(method, result, reason).Inc()
+}
+
+// ObservePanicRecovered records a panic recovered while handling an RPC.
+func ObservePanicRecovered(grpcType, fullMethod string) {
+	service, method := splitMethodName(fullMethod)
+
+	panicsRecovered.WithLabelValues(grpcType, service, method).Inc()
+}
+
+// ObserveRateLimited records an RPC rejected by the rate limiter.
+func ObserveRateLimited(fullMethod string) {
+	service, method := splitMethodName(fullMethod)
+
+	rateLimited.WithLabelValues(service, method).Inc()
+}
+
+// ObserveRegistration records a registered user.
+func ObserveRegistration() {
+	registrations.Inc()
+}
+
+// ObserveTokenVerificationFailure records an access token that failed verification.
+func ObserveTokenVerificationFailure(reason string) {
+	tokenVerificationFailures.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,91 @@
+package metrics_test
+
+import (
+	"bridge/internal/metrics"
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func scrape(t *testing.T) string {
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestObserveGrpc(t *testing.T) {
+	asserts := assert.New(t)
+
+	metrics.ObserveGrpc(metrics.GrpcTypeUnary, "/api.v1.AuthService/Login", codes.Unauthenticated, time.Millisecond)
+
+	out := scrape(t)
+	asserts.Contains(
+		out,
+		`bridge_grpc_server_handled_total{grpc_code="Unauthenticated",grpc_method="Login",`+
+			`grpc_service="api.v1.AuthService",grpc_type="unary"} 1`,
+	)
+	asserts.Contains(out, `bridge_grpc_server_handling_seconds_bucket{grpc_code="Unauthenticated"`)
+}
+
+func TestInstrumentHandler(t *testing.T) {
+	asserts := assert.New(t)
+
+	h := metrics.InstrumentHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/auth/login", nil))
+
+	out := scrape(t)
+	asserts.Contains(out, `bridge_gateway_requests_total{code="404",method="post"} 1`)
+	asserts.Contains(out, `bridge_gateway_request_duration_seconds_count{code="404",method="post"} 1`)
+}
+
+func TestDomainCounters(t *testing.T) {
+	asserts := assert.New(t)
+
+	metrics.ObserveLogin(metrics.LoginMethodPassword, metrics.LoginFailed, "invalid_credentials")
+	metrics.ObserveLogin(metrics.LoginMethodCode, metrics.LoginSucceeded, "")
+	metrics.ObservePanicRecovered(metrics.GrpcTypeUnary, "/api.v1.UserService/Update")
+	metrics.ObserveRegistration()
+	metrics.ObserveTokenVerificationFailure("expired_token")
+
+	out := scrape(t)
+	asserts.Contains(out, `bridge_auth_logins_total{method="password",reason="invalid_credentials",result="failed"} 1`)
+	asserts.Contains(out, `bridge_auth_logins_total{method="code",reason="",result="succeeded"} 1`)
+	asserts.Contains(
+		out,
+		`bridge_grpc_server_panics_recovered_total{grpc_method="Update",grpc_service="api.v1.UserService",`+
+			`grpc_type="unary"} 1`,
+	)
+	asserts.Contains(out, `bridge_auth_registrations_total 1`)
+	asserts.Contains(out, `bridge_auth_token_verification_failures_total{reason="expired_token"} 1`)
+}
+
+func TestRegisterDBStats(t *testing.T) {
+	asserts := assert.New(t)
+
+	// sql.Open doesn't connect to the database.
+	db, err := sql.Open("pgx", "postgres://localhost/bridge")
+	asserts.NoError(err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	asserts.NoError(metrics.RegisterDBStats(db, "test"))
+	asserts.Error(metrics.RegisterDBStats(db, "test"))
+
+	count, err := testutil.GatherAndCount(metrics.Registry(), "go_sql_max_open_connections")
+	asserts.NoError(err)
+	asserts.Equal(1, count)
+	asserts.True(strings.Contains(scrape(t), `go_sql_open_connections{db_name="test"} 0`))
+}
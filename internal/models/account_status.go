@@ -0,0 +1,38 @@
+package models
+
+import (
+	"bridge/api/v1/pb"
+	"time"
+)
+
+// accountStatusTransitions lists the statuses an account can move to from its current status.
+var accountStatusTransitions = map[pb.User_AccountStatus][]pb.User_AccountStatus{
+	pb.User_UNKNOWN:        {pb.User_PENDING_ACTIVE, pb.User_ACTIVE, pb.User_SUSPENDED, pb.User_INACTIVE},
+	pb.User_PENDING_ACTIVE: {pb.User_ACTIVE, pb.User_SUSPENDED, pb.User_INACTIVE},
+	pb.User_ACTIVE:         {pb.User_SUSPENDED, pb.User_INACTIVE},
+	pb.User_SUSPENDED:      {pb.User_ACTIVE, pb.User_INACTIVE},
+	pb.User_INACTIVE:       {pb.User_ACTIVE},
+}
+
+// CanTransitionAccountStatus checks whether an account can move from one status to the other.
+func CanTransitionAccountStatus(from, to pb.User_AccountStatus) bool {
+	for _, status := range accountStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountStatusBlocksAuth checks whether an account with the given status is not allowed to authenticate.
+func AccountStatusBlocksAuth(status pb.User_AccountStatus) bool {
+	return status == pb.User_SUSPENDED || status == pb.User_INACTIVE
+}
+
+// SuspensionExpired checks whether the user is suspended and the suspension has an expiry which has passed.
+func SuspensionExpired(u *pb.User) bool {
+	if u.AccountStatus != pb.User_SUSPENDED || u.StatusExpiresAt == nil {
+		return false
+	}
+	return time.Now().After(u.StatusExpiresAt.AsTime())
+}
@@ -0,0 +1,55 @@
+package models_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestCanTransitionAccountStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		from pb.User_AccountStatus
+		to   pb.User_AccountStatus
+		want bool
+	}{
+		{name: "pending account can be activated", from: pb.User_PENDING_ACTIVE, to: pb.User_ACTIVE, want: true},
+		{name: "active account can be suspended", from: pb.User_ACTIVE, to: pb.User_SUSPENDED, want: true},
+		{name: "active account can be deactivated", from: pb.User_ACTIVE, to: pb.User_INACTIVE, want: true},
+		{name: "suspended account can be reactivated", from: pb.User_SUSPENDED, to: pb.User_ACTIVE, want: true},
+		{name: "inactive account can be reactivated", from: pb.User_INACTIVE, to: pb.User_ACTIVE, want: true},
+		{name: "inactive account cannot be suspended", from: pb.User_INACTIVE, to: pb.User_SUSPENDED},
+		{name: "active account cannot be moved to pending", from: pb.User_ACTIVE, to: pb.User_PENDING_ACTIVE},
+		{name: "suspended account cannot be suspended again", from: pb.User_SUSPENDED, to: pb.User_SUSPENDED},
+		{name: "account cannot be moved to unknown", from: pb.User_ACTIVE, to: pb.User_UNKNOWN},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, models.CanTransitionAccountStatus(tt.from, tt.to))
+		})
+	}
+}
+
+func TestSuspensionExpired(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		u       = &pb.User{AccountStatus: pb.User_SUSPENDED}
+	)
+
+	asserts.False(models.SuspensionExpired(u))
+
+	u.StatusExpiresAt = timestamppb.New(time.Now().Add(time.Hour))
+	asserts.False(models.SuspensionExpired(u))
+
+	u.StatusExpiresAt = timestamppb.New(time.Now().Add(-time.Hour))
+	asserts.True(models.SuspensionExpired(u))
+
+	u.AccountStatus = pb.User_ACTIVE
+	asserts.False(models.SuspensionExpired(u))
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"bridge/api/v1/pb"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuditFilter selects the audit events to list. Empty fields match every event.
+type AuditFilter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+	// BeforeSequence lists the events older than the given sequence when it's set.
+	BeforeSequence int64
+	Limit          int
+}
+
+// auditHashInput is the content of an audit event covered by its hash. The fields are encoded in a fixed order so
+// that the hash can be recomputed when the trail is verified.
+type auditHashInput struct {
+	PrevHash   string            `json:"prev_hash"`
+	ActorID    string            `json:"actor_id"`
+	ActorRole  string            `json:"actor_role"`
+	Action     string            `json:"action"`
+	TargetType string            `json:"target_type"`
+	TargetID   string            `json:"target_id"`
+	Changes    []auditHashChange `json:"changes"`
+	Method     string            `json:"method"`
+	Code       string            `json:"code"`
+	RequestID  string            `json:"request_id"`
+	IP         string            `json:"ip"`
+	CreatedAt  string            `json:"created_at"`
+}
+
+type auditHashChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// AuditEventHash returns the hex encoded SHA-256 hash of e chained to e.PrevHash. The creation time must already be
+// truncated to the precision stored by the database, see AuditTime.
+func AuditEventHash(e *pb.AuditEvent) string {
+	input := auditHashInput{
+		PrevHash:   e.PrevHash,
+		ActorID:    e.ActorId,
+		ActorRole:  e.ActorRole,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetId,
+		Changes:    make([]auditHashChange, 0, len(e.Changes)),
+		Method:     e.Method,
+		Code:       e.Code,
+		RequestID:  e.RequestId,
+		IP:         e.Ip,
+		CreatedAt:  e.CreatedAt.AsTime().UTC().Format(time.RFC3339Nano),
+	}
+
+	for _, c := range e.Changes {
+		input.Changes = append(input.Changes, auditHashChange{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	// Marshalling plain structs of strings can't fail.
+	data, _ := json.Marshal(input)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditTime truncates t to the microsecond precision of Postgres timestamps, so that the hash of an event matches
+// once it's read back.
+func AuditTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"bridge/api/v1/pb"
+	"encoding/json"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// EventType identifies a domain event published to the rest of the platform.
+type EventType string
+
+const (
+	EventUserRegistered       EventType = "user.registered"
+	EventUserUpdated          EventType = "user.updated"
+	EventAccountStatusChanged EventType = "user.account_status_changed"
+	EventCategoryCreated      EventType = "category.created"
+)
+
+// EventTypes lists the event types published by the API, for example to validate webhook subscriptions. The
+// category.created event is left out until a service creates categories.
+var EventTypes = []EventType{
+	EventUserRegistered,
+	EventUserUpdated,
+	EventAccountStatusChanged,
+}
+
+// IsEventType checks whether t is one of EventTypes.
+func IsEventType(t string) bool {
+	for _, eventType := range EventTypes {
+		if string(eventType) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Aggregates the events are emitted for. Events of the same aggregate are published in the order they were written.
+const (
+	AggregateUser     = "user"
+	AggregateCategory = "category"
+)
+
+// OutboxEvent is a domain event written to the outbox in the same transaction as the change it describes, and
+// published by the relay afterwards.
+type OutboxEvent struct {
+	ID            string
+	Sequence      int64
+	AggregateType string
+	AggregateID   string
+	Type          EventType
+	Payload       json.RawMessage
+	Attempts      int
+	LastError     *string
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
+
+// UserPayload is the payload of the user.registered and user.updated events. The credentials and KYC data are never
+// included.
+type UserPayload struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	PhoneNumber   string    `json:"phone_number"`
+	Role          string    `json:"role"`
+	AccountStatus string    `json:"account_status"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// AccountStatusChangedPayload is the payload of the user.account_status_changed event.
+type AccountStatusChangedPayload struct {
+	UserID    string     `json:"user_id"`
+	From      string     `json:"from"`
+	To        string     `json:"to"`
+	Reason    string     `json:"reason"`
+	ActorID   string     `json:"actor_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+// CategoryPayload is the payload of the category.created event.
+type CategoryPayload struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newOutboxEvent(aggregateType, aggregateID string, eventType EventType, payload any) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxEvent{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       data,
+	}, nil
+}
+
+// NewUserEvent creates a user.registered or user.updated event for u.
+func NewUserEvent(eventType EventType, u *pb.User) (*OutboxEvent, error) {
+	return newOutboxEvent(AggregateUser, u.ID, eventType, UserPayload{
+		ID:            u.ID,
+		Name:          u.Name,
+		Email:         u.Email,
+		PhoneNumber:   u.PhoneNumber,
+		Role:          u.Role.String(),
+		AccountStatus: u.AccountStatus.String(),
+		CreatedAt:     timestampOrNow(u.CreatedAt),
+		UpdatedAt:     timestampOrNow(u.UpdatedAt),
+	})
+}
+
+// NewAccountStatusChangedEvent creates a user.account_status_changed event for change.
+func NewAccountStatusChangedEvent(change *pb.AccountStatusChange) (*OutboxEvent, error) {
+	payload := AccountStatusChangedPayload{
+		UserID:    change.UserId,
+		From:      change.From.String(),
+		To:        change.To.String(),
+		Reason:    change.Reason,
+		ActorID:   change.ActorId,
+		ChangedAt: timestampOrNow(change.CreatedAt),
+	}
+
+	if change.ExpiresAt != nil {
+		expiresAt := change.ExpiresAt.AsTime()
+		payload.ExpiresAt = &expiresAt
+	}
+
+	return newOutboxEvent(AggregateUser, change.UserId, EventAccountStatusChanged, payload)
+}
+
+// NewCategoryCreatedEvent creates a category.created event for c.
+func NewCategoryCreatedEvent(c *pb.Category) (*OutboxEvent, error) {
+	return newOutboxEvent(AggregateCategory, c.ID, EventCategoryCreated, CategoryPayload{
+		ID:        c.ID,
+		Name:      c.Name,
+		Slug:      c.Slug,
+		Status:    c.Status.String(),
+		CreatedAt: timestampOrNow(c.CreatedAt),
+	})
+}
+
+// timestampOrNow is used for the timestamps of records which are set by the database when they're missing.
+func timestampOrNow(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Now()
+	}
+	return ts.AsTime()
+}
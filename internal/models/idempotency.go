@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// IdempotencyRecord is the outcome of a call made with an idempotency key. The response is only set once the call
+// completed successfully.
+type IdempotencyRecord struct {
+	// Key identifies the call, scoped to its method and the user making it.
+	Key         string
+	Method      string
+	Fingerprint string
+	// ResponseType is the full name of the response message.
+	ResponseType string
+	// Response is the serialized response message.
+	Response    []byte
+	CompletedAt *time.Time
+	// LockedUntil is when the claim of a call in progress lapses, for example because the process handling it
+	// crashed, so that a retry can take over the key.
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
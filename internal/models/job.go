@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JobStatus is the state of a background job.
+type JobStatus string
+
+const (
+	// JobStatusPending jobs are waiting to run, either for the first time or after a failed attempt.
+	JobStatusPending JobStatus = "pending"
+	// JobStatusRunning jobs have been claimed by a worker.
+	JobStatusRunning JobStatus = "running"
+	// JobStatusSucceeded jobs have completed successfully.
+	JobStatusSucceeded JobStatus = "succeeded"
+	// JobStatusDead jobs have failed permanently or run out of attempts and won't be retried.
+	JobStatusDead JobStatus = "dead"
+)
+
+// Job is a unit of background work stored in the jobs queue table. The payload is decoded by the handler registered
+// for the job type.
+type Job struct {
+	ID          string
+	Queue       string
+	Type        string
+	Payload     json.RawMessage
+	Status      JobStatus
+	Attempts    int
+	MaxAttempts int
+	LastError   *string
+	RunAt       time.Time
+	LockedAt    *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
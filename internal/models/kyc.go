@@ -0,0 +1,46 @@
+package models
+
+import (
+	"bridge/api/v1/pb"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// KYCDocuments stores the documents attached to a KYC submission as JSON.
+type KYCDocuments []*pb.KYCDocument
+
+// Value implements driver.Valuer which simply returns the JSON-encoded representation of KYCDocuments.
+func (d KYCDocuments) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+// Scan implement the sql.Scanner which decodes a JSON-encoded value into KYCDocuments.
+func (d *KYCDocuments) Scan(value any) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed - got %T", value)
+	}
+	return json.Unmarshal(b, d)
+}
+
+// kycTransitions lists the statuses a KYC submission can move to from its current status.
+var kycTransitions = map[pb.KYCSubmission_Status][]pb.KYCSubmission_Status{
+	pb.KYCSubmission_SUBMITTED:    {pb.KYCSubmission_UNDER_REVIEW, pb.KYCSubmission_APPROVED, pb.KYCSubmission_REJECTED},
+	pb.KYCSubmission_UNDER_REVIEW: {pb.KYCSubmission_APPROVED, pb.KYCSubmission_REJECTED},
+}
+
+// CanTransitionKYCStatus checks whether a KYC submission can move from one status to the other.
+func CanTransitionKYCStatus(from, to pb.KYCSubmission_Status) bool {
+	for _, status := range kycTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// KYCPending checks whether the submission is still waiting for a decision.
+func KYCPending(status pb.KYCSubmission_Status) bool {
+	return status == pb.KYCSubmission_SUBMITTED || status == pb.KYCSubmission_UNDER_REVIEW
+}
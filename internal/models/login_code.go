@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// LoginChannel is the channel a passwordless login code is delivered through.
+type LoginChannel string
+
+const (
+	LoginChannelEmail LoginChannel = "email"
+	LoginChannelSMS   LoginChannel = "sms"
+)
+
+// LoginCode is a hashed one-time code used to log in without a password. Codes sent by email are used as
+// magic links while codes sent by SMS are short numeric OTPs.
+type LoginCode struct {
+	ID         string
+	UserID     string
+	Channel    LoginChannel
+	CodeHash   string
+	Attempts   int
+	ExpiresAt  time.Time
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// Expired checks whether the code can no longer be used.
+func (c *LoginCode) Expired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
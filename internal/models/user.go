@@ -11,6 +11,14 @@ type User struct {
 	*pb.User
 }
 
+// UserSearch selects the users to search.
+type UserSearch struct {
+	// Query is matched against the name, email and phone number, as words, as a substring or by similarity.
+	Query  string
+	Limit  int
+	Offset int
+}
+
 type UserMeta struct {
 	*pb.UserMeta
 }
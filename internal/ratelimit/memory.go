@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// bucket holds the tokens of a key, which are refilled continuously up to the limit of the policy.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	window  time.Duration
+}
+
+type memoryBackend struct {
+	buckets         map[string]*bucket
+	cleanupInterval time.Duration
+	mu              sync.Mutex
+}
+
+func (b *memoryBackend) Allow(_ context.Context, key string, policy Policy) (Result, error) {
+	var (
+		now   = time.Now()
+		limit = float64(policy.Limit)
+		// rate is the number of tokens added per second.
+		rate = limit / policy.Window.Seconds()
+	)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk, ok := b.buckets[key]
+	if !ok {
+		bk = &bucket{tokens: limit, updated: now}
+		b.buckets[key] = bk
+	}
+
+	bk.tokens = math.Min(limit, bk.tokens+now.Sub(bk.updated).Seconds()*rate)
+	bk.updated = now
+	bk.window = policy.Window
+
+	if bk.tokens < 1 {
+		wait := (1 - bk.tokens) / rate
+		return Result{RetryAfter: time.Duration(wait * float64(time.Second))}, nil
+	}
+
+	bk.tokens--
+	return Result{Allowed: true, Remaining: int(bk.tokens)}, nil
+}
+
+func (b *memoryBackend) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			b.cleanup(now)
+		}
+	}
+}
+
+// cleanup removes the buckets which have been refilled, since they're the same as new ones.
+func (b *memoryBackend) cleanup(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key, bk := range b.buckets {
+		if now.Sub(bk.updated) >= bk.window {
+			delete(b.buckets, key)
+		}
+	}
+}
+
+// NewMemoryBackend creates a Backend counting the calls in memory using token buckets, which are refilled over the
+// window of the policy. The idle buckets are removed every cleanupInterval while Run is running.
+func NewMemoryBackend(cleanupInterval time.Duration) Backend {
+	if cleanupInterval <= 0 {
+		cleanupInterval = DefaultCleanupInterval
+	}
+
+	return &memoryBackend{
+		buckets:         map[string]*bucket{},
+		cleanupInterval: cleanupInterval,
+	}
+}
@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"bridge/internal/repository"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type postgresBackend struct {
+	cleanupInterval time.Duration
+	l               zerolog.Logger
+	repo            repository.RateLimit
+}
+
+func (b *postgresBackend) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	var (
+		now           = time.Now().UTC()
+		windowStart   = now.Truncate(policy.Window)
+		previousStart = windowStart.Add(-policy.Window)
+		// The window is still needed to weigh the calls of the next one.
+		expiresAt = windowStart.Add(2 * policy.Window)
+	)
+
+	current, previous, err := b.repo.Hit(ctx, key, windowStart, previousStart, expiresAt)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var (
+		elapsed = now.Sub(windowStart)
+		limit   = float64(policy.Limit)
+		// The calls of the previous window are weighed by how much of it is still within the sliding window.
+		count = float64(previous)*(1-float64(elapsed)/float64(policy.Window)) + float64(current)
+	)
+
+	if count <= limit {
+		return Result{Allowed: true, Remaining: int(limit - count)}, nil
+	}
+
+	return Result{RetryAfter: slidingRetryAfter(policy, elapsed, current, previous)}, nil
+}
+
+// slidingRetryAfter returns how long a client which made current calls in the window started elapsed ago, and
+// previous calls in the window before it, has to wait until another call is within the limit of policy.
+func slidingRetryAfter(policy Policy, elapsed time.Duration, current, previous int64) time.Duration {
+	var (
+		window = float64(policy.Window)
+		// room is the number of calls the weighed calls of the previous window must drop to.
+		room = float64(policy.Limit - 1)
+	)
+
+	if float64(current) <= room {
+		// The calls of the previous window slide out before the current one ends.
+		wait := window*(1-(room-float64(current))/float64(previous)) - float64(elapsed)
+		return time.Duration(wait)
+	}
+
+	// The calls of the current window have to slide out of the next one.
+	wait := window - float64(elapsed) + window*(1-room/float64(current))
+	return time.Duration(wait)
+}
+
+func (b *postgresBackend) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := b.repo.DeleteExpired(ctx, now); err != nil {
+				b.l.Err(err).Msg("failed to delete expired windows")
+			}
+		}
+	}
+}
+
+// NewPostgresBackend creates a Backend counting the calls in repo using sliding windows, so that the limits are
+// shared by the replicas. Rejected calls are counted too, so clients which keep calling stay limited. The expired
+// windows are deleted every cleanupInterval while Run is running.
+func NewPostgresBackend(l zerolog.Logger, repo repository.RateLimit, cleanupInterval time.Duration) Backend {
+	if cleanupInterval <= 0 {
+		cleanupInterval = DefaultCleanupInterval
+	}
+
+	return &postgresBackend{
+		cleanupInterval: cleanupInterval,
+		l:               l.With().Str("component", "rate limiter").Logger(),
+		repo:            repo,
+	}
+}
@@ -0,0 +1,203 @@
+package ratelimit
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/metrics"
+	"bridge/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Identities the calls are counted by.
+const (
+	// KeyIP counts the calls by the IP address of the client.
+	KeyIP = "ip"
+	// KeyUser counts the calls by the authenticated user, or by IP address for unauthenticated calls.
+	KeyUser = "user"
+)
+
+// Supported backends.
+const (
+	// BackendMemory counts the calls in memory using token buckets. The limits apply to each replica separately.
+	BackendMemory = "memory"
+	// BackendPostgres counts the calls in Postgres using sliding windows, so that the limits are shared by the
+	// replicas.
+	BackendPostgres = "postgres"
+)
+
+// DefaultCleanupInterval is how often the backends remove the counters of the clients which went quiet.
+const DefaultCleanupInterval = time.Minute
+
+// ErrUnknownBackend is returned when the configured backend isn't supported.
+var ErrUnknownBackend = errors.New("ratelimit: unknown backend")
+
+// DefaultPolicies limit the methods which can be used to guess credentials or codes, create accounts or send
+// messages.
+var DefaultPolicies = map[string]Policy{
+	"/api.v1.AuthService/Login":                   {Key: KeyIP, Limit: 10, Window: time.Minute},
+	"/api.v1.AuthService/LoginWithCode":           {Key: KeyIP, Limit: 10, Window: time.Minute},
+	"/api.v1.AuthService/Register":                {Key: KeyIP, Limit: 5, Window: time.Hour},
+	"/api.v1.AuthService/RequestLoginCode":        {Key: KeyIP, Limit: 5, Window: 15 * time.Minute},
+	"/api.v1.AuthService/RequestVerificationCode": {Key: KeyUser, Limit: 5, Window: 15 * time.Minute},
+	"/api.v1.AuthService/VerifyContact":           {Key: KeyUser, Limit: 10, Window: time.Minute},
+}
+
+// Policy allows Limit calls to a method per Window for each identity.
+type Policy struct {
+	// Key is the identity the calls are counted by, either KeyIP or KeyUser.
+	Key    string
+	Limit  int
+	Window time.Duration
+}
+
+// Result is the outcome of counting a call.
+type Result struct {
+	Allowed bool
+	// Remaining is the number of calls left before the limit is reached.
+	Remaining int
+	// RetryAfter is how long the client should wait before calling again when the call isn't allowed.
+	RetryAfter time.Duration
+}
+
+// Backend counts the calls made with a key.
+type Backend interface {
+	// Allow counts a call made with key and reports whether it's within the limit of policy.
+	Allow(ctx context.Context, key string, policy Policy) (Result, error)
+	// Run removes the counters of the keys which weren't used for a window until ctx is done.
+	Run(ctx context.Context)
+}
+
+// Limiter applies the policies configured for the methods.
+type Limiter interface {
+	// Limit counts a call to fullMethod made by the client in ctx. The calls to methods without a policy are always
+	// allowed, and so are the calls which can't be counted because the backend failed, so that an outage of the
+	// backend doesn't take down the API.
+	Limit(ctx context.Context, fullMethod string) Result
+}
+
+type limiter struct {
+	backend  Backend
+	l        zerolog.Logger
+	policies map[string]Policy
+}
+
+func (lm *limiter) Limit(ctx context.Context, fullMethod string) Result {
+	policy, ok := lm.policies[fullMethod]
+	if !ok {
+		return Result{Allowed: true}
+	}
+
+	res, err := lm.backend.Allow(ctx, fullMethod+"|"+identity(ctx, policy.Key), policy)
+	if err != nil {
+		l := logger.FromContext(ctx, lm.l).With().Str("action", "limit").Str("method", fullMethod).Logger()
+		l.Err(err).Msg("failed to count call, allowing it")
+		return Result{Allowed: true}
+	}
+
+	if !res.Allowed {
+		metrics.ObserveRateLimited(fullMethod)
+	}
+
+	return res
+}
+
+// NewLimiter creates a Limiter counting the calls to the methods of policies, keyed by full method name, in backend.
+func NewLimiter(l zerolog.Logger, backend Backend, policies map[string]Policy) Limiter {
+	return &limiter{
+		backend:  backend,
+		l:        l.With().Str("component", "rate limiter").Logger(),
+		policies: policies,
+	}
+}
+
+// NewBackend creates the backend named name, BackendMemory is used if it's empty. repo is only used by
+// BackendPostgres.
+func NewBackend(name string, l zerolog.Logger, repo repository.RateLimit) (Backend, error) {
+	switch strings.ToLower(name) {
+	case "", BackendMemory:
+		return NewMemoryBackend(DefaultCleanupInterval), nil
+	case BackendPostgres:
+		return NewPostgresBackend(l, repo, DefaultCleanupInterval), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, name)
+	}
+}
+
+// identity returns the identity of the client in ctx used for key.
+func identity(ctx context.Context, key string) string {
+	switch key {
+	case KeyUser:
+		if r, ok := logger.RequestFromContext(ctx); ok && r.UserID() != "" {
+			return KeyUser + ":" + r.UserID()
+		}
+	}
+
+	return KeyIP + ":" + logger.ClientIP(ctx)
+}
+
+// ParsePolicies parses a comma separated list of method=key:limit/window policies, for example
+// "/api.v1.AuthService/Login=ip:10/1m,/api.v1.UserService/Update=user:30/1m".
+func ParsePolicies(s string) (map[string]Policy, error) {
+	policies := map[string]Policy{}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		method, rule, found := strings.Cut(part, "=")
+		if !found || !strings.HasPrefix(method, "/") {
+			return nil, fmt.Errorf("ratelimit: invalid policy %q", part)
+		}
+
+		key, rate, found := strings.Cut(rule, ":")
+		if !found {
+			return nil, fmt.Errorf("ratelimit: invalid policy %q", part)
+		}
+
+		switch key {
+		case KeyIP, KeyUser:
+		default:
+			return nil, fmt.Errorf("ratelimit: invalid key %q for method %q", key, method)
+		}
+
+		limit, window, found := strings.Cut(rate, "/")
+		if !found {
+			return nil, fmt.Errorf("ratelimit: invalid policy %q", part)
+		}
+
+		p := Policy{Key: key}
+
+		var err error
+		if p.Limit, err = strconv.Atoi(limit); err != nil || p.Limit < 1 {
+			return nil, fmt.Errorf("ratelimit: invalid limit for method %q", method)
+		}
+
+		if p.Window, err = time.ParseDuration(window); err != nil || p.Window <= 0 {
+			return nil, fmt.Errorf("ratelimit: invalid window for method %q", method)
+		}
+
+		policies[method] = p
+	}
+
+	return policies, nil
+}
+
+// MergePolicies returns the policies of base overridden by the ones of overrides.
+func MergePolicies(base, overrides map[string]Policy) map[string]Policy {
+	merged := make(map[string]Policy, len(base)+len(overrides))
+	for method, p := range base {
+		merged[method] = p
+	}
+	for method, p := range overrides {
+		merged[method] = p
+	}
+	return merged
+}
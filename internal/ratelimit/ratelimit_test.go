@@ -0,0 +1,221 @@
+package ratelimit_test
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/ratelimit"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testMethod = "/api.v1.AuthService/Login"
+
+// recordingBackend records the keys of the calls and allows them unless err is set.
+type recordingBackend struct {
+	mu   sync.Mutex
+	keys []string
+	err  error
+}
+
+func (b *recordingBackend) Allow(_ context.Context, key string, _ ratelimit.Policy) (ratelimit.Result, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.keys = append(b.keys, key)
+	return ratelimit.Result{Allowed: b.err == nil}, b.err
+}
+
+func (b *recordingBackend) Run(context.Context) {}
+
+// memoryRateLimitRepo is an in-memory repository.RateLimit.
+type memoryRateLimitRepo struct {
+	mu     sync.Mutex
+	counts map[string]map[time.Time]int64
+}
+
+func (r *memoryRateLimitRepo) Hit(
+	_ context.Context,
+	key string,
+	windowStart, previousStart, _ time.Time,
+) (int64, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.counts[key] == nil {
+		r.counts[key] = map[time.Time]int64{}
+	}
+
+	r.counts[key][windowStart]++
+	return r.counts[key][windowStart], r.counts[key][previousStart], nil
+}
+
+func (r *memoryRateLimitRepo) DeleteExpired(context.Context, time.Time) (int, error) {
+	return 0, nil
+}
+
+func requestCtx(peer string, md metadata.MD) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	return logger.ContextWithRequest(ctx, &logger.Request{ID: "request", Peer: peer})
+}
+
+func TestParsePolicies(t *testing.T) {
+	t.Parallel()
+
+	asserts := assert.New(t)
+
+	policies, err := ratelimit.ParsePolicies(
+		" /api.v1.AuthService/Login=ip:10/1m, /api.v1.UserService/Update=user:30/1h,,",
+	)
+	asserts.NoError(err)
+	asserts.Equal(map[string]ratelimit.Policy{
+		"/api.v1.AuthService/Login":  {Key: ratelimit.KeyIP, Limit: 10, Window: time.Minute},
+		"/api.v1.UserService/Update": {Key: ratelimit.KeyUser, Limit: 30, Window: time.Hour},
+	}, policies)
+
+	policies, err = ratelimit.ParsePolicies("")
+	asserts.NoError(err)
+	asserts.Empty(policies)
+
+	for _, s := range []string{
+		"Login=ip:10/1m",
+		"/api.v1.AuthService/Login",
+		"/api.v1.AuthService/Login=ip",
+		"/api.v1.AuthService/Login=session:10/1m",
+		"/api.v1.AuthService/Login=ip:0/1m",
+		"/api.v1.AuthService/Login=ip:10",
+		"/api.v1.AuthService/Login=ip:10/soon",
+	} {
+		_, err = ratelimit.ParsePolicies(s)
+		asserts.Error(err, s)
+	}
+
+	merged := ratelimit.MergePolicies(ratelimit.DefaultPolicies, map[string]ratelimit.Policy{
+		testMethod: {Key: ratelimit.KeyIP, Limit: 3, Window: time.Minute},
+	})
+	asserts.Equal(3, merged[testMethod].Limit)
+	asserts.Equal(ratelimit.DefaultPolicies["/api.v1.AuthService/Register"], merged["/api.v1.AuthService/Register"])
+	asserts.Equal(10, ratelimit.DefaultPolicies[testMethod].Limit)
+}
+
+func TestMemoryBackend(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		backend = ratelimit.NewMemoryBackend(0)
+		policy  = ratelimit.Policy{Key: ratelimit.KeyIP, Limit: 2, Window: 200 * time.Millisecond}
+	)
+
+	res, err := backend.Allow(ctx, "a", policy)
+	asserts.NoError(err)
+	asserts.True(res.Allowed)
+	asserts.Equal(1, res.Remaining)
+
+	res, err = backend.Allow(ctx, "a", policy)
+	asserts.NoError(err)
+	asserts.True(res.Allowed)
+	asserts.Zero(res.Remaining)
+
+	res, err = backend.Allow(ctx, "a", policy)
+	asserts.NoError(err)
+	asserts.False(res.Allowed)
+	asserts.Greater(res.RetryAfter, time.Duration(0))
+	asserts.LessOrEqual(res.RetryAfter, policy.Window/2)
+
+	// The keys have their own buckets.
+	res, err = backend.Allow(ctx, "b", policy)
+	asserts.NoError(err)
+	asserts.True(res.Allowed)
+
+	// A token is added every half window.
+	time.Sleep(policy.Window / 2)
+
+	res, err = backend.Allow(ctx, "a", policy)
+	asserts.NoError(err)
+	asserts.True(res.Allowed)
+}
+
+func TestPostgresBackend(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		repo    = &memoryRateLimitRepo{counts: map[string]map[time.Time]int64{}}
+		backend = ratelimit.NewPostgresBackend(logger.TestLogger, repo, 0)
+		policy  = ratelimit.Policy{Key: ratelimit.KeyIP, Limit: 3, Window: time.Hour}
+	)
+
+	for i := 0; i < policy.Limit; i++ {
+		res, err := backend.Allow(ctx, "a", policy)
+		asserts.NoError(err)
+		asserts.True(res.Allowed)
+	}
+
+	res, err := backend.Allow(ctx, "a", policy)
+	asserts.NoError(err)
+	asserts.False(res.Allowed)
+	// The calls of the current window slide out of the next one.
+	asserts.Greater(res.RetryAfter, time.Duration(0))
+	asserts.LessOrEqual(res.RetryAfter, 2*policy.Window)
+
+	res, err = backend.Allow(ctx, "b", policy)
+	asserts.NoError(err)
+	asserts.True(res.Allowed)
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		backend = &recordingBackend{}
+		limiter = ratelimit.NewLimiter(logger.TestLogger, backend, map[string]ratelimit.Policy{
+			testMethod:                   {Key: ratelimit.KeyIP, Limit: 1, Window: time.Minute},
+			"/api.v1.UserService/Update": {Key: ratelimit.KeyUser, Limit: 1, Window: time.Minute},
+		})
+	)
+
+	// Methods without a policy aren't counted.
+	asserts.True(limiter.Limit(requestCtx("198.51.100.20:443", nil), "/api.v1.KYCService/SubmitKYC").Allowed)
+	asserts.Empty(backend.keys)
+
+	// The address forwarded by the gateway is used for calls from the loopback interface.
+	ctx := requestCtx("127.0.0.1:50000", metadata.Pairs("x-forwarded-for", "203.0.113.7"))
+	asserts.True(limiter.Limit(ctx, testMethod).Allowed)
+	asserts.Equal(testMethod+"|ip:203.0.113.7", backend.keys[0])
+
+	// Calls by users fall back to the address when there is none.
+	ctx = requestCtx("198.51.100.20:443", nil)
+	limiter.Limit(ctx, "/api.v1.UserService/Update")
+	asserts.Equal("/api.v1.UserService/Update|ip:198.51.100.20", backend.keys[1])
+
+	r, _ := logger.RequestFromContext(ctx)
+	r.SetUserID("user-1")
+	limiter.Limit(ctx, "/api.v1.UserService/Update")
+	asserts.Equal("/api.v1.UserService/Update|user:user-1", backend.keys[2])
+
+	// Calls which can't be counted are allowed.
+	backend.err = errors.New("connection refused")
+	asserts.True(limiter.Limit(ctx, testMethod).Allowed)
+}
+
+func TestNewBackend(t *testing.T) {
+	t.Parallel()
+
+	asserts := assert.New(t)
+
+	for _, name := range []string{"", ratelimit.BackendMemory, ratelimit.BackendPostgres} {
+		backend, err := ratelimit.NewBackend(name, logger.TestLogger, nil)
+		asserts.NoError(err)
+		asserts.NotNil(backend)
+	}
+
+	_, err := ratelimit.NewBackend("redis", logger.TestLogger, nil)
+	asserts.ErrorIs(err, ratelimit.ErrUnknownBackend)
+}
@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/tracing"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+type Audit interface {
+	// Append adds the events to the end of the audit trail, chaining each one to the hash of the previous event.
+	Append(ctx context.Context, events ...*pb.AuditEvent) error
+	// List returns the events matching filter, from the newest.
+	List(ctx context.Context, filter models.AuditFilter) ([]*pb.AuditEvent, error)
+	// Verify recomputes the hash chain of the whole trail and returns the sequence of the first event which was
+	// altered, removed or inserted out of band, or zero if the trail is intact.
+	Verify(ctx context.Context) (int64, error)
+}
+
+type auditRepo struct {
+	db *sqlx.DB
+	l  zerolog.Logger
+}
+
+// auditLockKey is the key of the advisory lock serializing the appends, so that every event is chained to the last
+// one committed. The lock is held until the append commits, so the appends run one at a time, each taking a few round
+// trips to the primary. audit.Auditor appends the events of the calls completing concurrently together so that bursts
+// of calls, such as logins, don't queue up behind the lock, see audit.BenchmarkAuditor.
+const auditLockKey = 7_420_117
+
+const (
+	_auditLock     = `SELECT pg_advisory_xact_lock($1)`
+	_auditLastHash = `SELECT hash FROM audit_events ORDER BY sequence DESC LIMIT 1`
+
+	_auditCreate = `
+	INSERT INTO audit_events (actor_id, actor_role, action, target_type, target_id, changes, method, code, request_id,
+		ip, prev_hash, hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7, $8, $9, $10, $11, $12, $13)
+	RETURNING id, sequence`
+
+	_auditBaseSelect = `
+	SELECT id, sequence, actor_id, actor_role, action, target_type, target_id, changes, method, code, request_id, ip,
+		prev_hash, hash, created_at
+	FROM audit_events `
+
+	_auditList = _auditBaseSelect + `
+	WHERE ($1 = '' OR actor_id = $1)
+	  AND ($2 = '' OR action = $2)
+	  AND ($3 = '' OR target_type = $3)
+	  AND ($4 = '' OR target_id = $4)
+	  AND ($5::timestamptz IS NULL OR created_at >= $5)
+	  AND ($6::timestamptz IS NULL OR created_at < $6)
+	  AND ($7 = 0 OR sequence < $7)
+	ORDER BY sequence DESC
+	LIMIT $8`
+
+	_auditListAll = _auditBaseSelect + `ORDER BY sequence`
+)
+
+// auditChange is how the changes of an event are stored.
+type auditChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func scanAuditEvent(row interface{ Scan(dest ...any) error }) (*pb.AuditEvent, error) {
+	var (
+		event     = &pb.AuditEvent{}
+		changes   []byte
+		createdAt time.Time
+	)
+
+	err := row.Scan(
+		&event.ID,
+		&event.Sequence,
+		&event.ActorId,
+		&event.ActorRole,
+		&event.Action,
+		&event.TargetType,
+		&event.TargetId,
+		&changes,
+		&event.Method,
+		&event.Code,
+		&event.RequestId,
+		&event.Ip,
+		&event.PrevHash,
+		&event.Hash,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []auditChange
+	if err = json.Unmarshal(changes, &stored); err != nil {
+		return nil, err
+	}
+
+	for _, c := range stored {
+		event.Changes = append(event.Changes, &pb.AuditChange{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	event.CreatedAt = timestamppb.New(createdAt)
+	return event, nil
+}
+
+// appendAuditEvents adds the events to the end of the audit trail in tx, chaining each one to the hash of the previous
+// event. The chain lock is held until tx ends, so it's meant to be called right before committing.
+func appendAuditEvents(ctx context.Context, tx *sqlx.Tx, events ...*pb.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, _auditLock, auditLockKey); err != nil {
+		return err
+	}
+
+	var prevHash string
+	err := tx.QueryRowxContext(ctx, _auditLastHash).Scan(&prevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	now := models.AuditTime(time.Now())
+
+	for _, event := range events {
+		changes := make([]auditChange, 0, len(event.Changes))
+		for _, c := range event.Changes {
+			changes = append(changes, auditChange{Field: c.Field, Before: c.Before, After: c.After})
+		}
+
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return err
+		}
+
+		event.CreatedAt = timestamppb.New(now)
+		event.PrevHash = prevHash
+		event.Hash = models.AuditEventHash(event)
+
+		err = tx.QueryRowxContext(
+			ctx,
+			_auditCreate,
+			event.ActorId,
+			event.ActorRole,
+			event.Action,
+			event.TargetType,
+			event.TargetId,
+			string(data),
+			event.Method,
+			event.Code,
+			event.RequestId,
+			event.Ip,
+			event.PrevHash,
+			event.Hash,
+			now,
+		).Scan(&event.ID, &event.Sequence)
+		if err != nil {
+			return err
+		}
+
+		prevHash = event.Hash
+	}
+
+	return nil
+}
+
+// setAuditTargetID sets the target of the events recorded without one to id, since the services record the creations
+// before the target is stored.
+func setAuditTargetID(id string, events ...*pb.AuditEvent) {
+	for _, event := range events {
+		if event.TargetId == "" {
+			event.TargetId = id
+		}
+	}
+}
+
+func (r *auditRepo) Append(ctx context.Context, events ...*pb.AuditEvent) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "audit_events", "Append", _auditLock, _auditLastHash, _auditCreate)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "append").Int("events", len(events)).Logger()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func (r *auditRepo) List(ctx context.Context, filter models.AuditFilter) (_ []*pb.AuditEvent, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "audit_events", "List", _auditList)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "list").
+		Interface("filter", filter).
+		Str("query", _auditList).
+		Logger()
+
+	rows, err := r.db.QueryxContext(
+		ctx,
+		_auditList,
+		filter.ActorID,
+		filter.Action,
+		filter.TargetType,
+		filter.TargetID,
+		filter.From,
+		filter.To,
+		filter.BeforeSequence,
+		filter.Limit,
+	)
+	if err != nil {
+		l.Err(err).Msg("query rows")
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var events []*pb.AuditEvent
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			l.Err(err).Msg("scan row")
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Err(err).Msg("iterate rows")
+		return nil, err
+	}
+
+	l.Info().Int("count", len(events)).Msg("completed successfully")
+	return events, nil
+}
+
+func (r *auditRepo) Verify(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "audit_events", "Verify", _auditListAll)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "verify").Str("query", _auditListAll).Logger()
+
+	rows, err := r.db.QueryxContext(ctx, _auditListAll)
+	if err != nil {
+		l.Err(err).Msg("query rows")
+		return 0, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var (
+		prevHash string
+		count    int
+	)
+
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			l.Err(err).Msg("scan row")
+			return 0, err
+		}
+
+		if event.PrevHash != prevHash || models.AuditEventHash(event) != event.Hash {
+			l.Error().Int64("sequence", event.Sequence).Msg("audit trail was tampered with")
+			return event.Sequence, nil
+		}
+
+		prevHash = event.Hash
+		count++
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Err(err).Msg("iterate rows")
+		return 0, err
+	}
+
+	l.Info().Int("count", count).Msg("completed successfully")
+	return 0, nil
+}
+
+func NewTestAuditRepo(db *sqlx.DB) Audit {
+	return NewAuditRepo(db, logger.TestLogger)
+}
+
+func NewAuditRepo(db *sqlx.DB, l zerolog.Logger) Audit {
+	return &auditRepo{
+		db: db,
+		l:  l.With().Str("repo", "audit_sqlx").Logger(),
+	}
+}
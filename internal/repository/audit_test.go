@@ -0,0 +1,108 @@
+package repository_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAuditRepo(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts  = assert.New(t)
+		ctx      = context.Background()
+		repo     = repository.NewTestAuditRepo(testDB)
+		actorID  = uuid.NewString()
+		targetID = uuid.NewString()
+		first    = &pb.AuditEvent{
+			ActorId:    actorID,
+			ActorRole:  pb.User_ADMIN.String(),
+			Action:     "user.account_status_change",
+			TargetType: "user",
+			TargetId:   targetID,
+			Changes:    []*pb.AuditChange{{Field: "account_status", Before: "ACTIVE", After: "SUSPENDED"}},
+			Method:     "/api.v1.UserService/SuspendUser",
+			Code:       "OK",
+			RequestId:  uuid.NewString(),
+			Ip:         "203.0.113.7",
+		}
+		second = &pb.AuditEvent{
+			ActorId: actorID,
+			Action:  "/api.v1.AuditService/ListAuditEvents",
+			Method:  "/api.v1.AuditService/ListAuditEvents",
+			Code:    "OK",
+		}
+	)
+
+	asserts.NoError(repo.Append(ctx, first, second))
+	asserts.NotEmpty(first.ID)
+	asserts.Greater(second.Sequence, first.Sequence)
+	asserts.Equal(first.Hash, second.PrevHash)
+	asserts.Equal(models.AuditEventHash(first), first.Hash)
+
+	events, err := repo.List(ctx, models.AuditFilter{ActorID: actorID, Limit: 10})
+	asserts.NoError(err)
+	asserts.Len(events, 2)
+	asserts.Equal(second.ID, events[0].ID)
+	asserts.Equal(first.ID, events[1].ID)
+	asserts.Equal(first.Hash, events[1].Hash)
+	asserts.Len(events[1].Changes, 1)
+	asserts.Equal("SUSPENDED", events[1].Changes[0].After)
+
+	events, err = repo.List(ctx, models.AuditFilter{TargetType: "user", TargetID: targetID, Limit: 10})
+	asserts.NoError(err)
+	asserts.Len(events, 1)
+	asserts.Equal(first.ID, events[0].ID)
+
+	events, err = repo.List(ctx, models.AuditFilter{ActorID: actorID, BeforeSequence: second.Sequence, Limit: 10})
+	asserts.NoError(err)
+	asserts.Len(events, 1)
+	asserts.Equal(first.ID, events[0].ID)
+
+	from := time.Now().Add(time.Hour)
+	events, err = repo.List(ctx, models.AuditFilter{ActorID: actorID, From: &from, Limit: 10})
+	asserts.NoError(err)
+	asserts.Empty(events)
+
+	sequence, err := repo.Verify(ctx)
+	asserts.NoError(err)
+	asserts.Zero(sequence)
+
+	// The trail is append-only.
+	_, err = testDB.ExecContext(ctx, `UPDATE audit_events SET action = 'user.update' WHERE id = $1`, first.ID)
+	asserts.Error(err)
+
+	_, err = testDB.ExecContext(ctx, `DELETE FROM audit_events WHERE id = $1`, first.ID)
+	asserts.Error(err)
+
+	// Rows altered with the triggers disabled are detected by the hash chain.
+	tamper := func(ip string) {
+		tx, err := testDB.BeginTxx(ctx, nil)
+		asserts.NoError(err)
+		defer func() {
+			_ = tx.Rollback()
+		}()
+
+		_, err = tx.ExecContext(ctx, `SET LOCAL session_replication_role = replica`)
+		asserts.NoError(err)
+		_, err = tx.ExecContext(ctx, `UPDATE audit_events SET ip = $2 WHERE id = $1`, first.ID, ip)
+		asserts.NoError(err)
+		asserts.NoError(tx.Commit())
+	}
+
+	tamper("198.51.100.20")
+	sequence, err = repo.Verify(ctx)
+	asserts.NoError(err)
+	asserts.Equal(first.Sequence, sequence)
+
+	tamper(first.Ip)
+	sequence, err = repo.Verify(ctx)
+	asserts.NoError(err)
+	asserts.Zero(sequence)
+}
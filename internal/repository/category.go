@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/tracing"
+	"context"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+type Category interface {
+	Create(ctx context.Context, category *pb.Category) error
+}
+
+type categoryRepo struct {
+	db *sqlx.DB
+	l  zerolog.Logger
+}
+
+const _categoryCreate = `
+	INSERT INTO categories (name, slug, status, meta, created_at, updated_at)
+	VALUES ($1, $2, $3, $4::jsonb, $5, $6) RETURNING id`
+
+// Create stores the category and writes the category.created event to the outbox in the same transaction.
+func (r *categoryRepo) Create(ctx context.Context, category *pb.Category) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "categories", "Create", _categoryCreate, _outboxCreate)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "create").
+		Str("slug", category.Slug).
+		Str("query", _categoryCreate).
+		Logger()
+
+	meta := []byte("{}")
+	if category.Meta != nil {
+		if meta, err = json.Marshal(category.Meta); err != nil {
+			l.Err(err).Msg("marshal meta")
+			return err
+		}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	now := time.Now()
+	if category.CreatedAt == nil {
+		category.CreatedAt = timestamppb.New(now)
+	}
+	category.UpdatedAt = timestamppb.New(now)
+
+	var id string
+	err = tx.QueryRowxContext(
+		ctx,
+		_categoryCreate,
+		category.Name,
+		category.Slug,
+		category.Status,
+		string(meta),
+		category.CreatedAt.AsTime(),
+		category.UpdatedAt.AsTime(),
+	).Scan(&id)
+
+	if err != nil {
+		l.Err(err).Msg("exec and scan result")
+		return err
+	}
+
+	category.ID = id
+
+	event, err := models.NewCategoryCreatedEvent(category)
+	if err != nil {
+		l.Err(err).Msg("create event")
+		return err
+	}
+
+	if err = createOutboxEvents(ctx, tx, event); err != nil {
+		l.Err(err).Str("query", _outboxCreate).Msg("create outbox event")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	l.Info().Str("id", id).Msg("completed successfully")
+	return nil
+}
+
+func NewTestCategoryRepo(db *sqlx.DB) Category {
+	return NewCategoryRepo(db, logger.TestLogger)
+}
+
+func NewCategoryRepo(db *sqlx.DB, l zerolog.Logger) Category {
+	return &categoryRepo{
+		db: db,
+		l:  l.With().Str("repo", "category_sqlx").Logger(),
+	}
+}
@@ -0,0 +1,44 @@
+package repository_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/repository"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCategoryRepo_Create(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts  = assert.New(t)
+		ctx      = context.Background()
+		repo     = repository.NewTestCategoryRepo(testDB)
+		slug     = uuid.NewString()
+		category = &pb.Category{
+			Name:   "Groceries " + slug,
+			Slug:   slug,
+			Status: pb.Category_ACTIVE,
+			Meta:   &pb.CategoryMeta{Icon: "cart"},
+		}
+	)
+
+	asserts.NoError(repo.Create(ctx, category))
+	asserts.NotEmpty(category.ID)
+
+	var eventType string
+	err := testDB.QueryRowContext(
+		ctx,
+		`SELECT type FROM outbox_events WHERE aggregate_type = $1 AND aggregate_id = $2`,
+		"category",
+		category.ID,
+	).Scan(&eventType)
+	asserts.NoError(err)
+	asserts.Equal("category.created", eventType)
+
+	// The slug is unique.
+	category.ID = ""
+	asserts.Error(repo.Create(ctx, category))
+}
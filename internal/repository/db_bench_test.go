@@ -0,0 +1,105 @@
+package repository_test
+
+import (
+	"bridge/internal/db"
+	"bridge/internal/factory"
+	"bridge/internal/repository"
+	"context"
+	"github.com/jmoiron/sqlx"
+	"testing"
+
+	_ "github.com/lib/pq"
+)
+
+const _benchFindUser = `SELECT id, email FROM users WHERE id = $1`
+
+// BenchmarkFindUser compares the previous lib/pq implementation, which prepared the query on every call, with the
+// pgx statement cache modes.
+func BenchmarkFindUser(b *testing.B) {
+	var (
+		ctx = context.Background()
+		u   = factory.NewUser()
+	)
+
+	if _, err := repository.NewTestUserRepo(ctx, testDB, u); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("lib/pq prepare per call", func(b *testing.B) {
+		conn, err := sqlx.Connect("postgres", testDSN)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Cleanup(func() {
+			_ = conn.Close()
+		})
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			stmt, err := conn.PrepareContext(ctx, _benchFindUser)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var id, email string
+			if err = stmt.QueryRowContext(ctx, u.ID).Scan(&id, &email); err != nil {
+				b.Fatal(err)
+			}
+
+			// The statements weren't closed, which is done here so that the benchmark doesn't exhaust the server.
+			_ = stmt.Close()
+		}
+	})
+
+	modes := []string{
+		db.StatementCacheModeStatement,
+		db.StatementCacheModeDescribe,
+		db.StatementCacheModeDescribeExec,
+		db.StatementCacheModeExec,
+		db.StatementCacheModeSimpleProtocol,
+	}
+
+	for _, mode := range modes {
+		mode := mode
+		b.Run("pgx "+mode, func(b *testing.B) {
+			conn, err := db.NewConnection(testDSN, db.Config{StatementCacheMode: mode})
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.Cleanup(func() {
+				_ = conn.Close()
+			})
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var id, email string
+				if err = conn.QueryRowContext(ctx, _benchFindUser, u.ID).Scan(&id, &email); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkUserRepo_FindByID measures the repository with the default pool configuration, in parallel.
+func BenchmarkUserRepo_FindByID(b *testing.B) {
+	var (
+		ctx = context.Background()
+		u   = factory.NewUser()
+	)
+
+	repo, err := repository.NewTestUserRepo(ctx, testDB, u)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := repo.FindByID(ctx, u.ID); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/encryption"
+	"context"
+	"database/sql"
+)
+
+// encryptedKYC holds encrypted KYC identifiers along with the columns derived from their plaintext values.
+type encryptedKYC struct {
+	idNumber      string
+	idNumberIndex sql.NullString
+	keyVersion    int
+	kraPin        string
+}
+
+// kycData returns the encrypted identifiers as pb.KYCData.
+func (e *encryptedKYC) kycData() *pb.KYCData {
+	return &pb.KYCData{IdNumber: e.idNumber, KraPin: e.kraPin}
+}
+
+// encryptKYC encrypts the KYC identifiers and computes the blind index used to look up the ID number.
+func encryptKYC(ctx context.Context, c encryption.Cipher, idNumber, kraPin string) (*encryptedKYC, error) {
+	var (
+		e   = &encryptedKYC{}
+		err error
+	)
+
+	if e.idNumber, err = c.Encrypt(ctx, idNumber); err != nil {
+		return nil, err
+	}
+
+	if e.kraPin, err = c.Encrypt(ctx, kraPin); err != nil {
+		return nil, err
+	}
+
+	if index := c.BlindIndex(idNumber); index != "" {
+		e.idNumberIndex = sql.NullString{String: index, Valid: true}
+	}
+
+	e.keyVersion = c.KeyVersion(e.idNumber)
+	if v := c.KeyVersion(e.kraPin); v > e.keyVersion {
+		e.keyVersion = v
+	}
+
+	return e, nil
+}
+
+// encryptUserMeta returns a copy of meta with the KYC identifiers encrypted.
+func encryptUserMeta(ctx context.Context, c encryption.Cipher, meta *pb.UserMeta) (*pb.UserMeta, *encryptedKYC, error) {
+	if meta == nil || meta.KycData == nil {
+		return meta, &encryptedKYC{}, nil
+	}
+
+	e, err := encryptKYC(ctx, c, meta.KycData.IdNumber, meta.KycData.KraPin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &pb.UserMeta{KycData: e.kycData()}, e, nil
+}
+
+// decryptKYC decrypts the KYC identifiers in place.
+func decryptKYC(ctx context.Context, c encryption.Cipher, idNumber, kraPin *string) error {
+	var err error
+	if *idNumber, err = c.Decrypt(ctx, *idNumber); err != nil {
+		return err
+	}
+
+	*kraPin, err = c.Decrypt(ctx, *kraPin)
+	return err
+}
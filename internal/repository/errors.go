@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"bridge/internal/rpc_error"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/status"
+	"io"
+	"net"
+	"strings"
+)
+
+// The kinds of the errors returned by the repositories. They are matched with errors.Is, while the database error
+// remains available through errors.As and errors.Is, so sql.ErrNoRows still matches ErrNotFound errors.
+var (
+	// ErrNotFound is returned when no row matched.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a unique constraint is violated.
+	ErrConflict = errors.New("conflict")
+	// ErrForeignKey is returned when a foreign key constraint is violated.
+	ErrForeignKey = errors.New("foreign key violation")
+	// ErrCheckViolation is returned when a check or not-null constraint is violated.
+	ErrCheckViolation = errors.New("check violation")
+	// ErrSerialization is returned when a transaction failed because of a concurrent one and can be retried.
+	ErrSerialization = errors.New("serialization failure")
+	// ErrTimeout is returned when a query was cancelled because of a deadline or a lock timeout.
+	ErrTimeout = errors.New("timeout")
+	// ErrConnectionLost is returned when the connection to the database failed.
+	ErrConnectionLost = errors.New("connection lost")
+)
+
+// The unique constraints which are translated to specific rpc_error values.
+const (
+	ConstraintCategoriesName   = "categories_name_key"
+	ConstraintCategoriesSlug   = "categories_slug_key"
+	ConstraintUsersEmail       = "users_email_key"
+	ConstraintUsersPhoneNumber = "users_phone_number_key"
+)
+
+// conflictErrors maps the unique constraints to the errors returned to the clients when they are violated.
+var conflictErrors = map[string]error{
+	ConstraintCategoriesName:   rpc_error.ErrCategoryExists,
+	ConstraintCategoriesSlug:   rpc_error.ErrCategoryExists,
+	ConstraintUsersEmail:       rpc_error.ErrEmailExists,
+	ConstraintUsersPhoneNumber: rpc_error.ErrPhoneNumberExists,
+}
+
+// Error is a database error translated to one of the error kinds.
+type Error struct {
+	// Kind is one of the error kinds, such as ErrConflict.
+	Kind error
+	// Constraint is the name of the violated constraint, if any.
+	Constraint string
+	// Err is the database error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Constraint != "" {
+		return e.Kind.Error() + " on " + e.Constraint + ": " + e.Err.Error()
+	}
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+// Is reports whether target is the kind of e.
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// TranslateError returns err translated to an Error if it's a database error of one of the error kinds, or err as is
+// otherwise.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var translated *Error
+	if errors.As(err, &translated) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if kind := pgErrorKind(pgErr.Code); kind != nil {
+			return &Error{Kind: kind, Constraint: pgErr.ConstraintName, Err: err}
+		}
+		return err
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return &Error{Kind: ErrNotFound, Err: err}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Error{Kind: ErrTimeout, Err: err}
+	case errors.Is(err, driver.ErrBadConn),
+		errors.Is(err, sql.ErrConnDone),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.As(err, &netErr):
+		return &Error{Kind: ErrConnectionLost, Err: err}
+	}
+
+	return err
+}
+
+// pgErrorKind returns the kind of the errors with the SQLSTATE code, or nil if they don't have one.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html.
+func pgErrorKind(code string) error {
+	switch code {
+	case "23505": // unique_violation
+		return ErrConflict
+	case "23503": // foreign_key_violation
+		return ErrForeignKey
+	case "23502", "23514": // not_null_violation, check_violation
+		return ErrCheckViolation
+	case "40001", "40P01": // serialization_failure, deadlock_detected
+		return ErrSerialization
+	case "57014", "55P03": // query_canceled, lock_not_available
+		return ErrTimeout
+	case "57P01", "57P02", "57P03": // admin_shutdown, crash_shutdown, cannot_connect_now
+		return ErrConnectionLost
+	}
+
+	// connection_exception
+	if strings.HasPrefix(code, "08") {
+		return ErrConnectionLost
+	}
+	return nil
+}
+
+// RPCError returns the rpc_error value returned to the clients for err, an error returned by a repository. Errors
+// which are already rpc_error values are returned as is, and the other errors as rpc_error.ErrServerError so that
+// database details aren't leaked.
+func RPCError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	err = TranslateError(err)
+
+	var translated *Error
+	if !errors.As(err, &translated) {
+		return rpc_error.ErrServerError
+	}
+
+	switch translated.Kind {
+	case ErrNotFound:
+		return rpc_error.ErrResourceNotFound
+	case ErrConflict:
+		if conflictErr, ok := conflictErrors[translated.Constraint]; ok {
+			return conflictErr
+		}
+		return rpc_error.ErrAlreadyExists
+	case ErrForeignKey:
+		return rpc_error.ErrInvalidReference
+	case ErrCheckViolation:
+		return rpc_error.ErrConstraintViolation
+	case ErrSerialization:
+		return rpc_error.ErrConcurrentModification
+	case ErrTimeout:
+		return rpc_error.ErrTimeout
+	case ErrConnectionLost:
+		return rpc_error.ErrUnavailable
+	}
+	return rpc_error.ErrServerError
+}
@@ -0,0 +1,158 @@
+package repository_test
+
+import (
+	"bridge/internal/factory"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTranslateError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		kind       error
+		constraint string
+		rpcErr     error
+	}{
+		{
+			name:   "no rows",
+			err:    fmt.Errorf("scan row: %w", sql.ErrNoRows),
+			kind:   repository.ErrNotFound,
+			rpcErr: rpc_error.ErrResourceNotFound,
+		},
+		{
+			name:       "unique violation on a known constraint",
+			err:        &pgconn.PgError{Code: "23505", ConstraintName: repository.ConstraintUsersEmail},
+			kind:       repository.ErrConflict,
+			constraint: repository.ConstraintUsersEmail,
+			rpcErr:     rpc_error.ErrEmailExists,
+		},
+		{
+			name:       "unique violation",
+			err:        &pgconn.PgError{Code: "23505", ConstraintName: "webhook_deliveries_pkey"},
+			kind:       repository.ErrConflict,
+			constraint: "webhook_deliveries_pkey",
+			rpcErr:     rpc_error.ErrAlreadyExists,
+		},
+		{
+			name:       "foreign key violation",
+			err:        &pgconn.PgError{Code: "23503", ConstraintName: "login_codes_user_id_fkey"},
+			kind:       repository.ErrForeignKey,
+			constraint: "login_codes_user_id_fkey",
+			rpcErr:     rpc_error.ErrInvalidReference,
+		},
+		{
+			name:   "check violation",
+			err:    &pgconn.PgError{Code: "23514"},
+			kind:   repository.ErrCheckViolation,
+			rpcErr: rpc_error.ErrConstraintViolation,
+		},
+		{
+			name:   "deadlock",
+			err:    &pgconn.PgError{Code: "40P01"},
+			kind:   repository.ErrSerialization,
+			rpcErr: rpc_error.ErrConcurrentModification,
+		},
+		{
+			name:   "statement timeout",
+			err:    &pgconn.PgError{Code: "57014"},
+			kind:   repository.ErrTimeout,
+			rpcErr: rpc_error.ErrTimeout,
+		},
+		{
+			name:   "deadline exceeded",
+			err:    context.DeadlineExceeded,
+			kind:   repository.ErrTimeout,
+			rpcErr: rpc_error.ErrTimeout,
+		},
+		{
+			name:   "connection failure",
+			err:    &pgconn.PgError{Code: "08006"},
+			kind:   repository.ErrConnectionLost,
+			rpcErr: rpc_error.ErrUnavailable,
+		},
+		{
+			name:   "bad connection",
+			err:    driver.ErrBadConn,
+			kind:   repository.ErrConnectionLost,
+			rpcErr: rpc_error.ErrUnavailable,
+		},
+		{
+			name:   "other database error",
+			err:    &pgconn.PgError{Code: "42601", Detail: "Key (email)=(jane@example.com) already exists."},
+			rpcErr: rpc_error.ErrServerError,
+		},
+		{
+			name:   "rpc error",
+			err:    rpc_error.ErrEmailExists,
+			rpcErr: rpc_error.ErrEmailExists,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			asserts := assert.New(t)
+
+			err := repository.TranslateError(tt.err)
+			asserts.ErrorIs(err, tt.err)
+			asserts.Equal(err, repository.TranslateError(err))
+
+			var translated *repository.Error
+			if tt.kind == nil {
+				asserts.False(errors.As(err, &translated))
+			} else if asserts.True(errors.As(err, &translated)) {
+				asserts.ErrorIs(err, tt.kind)
+				asserts.Equal(tt.constraint, translated.Constraint)
+			}
+
+			asserts.Equal(tt.rpcErr, repository.RPCError(tt.err))
+		})
+	}
+
+	assert.NoError(t, repository.TranslateError(nil))
+	assert.NoError(t, repository.RPCError(nil))
+}
+
+func TestUserRepo_CreateConflict(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+	)
+
+	repo, err := repository.NewTestUserRepo(ctx, testDB, u)
+	asserts.NoError(err)
+
+	// The names of the unique constraints match the ones mapped to specific errors.
+	duplicate := factory.NewUser()
+	duplicate.Email = u.Email
+
+	err = repo.Create(ctx, duplicate)
+	asserts.ErrorIs(err, repository.ErrConflict)
+	asserts.Equal(rpc_error.ErrEmailExists, repository.RPCError(err))
+
+	duplicate = factory.NewUser()
+	duplicate.PhoneNumber = u.PhoneNumber
+
+	err = repo.Create(ctx, duplicate)
+	asserts.Equal(rpc_error.ErrPhoneNumberExists, repository.RPCError(err))
+
+	_, err = repo.FindByID(ctx, factory.NewUser().ID)
+	asserts.ErrorIs(err, repository.ErrNotFound)
+	asserts.ErrorIs(err, sql.ErrNoRows)
+}
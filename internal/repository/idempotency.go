@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"bridge/internal/encryption"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/tracing"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+// claimAttempts is the number of times a key is claimed when the call holding it releases it concurrently.
+const claimAttempts = 3
+
+type Idempotency interface {
+	// Claim records that the call identified by record.Key is in progress until record.LockedUntil. If the key is
+	// already held by a call which hasn't expired, and which either completed or whose claim hasn't lapsed, that
+	// call's record is returned instead and the key isn't claimed.
+	Claim(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error)
+	// Complete stores the response of the call holding key. The response is encrypted since it may hold secrets such
+	// as access tokens.
+	Complete(ctx context.Context, key, responseType string, response []byte) error
+	// Release deletes the key of a call which didn't complete, so that it can be retried.
+	Release(ctx context.Context, key string) error
+	// DeleteExpired deletes the keys which expired before now, returning how many were deleted.
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+type idempotencyRepo struct {
+	cipher encryption.Cipher
+	db     *sqlx.DB
+	l      zerolog.Logger
+}
+
+const (
+	// _idempotencyClaim inserts the key, or takes over an expired one or one whose call was abandoned.
+	_idempotencyClaim = `
+	INSERT INTO idempotency_keys (key, method, fingerprint, created_at, expires_at, locked_until)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (key) DO UPDATE SET method        = EXCLUDED.method,
+	                                fingerprint   = EXCLUDED.fingerprint,
+	                                response_type = '',
+	                                response      = NULL,
+	                                completed_at  = NULL,
+	                                created_at    = EXCLUDED.created_at,
+	                                expires_at    = EXCLUDED.expires_at,
+	                                locked_until  = EXCLUDED.locked_until
+	WHERE idempotency_keys.expires_at <= $4
+		OR (idempotency_keys.completed_at IS NULL AND idempotency_keys.locked_until <= $4)
+	RETURNING key`
+
+	_idempotencyFind = `
+	SELECT key, method, fingerprint, response_type, response, completed_at, locked_until, created_at, expires_at
+	FROM idempotency_keys
+	WHERE key = $1`
+
+	_idempotencyComplete = `
+	UPDATE idempotency_keys SET response_type = $1, response = $2, completed_at = $3, locked_until = NULL
+	WHERE key = $4 AND completed_at IS NULL`
+
+	_idempotencyRelease       = `DELETE FROM idempotency_keys WHERE key = $1 AND completed_at IS NULL`
+	_idempotencyDeleteExpired = `DELETE FROM idempotency_keys WHERE expires_at < $1`
+)
+
+func (r *idempotencyRepo) Claim(
+	ctx context.Context,
+	record *models.IdempotencyRecord,
+) (_ *models.IdempotencyRecord, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "idempotency_keys", "Claim", _idempotencyClaim, _idempotencyFind)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "claim").
+		Str("key", record.Key).
+		Str("method", record.Method).
+		Logger()
+
+	record.CreatedAt = time.Now()
+
+	for attempt := 0; attempt < claimAttempts; attempt++ {
+		var key string
+		err = r.db.QueryRowxContext(
+			ctx,
+			_idempotencyClaim,
+			record.Key,
+			record.Method,
+			record.Fingerprint,
+			record.CreatedAt,
+			record.ExpiresAt,
+			record.LockedUntil,
+		).Scan(&key)
+
+		if err == nil {
+			l.Info().Msg("completed successfully")
+			return nil, nil
+		}
+
+		if !errors.Is(err, sql.ErrNoRows) {
+			l.Err(err).Str("query", _idempotencyClaim).Msg("exec and scan result")
+			return nil, err
+		}
+
+		existing, err := r.find(ctx, record.Key)
+		if err == nil {
+			l.Info().Bool("completed", existing.CompletedAt != nil).Msg("key already claimed")
+			return existing, nil
+		}
+
+		// The call holding the key released it in between, so it can be claimed again.
+		if !errors.Is(err, sql.ErrNoRows) {
+			l.Err(err).Str("query", _idempotencyFind).Msg("scan row")
+			return nil, err
+		}
+	}
+
+	err = sql.ErrNoRows
+	l.Err(err).Int("attempts", claimAttempts).Msg("failed to claim key")
+	return nil, err
+}
+
+func (r *idempotencyRepo) find(ctx context.Context, key string) (*models.IdempotencyRecord, error) {
+	var (
+		record   = &models.IdempotencyRecord{}
+		response sql.NullString
+	)
+
+	err := r.db.QueryRowxContext(ctx, _idempotencyFind, key).Scan(
+		&record.Key,
+		&record.Method,
+		&record.Fingerprint,
+		&record.ResponseType,
+		&response,
+		&record.CompletedAt,
+		&record.LockedUntil,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Valid {
+		decrypted, err := r.cipher.Decrypt(ctx, response.String)
+		if err != nil {
+			return nil, err
+		}
+
+		if record.Response, err = base64.StdEncoding.DecodeString(decrypted); err != nil {
+			return nil, err
+		}
+	}
+
+	return record, nil
+}
+
+func (r *idempotencyRepo) Complete(ctx context.Context, key, responseType string, response []byte) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "idempotency_keys", "Complete", _idempotencyComplete)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "complete").
+		Str("key", key).
+		Str("response_type", responseType).
+		Str("query", _idempotencyComplete).
+		Logger()
+
+	encrypted, err := r.cipher.Encrypt(ctx, base64.StdEncoding.EncodeToString(response))
+	if err != nil {
+		l.Err(err).Msg("encrypt response")
+		return err
+	}
+
+	res, err := r.db.ExecContext(ctx, _idempotencyComplete, responseType, encrypted, time.Now(), key)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return err
+	}
+
+	if rows == 0 {
+		err = sql.ErrNoRows
+		l.Err(err).Msg("key not claimed")
+		return err
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func (r *idempotencyRepo) Release(ctx context.Context, key string) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "idempotency_keys", "Release", _idempotencyRelease)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "release").
+		Str("key", key).
+		Str("query", _idempotencyRelease).
+		Logger()
+
+	if _, err = r.db.ExecContext(ctx, _idempotencyRelease, key); err != nil {
+		l.Err(err).Msg("exec query")
+		return err
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func (r *idempotencyRepo) DeleteExpired(ctx context.Context, now time.Time) (_ int, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "idempotency_keys", "DeleteExpired", _idempotencyDeleteExpired)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "delete expired").
+		Time("now", now).
+		Str("query", _idempotencyDeleteExpired).
+		Logger()
+
+	res, err := r.db.ExecContext(ctx, _idempotencyDeleteExpired, now)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return 0, err
+	}
+
+	l.Info().Int64("deleted", rows).Msg("completed successfully")
+	return int(rows), nil
+}
+
+func NewTestIdempotencyRepo(db *sqlx.DB) Idempotency {
+	return NewIdempotencyRepo(db, logger.TestLogger, encryption.TestCipher)
+}
+
+func NewIdempotencyRepo(db *sqlx.DB, l zerolog.Logger, cipher encryption.Cipher) Idempotency {
+	return &idempotencyRepo{
+		cipher: cipher,
+		db:     db,
+		l:      l.With().Str("repo", "idempotency_sqlx").Logger(),
+	}
+}
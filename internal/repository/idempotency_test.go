@@ -0,0 +1,138 @@
+package repository_test
+
+import (
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyRepo(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		repo    = repository.NewTestIdempotencyRepo(testDB)
+		record  = &models.IdempotencyRecord{
+			Key:         uuid.NewString(),
+			Method:      "/api.v1.UserService/Create",
+			Fingerprint: "fingerprint",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+		response = []byte("serialized response")
+	)
+
+	existing, err := repo.Claim(ctx, record)
+	asserts.NoError(err)
+	asserts.Nil(existing)
+
+	existing, err = repo.Claim(ctx, record)
+	asserts.NoError(err)
+	if asserts.NotNil(existing) {
+		asserts.Equal(record.Fingerprint, existing.Fingerprint)
+		asserts.Nil(existing.CompletedAt)
+	}
+
+	asserts.NoError(repo.Complete(ctx, record.Key, "api.v1.CreateUserResponse", response))
+	asserts.ErrorIs(repo.Complete(ctx, record.Key, "api.v1.CreateUserResponse", response), sql.ErrNoRows)
+
+	// The response is stored encrypted.
+	var stored string
+	err = testDB.QueryRowContext(ctx, `SELECT response FROM idempotency_keys WHERE key = $1`, record.Key).Scan(&stored)
+	asserts.NoError(err)
+	asserts.NotContains(stored, string(response))
+
+	existing, err = repo.Claim(ctx, record)
+	asserts.NoError(err)
+	if asserts.NotNil(existing) {
+		asserts.NotNil(existing.CompletedAt)
+		asserts.Equal("api.v1.CreateUserResponse", existing.ResponseType)
+		asserts.Equal(response, existing.Response)
+	}
+
+	// Completed keys aren't released.
+	asserts.NoError(repo.Release(ctx, record.Key))
+	existing, err = repo.Claim(ctx, record)
+	asserts.NoError(err)
+	asserts.NotNil(existing)
+
+	released := &models.IdempotencyRecord{
+		Key:         uuid.NewString(),
+		Method:      record.Method,
+		Fingerprint: record.Fingerprint,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	existing, err = repo.Claim(ctx, released)
+	asserts.NoError(err)
+	asserts.Nil(existing)
+
+	asserts.NoError(repo.Release(ctx, released.Key))
+	existing, err = repo.Claim(ctx, released)
+	asserts.NoError(err)
+	asserts.Nil(existing)
+
+	// Expired keys can be claimed again and are deleted.
+	expired := &models.IdempotencyRecord{
+		Key:         uuid.NewString(),
+		Method:      record.Method,
+		Fingerprint: record.Fingerprint,
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	}
+
+	existing, err = repo.Claim(ctx, expired)
+	asserts.NoError(err)
+	asserts.Nil(existing)
+
+	expired.Fingerprint = "other"
+	existing, err = repo.Claim(ctx, expired)
+	asserts.NoError(err)
+	asserts.Nil(existing)
+
+	// Keys whose call was abandoned can be claimed again once the lease lapsed, unlike the ones still leased.
+	var (
+		lapsed    = time.Now().Add(-time.Second)
+		leased    = time.Now().Add(time.Minute)
+		abandoned = &models.IdempotencyRecord{
+			Key:         uuid.NewString(),
+			Method:      record.Method,
+			Fingerprint: record.Fingerprint,
+			LockedUntil: &lapsed,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+	)
+
+	existing, err = repo.Claim(ctx, abandoned)
+	asserts.NoError(err)
+	asserts.Nil(existing)
+
+	abandoned.LockedUntil = &leased
+	existing, err = repo.Claim(ctx, abandoned)
+	asserts.NoError(err)
+	asserts.Nil(existing)
+
+	existing, err = repo.Claim(ctx, abandoned)
+	asserts.NoError(err)
+	if asserts.NotNil(existing) && asserts.NotNil(existing.LockedUntil) {
+		asserts.WithinDuration(leased, *existing.LockedUntil, time.Millisecond)
+	}
+
+	// Completed keys aren't taken over once the lease lapsed.
+	asserts.NoError(repo.Complete(ctx, abandoned.Key, "api.v1.CreateUserResponse", response))
+	abandoned.LockedUntil = &lapsed
+	existing, err = repo.Claim(ctx, abandoned)
+	asserts.NoError(err)
+	if asserts.NotNil(existing) {
+		asserts.NotNil(existing.CompletedAt)
+		asserts.Nil(existing.LockedUntil)
+	}
+
+	deleted, err := repo.DeleteExpired(ctx, time.Now())
+	asserts.NoError(err)
+	asserts.GreaterOrEqual(deleted, 1)
+}
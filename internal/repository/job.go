@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/tracing"
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type Job interface {
+	// Claim locks at most limit pending jobs on queue which are due and marks them as running. Jobs locked by other
+	// workers are skipped.
+	Claim(ctx context.Context, queue string, limit int) ([]*models.Job, error)
+	Complete(ctx context.Context, id string) error
+	Enqueue(ctx context.Context, job *models.Job) error
+	FindByID(ctx context.Context, id string) (*models.Job, error)
+	// Kill moves a running job to the dead-letter state.
+	Kill(ctx context.Context, id string, reason string) error
+	// RescueStale returns jobs locked before lockedBefore to the queue. They were claimed by workers which stopped
+	// before finishing them.
+	RescueStale(ctx context.Context, lockedBefore time.Time) (int, error)
+	// Retry reschedules a running job to run again at runAt.
+	Retry(ctx context.Context, id string, runAt time.Time, reason string) error
+}
+
+type jobRepo struct {
+	db *sqlx.DB
+	l  zerolog.Logger
+}
+
+const (
+	_jobColumns = `id, queue, type, payload, status, attempts, max_attempts, last_error, run_at, locked_at, created_at,
+	updated_at`
+
+	_jobEnqueue = `
+	INSERT INTO jobs (queue, type, payload, status, max_attempts, run_at)
+	VALUES ($1, $2, $3::jsonb, $4, $5, $6) RETURNING id, run_at, created_at, updated_at`
+
+	_jobFindByID = `SELECT ` + _jobColumns + ` FROM jobs WHERE id = $1`
+
+	_jobClaim = `
+	UPDATE jobs SET status = $1, attempts = attempts + 1, locked_at = $2, updated_at = $2
+	WHERE id IN (
+		SELECT id FROM jobs
+		WHERE queue = $3 AND status = $4 AND run_at <= $2
+		ORDER BY run_at
+		LIMIT $5
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + _jobColumns
+
+	_jobComplete = `
+	UPDATE jobs SET status = $1, locked_at = NULL, updated_at = $2 WHERE id = $3 AND status = $4`
+
+	_jobRetry = `
+	UPDATE jobs SET status = $1, run_at = $2, last_error = $3, locked_at = NULL, updated_at = $4
+	WHERE id = $5 AND status = $6`
+
+	_jobKill = `
+	UPDATE jobs SET status = $1, last_error = $2, locked_at = NULL, updated_at = $3 WHERE id = $4 AND status = $5`
+
+	_jobRescueStale = `
+	UPDATE jobs SET status = $1, locked_at = NULL, updated_at = $2 WHERE status = $3 AND locked_at < $4`
+)
+
+func scanJob(row interface{ Scan(dest ...any) error }) (*models.Job, error) {
+	job := &models.Job{}
+	err := row.Scan(
+		&job.ID,
+		&job.Queue,
+		&job.Type,
+		&job.Payload,
+		&job.Status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.LastError,
+		&job.RunAt,
+		&job.LockedAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// finish moves a running job out of the running state using query, which takes the job id and the running status as
+// its last two arguments. ErrNotFound is returned if the job isn't running, for example because it was rescued
+// after its lock went stale.
+func (r *jobRepo) finish(ctx context.Context, l zerolog.Logger, query string, id string, args ...any) error {
+	res, err := r.db.ExecContext(ctx, query, append(args, id, models.JobStatusRunning)...)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return err
+	}
+
+	if rows == 0 {
+		l.Err(sql.ErrNoRows).Msg("job is not running")
+		return sql.ErrNoRows
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func (r *jobRepo) Claim(ctx context.Context, queue string, limit int) (_ []*models.Job, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "jobs", "Claim", _jobClaim)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "claim").
+		Str("queue", queue).
+		Str("query", _jobClaim).
+		Logger()
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		_jobClaim,
+		models.JobStatusRunning,
+		time.Now(),
+		queue,
+		models.JobStatusPending,
+		limit,
+	)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var jobs []*models.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			l.Err(err).Msg("scan row")
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Err(err).Msg("iterate rows")
+		return nil, err
+	}
+
+	l.Info().Int("claimed", len(jobs)).Msg("completed successfully")
+	return jobs, nil
+}
+
+func (r *jobRepo) Complete(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "jobs", "Complete", _jobComplete)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "complete").
+		Str("id", id).
+		Str("query", _jobComplete).
+		Logger()
+
+	return r.finish(ctx, l, _jobComplete, id, models.JobStatusSucceeded, time.Now())
+}
+
+func (r *jobRepo) Enqueue(ctx context.Context, job *models.Job) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "jobs", "Enqueue", _jobEnqueue)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "enqueue").
+		Str("queue", job.Queue).
+		Str("type", job.Type).
+		Str("query", _jobEnqueue).
+		Logger()
+
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now()
+	}
+
+	err = r.db.QueryRowxContext(
+		ctx,
+		_jobEnqueue,
+		job.Queue,
+		job.Type,
+		string(job.Payload),
+		models.JobStatusPending,
+		job.MaxAttempts,
+		job.RunAt,
+	).Scan(&job.ID, &job.RunAt, &job.CreatedAt, &job.UpdatedAt)
+
+	if err != nil {
+		l.Err(err).Msg("exec and scan result")
+		return err
+	}
+
+	job.Status = models.JobStatusPending
+
+	l.Info().Str("id", job.ID).Msg("completed successfully")
+	return nil
+}
+
+func (r *jobRepo) FindByID(ctx context.Context, id string) (_ *models.Job, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "jobs", "FindByID", _jobFindByID)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find by id").
+		Str("id", id).
+		Str("query", _jobFindByID).
+		Logger()
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, _jobFindByID, id))
+	if err != nil {
+		l.Err(err).Msg("scan row")
+		return nil, err
+	}
+
+	l.Info().Msg("completed successfully")
+	return job, nil
+}
+
+func (r *jobRepo) Kill(ctx context.Context, id string, reason string) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "jobs", "Kill", _jobKill)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "kill").
+		Str("id", id).
+		Str("query", _jobKill).
+		Logger()
+
+	return r.finish(ctx, l, _jobKill, id, models.JobStatusDead, reason, time.Now())
+}
+
+func (r *jobRepo) RescueStale(ctx context.Context, lockedBefore time.Time) (_ int, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "jobs", "RescueStale", _jobRescueStale)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "rescue stale").
+		Time("locked_before", lockedBefore).
+		Str("query", _jobRescueStale).
+		Logger()
+
+	res, err := r.db.ExecContext(
+		ctx,
+		_jobRescueStale,
+		models.JobStatusPending,
+		time.Now(),
+		models.JobStatusRunning,
+		lockedBefore,
+	)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return 0, err
+	}
+
+	l.Info().Int64("rescued", rows).Msg("completed successfully")
+	return int(rows), nil
+}
+
+func (r *jobRepo) Retry(ctx context.Context, id string, runAt time.Time, reason string) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "jobs", "Retry", _jobRetry)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "retry").
+		Str("id", id).
+		Time("run_at", runAt).
+		Str("query", _jobRetry).
+		Logger()
+
+	return r.finish(ctx, l, _jobRetry, id, models.JobStatusPending, runAt, reason, time.Now())
+}
+
+func NewTestJobRepo(db *sqlx.DB) Job {
+	return NewJobRepo(db, logger.TestLogger)
+}
+
+func NewJobRepo(db *sqlx.DB, l zerolog.Logger) Job {
+	return &jobRepo{
+		db: db,
+		l:  l.With().Str("repo", "job_sqlx").Logger(),
+	}
+}
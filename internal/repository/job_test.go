@@ -0,0 +1,113 @@
+package repository_test
+
+import (
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTestJob(queue string) *models.Job {
+	return &models.Job{
+		Queue:       queue,
+		Type:        "greet",
+		Payload:     json.RawMessage(`{"name": "Jane"}`),
+		MaxAttempts: 3,
+	}
+}
+
+func TestJobRepo(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		repo    = repository.NewTestJobRepo(testDB)
+		queue   = uuid.NewString()
+		job     = newTestJob(queue)
+		delayed = newTestJob(queue)
+	)
+
+	delayed.RunAt = time.Now().Add(time.Hour)
+
+	asserts.NoError(repo.Enqueue(ctx, job))
+	asserts.NotEmpty(job.ID)
+	asserts.Equal(models.JobStatusPending, job.Status)
+
+	asserts.NoError(repo.Enqueue(ctx, delayed))
+
+	// Only the due job is claimed.
+	claimed, err := repo.Claim(ctx, queue, 10)
+	asserts.NoError(err)
+	asserts.Len(claimed, 1)
+	asserts.Equal(job.ID, claimed[0].ID)
+	asserts.Equal(models.JobStatusRunning, claimed[0].Status)
+	asserts.Equal(1, claimed[0].Attempts)
+	asserts.JSONEq(string(job.Payload), string(claimed[0].Payload))
+
+	// Running jobs aren't claimed again.
+	claimed, err = repo.Claim(ctx, queue, 10)
+	asserts.NoError(err)
+	asserts.Empty(claimed)
+
+	runAt := time.Now().Add(-time.Second)
+	asserts.NoError(repo.Retry(ctx, job.ID, runAt, "connection refused"))
+
+	gotJob, err := repo.FindByID(ctx, job.ID)
+	asserts.NoError(err)
+	asserts.Equal(models.JobStatusPending, gotJob.Status)
+	asserts.Equal("connection refused", *gotJob.LastError)
+	asserts.Nil(gotJob.LockedAt)
+
+	claimed, err = repo.Claim(ctx, queue, 10)
+	asserts.NoError(err)
+	asserts.Len(claimed, 1)
+	asserts.Equal(2, claimed[0].Attempts)
+
+	asserts.NoError(repo.Complete(ctx, job.ID))
+
+	// The job is no longer running.
+	asserts.ErrorIs(repo.Kill(ctx, job.ID, "invalid recipient"), sql.ErrNoRows)
+
+	gotJob, err = repo.FindByID(ctx, job.ID)
+	asserts.NoError(err)
+	asserts.Equal(models.JobStatusSucceeded, gotJob.Status)
+}
+
+func TestJobRepo_RescueStale(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		repo    = repository.NewTestJobRepo(testDB)
+		queue   = uuid.NewString()
+		job     = newTestJob(queue)
+	)
+
+	asserts.NoError(repo.Enqueue(ctx, job))
+
+	claimed, err := repo.Claim(ctx, queue, 1)
+	asserts.NoError(err)
+	asserts.Len(claimed, 1)
+
+	rescued, err := repo.RescueStale(ctx, time.Now().Add(time.Second))
+	asserts.NoError(err)
+	asserts.GreaterOrEqual(rescued, 1)
+
+	claimed, err = repo.Claim(ctx, queue, 1)
+	asserts.NoError(err)
+	asserts.Len(claimed, 1)
+
+	asserts.NoError(repo.Kill(ctx, job.ID, "invalid recipient"))
+
+	gotJob, err := repo.FindByID(ctx, job.ID)
+	asserts.NoError(err)
+	asserts.Equal(models.JobStatusDead, gotJob.Status)
+	asserts.Equal("invalid recipient", *gotJob.LastError)
+}
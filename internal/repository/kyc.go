@@ -0,0 +1,440 @@
+package repository
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/encryption"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// KYC is the repository of the KYC submissions. The writes taking audit events append them to the audit trail in the
+// same transaction as the change, see audit.Record.
+type KYC interface {
+	Create(ctx context.Context, submission *pb.KYCSubmission, events ...*pb.AuditEvent) error
+	FindByID(ctx context.Context, id string) (*pb.KYCSubmission, error)
+	FindLatestByUserID(ctx context.Context, userID string) (*pb.KYCSubmission, error)
+	Reencrypt(ctx context.Context, latestVersion, limit int) (int, error)
+	UpdateStatus(
+		ctx context.Context,
+		submission *pb.KYCSubmission,
+		from pb.KYCSubmission_Status,
+		events ...*pb.AuditEvent,
+	) error
+}
+
+type kycRepo struct {
+	cipher encryption.Cipher
+	db     *sqlx.DB
+	l      zerolog.Logger
+}
+
+const (
+	_kycBaseSelect = `
+	SELECT id, user_id, id_number, kra_pin, documents, status, reviewer_id, review_notes, submitted_at, reviewed_at,
+		updated_at
+	FROM kyc_submissions `
+	_kycFindByID           = _kycBaseSelect + `WHERE id = $1`
+	_kycFindLatestByUserID = _kycBaseSelect + `WHERE user_id = $1 ORDER BY submitted_at DESC LIMIT 1`
+
+	_kycCreate = `
+	INSERT INTO kyc_submissions (user_id, id_number, kra_pin, documents, status, submitted_at, updated_at,
+		id_number_index, encryption_key_version)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+
+	_kycUpdateStatus = `
+	UPDATE kyc_submissions
+	SET status       = $1,
+		reviewer_id  = $2,
+		review_notes = $3,
+		reviewed_at  = $4,
+		updated_at   = $5
+	WHERE id = $6 AND status = $7`
+
+	_kycApproveUser = `
+	UPDATE users
+	SET meta                   = jsonb_set(coalesce(meta, '{}'::jsonb), '{kyc_data}', $1::jsonb),
+		updated_at             = $2,
+		id_number_index        = $3,
+		encryption_key_version = $4
+	WHERE id = $5`
+
+	_kycListStaleEncryption = `
+	SELECT id, id_number, kra_pin
+	FROM kyc_submissions
+	WHERE encryption_key_version < $1
+	ORDER BY id
+	LIMIT $2 FOR UPDATE SKIP LOCKED`
+
+	_kycUpdateEncryption = `
+	UPDATE kyc_submissions
+	SET id_number              = $1,
+		kra_pin                = $2,
+		id_number_index        = $3,
+		encryption_key_version = $4
+	WHERE id = $5`
+)
+
+func (r *kycRepo) scanRow(ctx context.Context, row *sqlx.Row) (*pb.KYCSubmission, error) {
+	var (
+		s                       = &pb.KYCSubmission{}
+		documents               models.KYCDocuments
+		reviewerID, reviewNotes sql.NullString
+		reviewedAt              sql.NullTime
+		submittedAt, updatedAt  time.Time
+	)
+
+	err := row.Scan(
+		&s.ID,
+		&s.UserId,
+		&s.IdNumber,
+		&s.KraPin,
+		&documents,
+		&s.Status,
+		&reviewerID,
+		&reviewNotes,
+		&submittedAt,
+		&reviewedAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = decryptKYC(ctx, r.cipher, &s.IdNumber, &s.KraPin); err != nil {
+		return nil, err
+	}
+
+	s.Documents = documents
+	s.ReviewerId = reviewerID.String
+	s.ReviewNotes = reviewNotes.String
+	s.SubmittedAt = timestamppb.New(submittedAt)
+	s.UpdatedAt = timestamppb.New(updatedAt)
+
+	if reviewedAt.Valid {
+		s.ReviewedAt = timestamppb.New(reviewedAt.Time)
+	}
+	return s, nil
+}
+
+// Create stores the submission and writes the audit events, which target the submission unless they have a target, in
+// the same transaction.
+func (r *kycRepo) Create(ctx context.Context, submission *pb.KYCSubmission, events ...*pb.AuditEvent) (err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "create").
+		Str("user_id", submission.UserId).
+		Str("query", _kycCreate).
+		Logger()
+
+	kyc, err := encryptKYC(ctx, r.cipher, submission.IdNumber, submission.KraPin)
+	if err != nil {
+		l.Err(err).Msg("encrypt kyc data")
+		return err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		id  string
+		now = time.Now()
+	)
+
+	err = tx.QueryRowxContext(
+		ctx,
+		_kycCreate,
+		submission.UserId,
+		kyc.idNumber,
+		kyc.kraPin,
+		models.KYCDocuments(submission.Documents),
+		submission.Status,
+		now,
+		now,
+		kyc.idNumberIndex,
+		kyc.keyVersion,
+	).Scan(&id)
+
+	if err != nil {
+		l.Err(err).Msg("exec and scan result")
+		return err
+	}
+
+	setAuditTargetID(id, events...)
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	submission.ID = id
+	submission.SubmittedAt = timestamppb.New(now)
+	submission.UpdatedAt = timestamppb.New(now)
+	return nil
+}
+
+func (r *kycRepo) FindByID(ctx context.Context, id string) (_ *pb.KYCSubmission, err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find by id").
+		Str("id", id).
+		Str("query", _kycFindByID).
+		Logger()
+
+	s, err := r.scanRow(ctx, r.db.QueryRowxContext(ctx, _kycFindByID, id))
+	if err != nil {
+		l.Err(err).Msg("scan row")
+		return nil, err
+	}
+
+	l.Info().Msg("completed successfully")
+	return s, nil
+}
+
+func (r *kycRepo) FindLatestByUserID(ctx context.Context, userID string) (_ *pb.KYCSubmission, err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find latest by user id").
+		Str("user_id", userID).
+		Str("query", _kycFindLatestByUserID).
+		Logger()
+
+	s, err := r.scanRow(ctx, r.db.QueryRowxContext(ctx, _kycFindLatestByUserID, userID))
+	if err != nil {
+		l.Err(err).Msg("scan row")
+		return nil, err
+	}
+
+	l.Info().Str("id", s.ID).Msg("completed successfully")
+	return s, nil
+}
+
+// Reencrypt re-encrypts the identifiers of submissions encrypted with a key version older than latestVersion.
+func (r *kycRepo) Reencrypt(ctx context.Context, latestVersion, limit int) (_ int, err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "reencrypt").
+		Int("latest_version", latestVersion).
+		Logger()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return 0, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.QueryxContext(ctx, _kycListStaleEncryption, latestVersion, limit)
+	if err != nil {
+		l.Err(err).Str("query", _kycListStaleEncryption).Msg("query rows")
+		return 0, err
+	}
+
+	var submissions []*pb.KYCSubmission
+	for rows.Next() {
+		s := &pb.KYCSubmission{}
+		if err = rows.Scan(&s.ID, &s.IdNumber, &s.KraPin); err != nil {
+			_ = rows.Close()
+			l.Err(err).Msg("scan row")
+			return 0, err
+		}
+
+		submissions = append(submissions, s)
+	}
+
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		l.Err(err).Msg("iterate rows")
+		return 0, err
+	}
+
+	for _, s := range submissions {
+		if err = decryptKYC(ctx, r.cipher, &s.IdNumber, &s.KraPin); err != nil {
+			l.Err(err).Str("id", s.ID).Msg("decrypt kyc data")
+			return 0, err
+		}
+
+		kyc, err := encryptKYC(ctx, r.cipher, s.IdNumber, s.KraPin)
+		if err != nil {
+			l.Err(err).Str("id", s.ID).Msg("encrypt kyc data")
+			return 0, err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			_kycUpdateEncryption,
+			kyc.idNumber,
+			kyc.kraPin,
+			kyc.idNumberIndex,
+			latestVersion,
+			s.ID,
+		)
+		if err != nil {
+			l.Err(err).Str("id", s.ID).Str("query", _kycUpdateEncryption).Msg("exec query")
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return 0, err
+	}
+
+	l.Info().Int("count", len(submissions)).Msg("completed successfully")
+	return len(submissions), nil
+}
+
+// UpdateStatus moves the submission from the provided status to submission.Status. Approving a submission also
+// copies the verified identifiers to the user's KYC data. ErrNotFound is returned if the submission is no longer
+// in the from status. The audit events are written in the same transaction.
+func (r *kycRepo) UpdateStatus(
+	ctx context.Context,
+	submission *pb.KYCSubmission,
+	from pb.KYCSubmission_Status,
+	events ...*pb.AuditEvent,
+) (err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "update status").
+		Str("id", submission.ID).
+		Stringer("from", from).
+		Stringer("to", submission.Status).
+		Logger()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		now         = time.Now()
+		reviewerID  = sql.NullString{String: submission.ReviewerId, Valid: submission.ReviewerId != ""}
+		reviewNotes = sql.NullString{String: submission.ReviewNotes, Valid: submission.ReviewNotes != ""}
+		reviewedAt  sql.NullTime
+	)
+
+	if submission.Status == pb.KYCSubmission_APPROVED || submission.Status == pb.KYCSubmission_REJECTED {
+		reviewedAt = sql.NullTime{Time: now, Valid: true}
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		_kycUpdateStatus,
+		submission.Status,
+		reviewerID,
+		reviewNotes,
+		reviewedAt,
+		now,
+		submission.ID,
+		from,
+	)
+	if err != nil {
+		l.Err(err).Str("query", _kycUpdateStatus).Msg("exec query")
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return err
+	}
+
+	if rows == 0 {
+		l.Err(sql.ErrNoRows).Msg("submission not found or status changed")
+		return sql.ErrNoRows
+	}
+
+	if submission.Status == pb.KYCSubmission_APPROVED {
+		kyc, err := encryptKYC(ctx, r.cipher, submission.IdNumber, submission.KraPin)
+		if err != nil {
+			l.Err(err).Msg("encrypt kyc data")
+			return err
+		}
+
+		kycData, err := json.Marshal(kyc.kycData())
+		if err != nil {
+			l.Err(err).Msg("marshal kyc data")
+			return err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			_kycApproveUser,
+			kycData,
+			now,
+			kyc.idNumberIndex,
+			kyc.keyVersion,
+			submission.UserId,
+		)
+		if err != nil {
+			l.Err(err).Str("query", _kycApproveUser).Msg("exec query")
+			return err
+		}
+	}
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	submission.UpdatedAt = timestamppb.New(now)
+	if reviewedAt.Valid {
+		submission.ReviewedAt = timestamppb.New(now)
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func NewTestKYCRepo(db *sqlx.DB) KYC {
+	return NewKYCRepo(db, logger.TestLogger, encryption.TestCipher)
+}
+
+func NewKYCRepo(db *sqlx.DB, l zerolog.Logger, cipher encryption.Cipher) KYC {
+	return &kycRepo{
+		cipher: cipher,
+		db:     db,
+		l:      l.With().Str("repo", "kyc_sqlx").Logger(),
+	}
+}
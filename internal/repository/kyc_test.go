@@ -0,0 +1,73 @@
+package repository_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/factory"
+	"bridge/internal/repository"
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKYCRepo(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts  = assert.New(t)
+		ctx      = context.Background()
+		u        = factory.NewUser()
+		reviewer = factory.NewUser()
+	)
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testDB, u, reviewer)
+	asserts.NoError(err)
+
+	var (
+		repo       = repository.NewTestKYCRepo(testDB)
+		submission = factory.NewKYCSubmission(u.ID)
+	)
+
+	err = repo.Create(ctx, submission)
+	asserts.NoError(err)
+	asserts.NotEmpty(submission.ID)
+
+	var storedIDNumber, storedKraPin string
+	err = testDB.QueryRowContext(ctx, `SELECT id_number, kra_pin FROM kyc_submissions WHERE id = $1`, submission.ID).
+		Scan(&storedIDNumber, &storedKraPin)
+	asserts.NoError(err)
+	asserts.NotEqual(submission.IdNumber, storedIDNumber)
+	asserts.NotEqual(submission.KraPin, storedKraPin)
+
+	gotSubmission, err := repo.FindLatestByUserID(ctx, u.ID)
+	asserts.NoError(err)
+	asserts.Equal(submission.ID, gotSubmission.ID)
+	asserts.Len(gotSubmission.Documents, len(submission.Documents))
+	asserts.Equal(pb.KYCSubmission_SUBMITTED, gotSubmission.Status)
+
+	gotSubmission.Status = pb.KYCSubmission_APPROVED
+	gotSubmission.ReviewerId = reviewer.ID
+	gotSubmission.ReviewNotes = "documents verified"
+
+	err = repo.UpdateStatus(ctx, gotSubmission, pb.KYCSubmission_SUBMITTED)
+	asserts.NoError(err)
+	asserts.NotNil(gotSubmission.ReviewedAt)
+
+	// The submission is no longer in the submitted status.
+	err = repo.UpdateStatus(ctx, gotSubmission, pb.KYCSubmission_SUBMITTED)
+	asserts.ErrorIs(err, sql.ErrNoRows)
+
+	gotSubmission, err = repo.FindByID(ctx, submission.ID)
+	asserts.NoError(err)
+	asserts.Equal(pb.KYCSubmission_APPROVED, gotSubmission.Status)
+	asserts.Equal(reviewer.ID, gotSubmission.ReviewerId)
+
+	gotUser, err := userRepo.FindByID(ctx, u.ID)
+	asserts.NoError(err)
+	asserts.Equal(submission.IdNumber, gotUser.Meta.KycData.IdNumber)
+	asserts.Equal(submission.KraPin, gotUser.Meta.KycData.KraPin)
+
+	gotUser, err = userRepo.FindByIDNumber(ctx, submission.IdNumber)
+	asserts.NoError(err)
+	asserts.Equal(u.ID, gotUser.ID)
+}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+// ErrLoginCodeCooldown is returned by LoginCode.Replace when a code was created for the user too recently.
+var ErrLoginCodeCooldown = errors.New("login code requested during the cooldown")
+
+type LoginCode interface {
+	Consume(ctx context.Context, id string) error
+	Create(ctx context.Context, code *models.LoginCode) error
+	FindActive(ctx context.Context, userID string, channel models.LoginChannel) (*models.LoginCode, error)
+	// IncrementAttempts counts an attempt to use the unused code with id and returns the attempts made so far.
+	// ErrNotFound is returned once maxAttempts were made or the code was used.
+	IncrementAttempts(ctx context.Context, id string, maxAttempts int) (int, error)
+	Invalidate(ctx context.Context, userID string, channel models.LoginChannel) error
+	// Replace invalidates the unused codes of the user for the channel of code and stores code, unless a code was
+	// created for them less than cooldown before code, in which case ErrLoginCodeCooldown is returned. Concurrent
+	// calls for the same user are serialized.
+	Replace(ctx context.Context, code *models.LoginCode, cooldown time.Duration) error
+}
+
+type loginCodeRepo struct {
+	db *sqlx.DB
+	l  zerolog.Logger
+}
+
+const (
+	_loginCodeFindActive = `
+	SELECT id, user_id, channel, code_hash, attempts, expires_at, consumed_at, created_at
+	FROM login_codes
+	WHERE user_id = $1 AND channel = $2 AND consumed_at IS NULL
+	ORDER BY created_at DESC
+	LIMIT 1`
+
+	_loginCodeCreate = `
+	INSERT INTO login_codes (user_id, channel, code_hash, expires_at, created_at)
+	VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+	_loginCodeIncrementAttempts = `
+	UPDATE login_codes
+	SET attempts = attempts + 1
+	WHERE id = $1 AND attempts < $2 AND consumed_at IS NULL
+	RETURNING attempts`
+
+	_loginCodeConsume    = `UPDATE login_codes SET consumed_at = $1 WHERE id = $2 AND consumed_at IS NULL`
+	_loginCodeInvalidate = `
+	UPDATE login_codes SET consumed_at = $1 WHERE user_id = $2 AND channel = $3 AND consumed_at IS NULL`
+
+	// _loginCodeLockUser serializes the replacements of the codes of a user.
+	_loginCodeLockUser     = `SELECT id FROM users WHERE id = $1 FOR NO KEY UPDATE`
+	_loginCodeCreatedSince = `
+	SELECT exists(SELECT 1 FROM login_codes WHERE user_id = $1 AND channel = $2 AND created_at > $3)`
+)
+
+func (r *loginCodeRepo) Consume(ctx context.Context, id string) (err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "consume").
+		Str("id", id).
+		Str("query", _loginCodeConsume).
+		Logger()
+
+	res, err := r.db.ExecContext(ctx, _loginCodeConsume, time.Now(), id)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return err
+	}
+
+	// The code was consumed by a concurrent request.
+	if rows == 0 {
+		l.Err(sql.ErrNoRows).Msg("code already consumed")
+		return sql.ErrNoRows
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func (r *loginCodeRepo) Create(ctx context.Context, code *models.LoginCode) (err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "create").
+		Str("user_id", code.UserID).
+		Str("channel", string(code.Channel)).
+		Str("query", _loginCodeCreate).
+		Logger()
+
+	var id string
+	err = r.db.QueryRowxContext(
+		ctx,
+		_loginCodeCreate,
+		code.UserID,
+		code.Channel,
+		code.CodeHash,
+		code.ExpiresAt,
+		code.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		l.Err(err).Msg("exec and scan result")
+		return err
+	}
+
+	code.ID = id
+	return nil
+}
+
+func (r *loginCodeRepo) FindActive(
+	ctx context.Context,
+	userID string,
+	channel models.LoginChannel,
+) (_ *models.LoginCode, err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find active").
+		Str("user_id", userID).
+		Str("channel", string(channel)).
+		Str("query", _loginCodeFindActive).
+		Logger()
+
+	code := &models.LoginCode{}
+	err = r.db.QueryRowContext(ctx, _loginCodeFindActive, userID, channel).Scan(
+		&code.ID,
+		&code.UserID,
+		&code.Channel,
+		&code.CodeHash,
+		&code.Attempts,
+		&code.ExpiresAt,
+		&code.ConsumedAt,
+		&code.CreatedAt,
+	)
+	if err != nil {
+		l.Err(err).Msg("scan row")
+		return nil, err
+	}
+
+	l.Info().Str("id", code.ID).Msg("completed successfully")
+	return code, nil
+}
+
+func (r *loginCodeRepo) IncrementAttempts(ctx context.Context, id string, maxAttempts int) (_ int, err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "increment attempts").
+		Str("id", id).
+		Str("query", _loginCodeIncrementAttempts).
+		Logger()
+
+	var attempts int
+	err = r.db.QueryRowContext(ctx, _loginCodeIncrementAttempts, id, maxAttempts).Scan(&attempts)
+	if err != nil {
+		l.Err(err).Msg("scan row")
+		return 0, err
+	}
+
+	l.Info().Int("attempts", attempts).Msg("completed successfully")
+	return attempts, nil
+}
+
+func (r *loginCodeRepo) Invalidate(ctx context.Context, userID string, channel models.LoginChannel) (err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "invalidate").
+		Str("user_id", userID).
+		Str("channel", string(channel)).
+		Str("query", _loginCodeInvalidate).
+		Logger()
+
+	if _, err = r.db.ExecContext(ctx, _loginCodeInvalidate, time.Now(), userID, channel); err != nil {
+		l.Err(err).Msg("exec query")
+		return err
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func (r *loginCodeRepo) Replace(ctx context.Context, code *models.LoginCode, cooldown time.Duration) (err error) {
+	defer func() {
+		err = TranslateError(err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "replace").
+		Str("user_id", code.UserID).
+		Str("channel", string(code.Channel)).
+		Logger()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var userID string
+	if err = tx.QueryRowContext(ctx, _loginCodeLockUser, code.UserID).Scan(&userID); err != nil {
+		l.Err(err).Str("query", _loginCodeLockUser).Msg("lock user")
+		return err
+	}
+
+	var recent bool
+	err = tx.QueryRowContext(ctx, _loginCodeCreatedSince, code.UserID, code.Channel, code.CreatedAt.Add(-cooldown)).
+		Scan(&recent)
+	if err != nil {
+		l.Err(err).Str("query", _loginCodeCreatedSince).Msg("scan row")
+		return err
+	}
+
+	if recent {
+		l.Error().Dur("cooldown", cooldown).Msg("code created during the cooldown")
+		return ErrLoginCodeCooldown
+	}
+
+	if _, err = tx.ExecContext(ctx, _loginCodeInvalidate, code.CreatedAt, code.UserID, code.Channel); err != nil {
+		l.Err(err).Str("query", _loginCodeInvalidate).Msg("exec query")
+		return err
+	}
+
+	var id string
+	err = tx.QueryRowxContext(
+		ctx,
+		_loginCodeCreate,
+		code.UserID,
+		code.Channel,
+		code.CodeHash,
+		code.ExpiresAt,
+		code.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		l.Err(err).Str("query", _loginCodeCreate).Msg("exec and scan result")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	code.ID = id
+	l.Info().Str("id", id).Msg("completed successfully")
+	return nil
+}
+
+func NewTestLoginCodeRepo(db *sqlx.DB) LoginCode {
+	return NewLoginCodeRepo(db, logger.TestLogger)
+}
+
+func NewLoginCodeRepo(db *sqlx.DB, l zerolog.Logger) LoginCode {
+	return &loginCodeRepo{
+		db: db,
+		l:  l.With().Str("repo", "login_code_sqlx").Logger(),
+	}
+}
@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"bridge/internal/factory"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLoginCodeRepo(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+	)
+
+	_, err := repository.NewTestUserRepo(ctx, testDB, u)
+	asserts.NoError(err)
+
+	repo := repository.NewTestLoginCodeRepo(testDB)
+
+	code := &models.LoginCode{
+		UserID:    u.ID,
+		Channel:   models.LoginChannelSMS,
+		CodeHash:  "hash",
+		ExpiresAt: time.Now().Add(time.Minute),
+		CreatedAt: time.Now(),
+	}
+
+	err = repo.Create(ctx, code)
+	asserts.NoError(err)
+	asserts.NotEmpty(code.ID)
+
+	gotCode, err := repo.FindActive(ctx, u.ID, models.LoginChannelSMS)
+	asserts.NoError(err)
+	asserts.Equal(code.ID, gotCode.ID)
+	asserts.False(gotCode.Expired())
+
+	_, err = repo.FindActive(ctx, u.ID, models.LoginChannelEmail)
+	asserts.ErrorIs(err, sql.ErrNoRows)
+
+	attempts, err := repo.IncrementAttempts(ctx, code.ID, 2)
+	asserts.NoError(err)
+	asserts.Equal(1, attempts)
+
+	attempts, err = repo.IncrementAttempts(ctx, code.ID, 2)
+	asserts.NoError(err)
+	asserts.Equal(2, attempts)
+
+	_, err = repo.IncrementAttempts(ctx, code.ID, 2)
+	asserts.ErrorIs(err, repository.ErrNotFound)
+
+	err = repo.Consume(ctx, code.ID)
+	asserts.NoError(err)
+
+	err = repo.Consume(ctx, code.ID)
+	asserts.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = repo.FindActive(ctx, u.ID, models.LoginChannelSMS)
+	asserts.ErrorIs(err, sql.ErrNoRows)
+}
+
+func TestLoginCodeRepo_Replace(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+		now     = time.Now()
+	)
+
+	_, err := repository.NewTestUserRepo(ctx, testDB, u)
+	asserts.NoError(err)
+
+	repo := repository.NewTestLoginCodeRepo(testDB)
+
+	newCode := func(createdAt time.Time) *models.LoginCode {
+		return &models.LoginCode{
+			UserID:    u.ID,
+			Channel:   models.LoginChannelSMS,
+			CodeHash:  "hash",
+			ExpiresAt: createdAt.Add(time.Minute),
+			CreatedAt: createdAt,
+		}
+	}
+
+	first := newCode(now.Add(-2 * time.Minute))
+	asserts.NoError(repo.Replace(ctx, first, time.Minute))
+
+	second := newCode(now)
+	asserts.NoError(repo.Replace(ctx, second, time.Minute))
+
+	gotCode, err := repo.FindActive(ctx, u.ID, models.LoginChannelSMS)
+	asserts.NoError(err)
+	asserts.Equal(second.ID, gotCode.ID)
+
+	err = repo.Replace(ctx, newCode(now.Add(time.Second)), time.Minute)
+	asserts.ErrorIs(err, repository.ErrLoginCodeCooldown)
+
+	gotCode, err = repo.FindActive(ctx, u.ID, models.LoginChannelSMS)
+	asserts.NoError(err)
+	asserts.Equal(second.ID, gotCode.ID)
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/tracing"
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+// PublishFunc publishes a single outbox event.
+type PublishFunc func(ctx context.Context, event *models.OutboxEvent) error
+
+type Outbox interface {
+	// Publish passes at most limit unpublished events to publish in the order they were written and marks the ones
+	// published successfully, returning how many were published.
+	//
+	// The events are locked while they're published so that relays running concurrently don't publish them twice.
+	// Once an event fails to publish, the later events of its aggregate are left for the next call so that the
+	// events of an aggregate are always published in order. An event may be published more than once if the
+	// transaction fails to commit.
+	Publish(ctx context.Context, limit int, publish PublishFunc) (int, error)
+}
+
+type outboxRepo struct {
+	db *sqlx.DB
+	l  zerolog.Logger
+}
+
+const (
+	_outboxCreate = `
+	INSERT INTO outbox_events (aggregate_type, aggregate_id, type, payload, created_at)
+	VALUES ($1, $2, $3, $4::jsonb, $5) RETURNING id, sequence`
+
+	_outboxListUnpublished = `
+	SELECT id, sequence, aggregate_type, aggregate_id, type, payload, attempts, last_error, created_at
+	FROM outbox_events
+	WHERE published_at IS NULL
+	ORDER BY sequence
+	LIMIT $1
+	FOR UPDATE`
+
+	_outboxMarkPublished = `UPDATE outbox_events SET published_at = $1, attempts = attempts + 1 WHERE id = $2`
+	_outboxMarkFailed    = `UPDATE outbox_events SET attempts = attempts + 1, last_error = $1 WHERE id = $2`
+)
+
+// createOutboxEvents writes the events to the outbox using tx, so that they're only published if the change they
+// describe is committed.
+func createOutboxEvents(ctx context.Context, tx *sqlx.Tx, events ...*models.OutboxEvent) error {
+	for _, event := range events {
+		event.CreatedAt = time.Now()
+
+		err := tx.QueryRowxContext(
+			ctx,
+			_outboxCreate,
+			event.AggregateType,
+			event.AggregateID,
+			event.Type,
+			string(event.Payload),
+			event.CreatedAt,
+		).Scan(&event.ID, &event.Sequence)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *outboxRepo) Publish(ctx context.Context, limit int, publish PublishFunc) (_ int, err error) {
+	ctx, span := tracing.StartDBSpan(
+		ctx,
+		"outbox_events",
+		"Publish",
+		_outboxListUnpublished,
+		_outboxMarkPublished,
+		_outboxMarkFailed,
+	)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "publish").Logger()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return 0, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.QueryxContext(ctx, _outboxListUnpublished, limit)
+	if err != nil {
+		l.Err(err).Str("query", _outboxListUnpublished).Msg("query rows")
+		return 0, err
+	}
+
+	var events []*models.OutboxEvent
+	for rows.Next() {
+		event := &models.OutboxEvent{}
+		err = rows.Scan(
+			&event.ID,
+			&event.Sequence,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.Type,
+			&event.Payload,
+			&event.Attempts,
+			&event.LastError,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			_ = rows.Close()
+			l.Err(err).Msg("scan row")
+			return 0, err
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Err(err).Msg("iterate rows")
+		return 0, err
+	}
+
+	_ = rows.Close()
+
+	var (
+		published int
+		blocked   = make(map[string]bool)
+	)
+
+	for _, event := range events {
+		aggregate := event.AggregateType + ":" + event.AggregateID
+		if blocked[aggregate] {
+			continue
+		}
+
+		if publishErr := publish(ctx, event); publishErr != nil {
+			blocked[aggregate] = true
+
+			l.Warn().Err(publishErr).
+				Str("event_id", event.ID).
+				Str("type", string(event.Type)).
+				Msg("failed to publish event")
+
+			if _, err = tx.ExecContext(ctx, _outboxMarkFailed, publishErr.Error(), event.ID); err != nil {
+				l.Err(err).Str("query", _outboxMarkFailed).Msg("exec query")
+				return 0, err
+			}
+
+			continue
+		}
+
+		if _, err = tx.ExecContext(ctx, _outboxMarkPublished, time.Now(), event.ID); err != nil {
+			l.Err(err).Str("query", _outboxMarkPublished).Msg("exec query")
+			return 0, err
+		}
+
+		published++
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return 0, err
+	}
+
+	l.Info().Int("published", published).Int("pending", len(events)-published).Msg("completed successfully")
+	return published, nil
+}
+
+func NewTestOutboxRepo(db *sqlx.DB) Outbox {
+	return NewOutboxRepo(db, logger.TestLogger)
+}
+
+func NewOutboxRepo(db *sqlx.DB, l zerolog.Logger) Outbox {
+	return &outboxRepo{
+		db: db,
+		l:  l.With().Str("repo", "outbox_sqlx").Logger(),
+	}
+}
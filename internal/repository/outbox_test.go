@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/factory"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOutboxRepo_Publish(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+		outbox  = repository.NewTestOutboxRepo(testDB)
+	)
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testDB, u)
+	asserts.NoError(err)
+
+	u.Name = "Jane Doe"
+	asserts.NoError(userRepo.Update(ctx, u))
+
+	// publishUserEvents publishes the pending events, failing for the user's events if fail is set, and returns
+	// the types of the user's events which were published.
+	publishUserEvents := func(fail bool) []models.EventType {
+		var published []models.EventType
+
+		_, err := outbox.Publish(ctx, 1000, func(ctx context.Context, event *models.OutboxEvent) error {
+			if event.AggregateID != u.ID {
+				return nil
+			}
+
+			if fail {
+				return errors.New("broker unavailable")
+			}
+
+			published = append(published, event.Type)
+			return nil
+		})
+		asserts.NoError(err)
+
+		return published
+	}
+
+	// The later events of the user aren't published once one of them fails.
+	asserts.Empty(publishUserEvents(true))
+
+	asserts.Equal(
+		[]models.EventType{models.EventUserRegistered, models.EventUserUpdated},
+		publishUserEvents(false),
+	)
+
+	// Published events aren't published again.
+	asserts.Empty(publishUserEvents(false))
+
+	err = userRepo.UpdateAccountStatus(ctx, &pb.AccountStatusChange{
+		UserId: u.ID,
+		From:   u.AccountStatus,
+		To:     pb.User_SUSPENDED,
+		Reason: "fraud review",
+	})
+	asserts.NoError(err)
+
+	asserts.Equal([]models.EventType{models.EventAccountStatusChanged}, publishUserEvents(false))
+}
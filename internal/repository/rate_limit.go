@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/tracing"
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type RateLimit interface {
+	// Hit counts a call in the window of key starting at windowStart, which is kept until expiresAt, and returns the
+	// number of calls counted in that window and in the one starting at previousStart.
+	Hit(ctx context.Context, key string, windowStart, previousStart, expiresAt time.Time) (int64, int64, error)
+	// DeleteExpired deletes the windows which expired before now, returning how many were deleted.
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+type rateLimitRepo struct {
+	db *sqlx.DB
+	l  zerolog.Logger
+}
+
+const (
+	_rateLimitHit = `
+	WITH current AS (
+		INSERT INTO rate_limits (key, window_start, count, expires_at)
+		VALUES ($1, $2, 1, $4)
+		ON CONFLICT (key, window_start) DO UPDATE SET count = rate_limits.count + 1
+		RETURNING count
+	)
+	SELECT current.count,
+		COALESCE((SELECT count FROM rate_limits WHERE key = $1 AND window_start = $3), 0)
+	FROM current`
+
+	_rateLimitDeleteExpired = `DELETE FROM rate_limits WHERE expires_at < $1`
+)
+
+func (r *rateLimitRepo) Hit(
+	ctx context.Context,
+	key string,
+	windowStart, previousStart, expiresAt time.Time,
+) (_ int64, _ int64, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "rate_limits", "Hit", _rateLimitHit)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "hit").
+		Str("key", key).
+		Time("window_start", windowStart).
+		Str("query", _rateLimitHit).
+		Logger()
+
+	var current, previous int64
+	err = r.db.QueryRowxContext(ctx, _rateLimitHit, key, windowStart, previousStart, expiresAt).
+		Scan(&current, &previous)
+	if err != nil {
+		l.Err(err).Msg("exec and scan result")
+		return 0, 0, err
+	}
+
+	l.Info().Int64("current", current).Int64("previous", previous).Msg("completed successfully")
+	return current, previous, nil
+}
+
+func (r *rateLimitRepo) DeleteExpired(ctx context.Context, now time.Time) (_ int, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "rate_limits", "DeleteExpired", _rateLimitDeleteExpired)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "delete expired").
+		Time("now", now).
+		Str("query", _rateLimitDeleteExpired).
+		Logger()
+
+	res, err := r.db.ExecContext(ctx, _rateLimitDeleteExpired, now)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return 0, err
+	}
+
+	l.Info().Int64("deleted", rows).Msg("completed successfully")
+	return int(rows), nil
+}
+
+func NewTestRateLimitRepo(db *sqlx.DB) RateLimit {
+	return NewRateLimitRepo(db, logger.TestLogger)
+}
+
+func NewRateLimitRepo(db *sqlx.DB, l zerolog.Logger) RateLimit {
+	return &rateLimitRepo{
+		db: db,
+		l:  l.With().Str("repo", "rate_limit_sqlx").Logger(),
+	}
+}
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"bridge/internal/repository"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRateLimitRepo(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts       = assert.New(t)
+		ctx           = context.Background()
+		repo          = repository.NewTestRateLimitRepo(testDB)
+		key           = "/api.v1.AuthService/Login|ip:" + uuid.NewString()
+		windowStart   = time.Now().UTC().Truncate(time.Minute)
+		previousStart = windowStart.Add(-time.Minute)
+	)
+
+	// A call at the end of the previous window.
+	current, previous, err := repo.Hit(
+		ctx,
+		key,
+		previousStart,
+		previousStart.Add(-time.Minute),
+		windowStart.Add(time.Minute),
+	)
+	asserts.NoError(err)
+	asserts.EqualValues(1, current)
+	asserts.Zero(previous)
+
+	for i := int64(1); i <= 3; i++ {
+		current, previous, err = repo.Hit(ctx, key, windowStart, previousStart, windowStart.Add(2*time.Minute))
+		asserts.NoError(err)
+		asserts.Equal(i, current)
+		asserts.EqualValues(1, previous)
+	}
+
+	// Only the windows which expired are deleted.
+	deleted, err := repo.DeleteExpired(ctx, windowStart.Add(90*time.Second))
+	asserts.NoError(err)
+	asserts.GreaterOrEqual(deleted, 1)
+
+	current, previous, err = repo.Hit(ctx, key, windowStart, previousStart, windowStart.Add(2*time.Minute))
+	asserts.NoError(err)
+	asserts.EqualValues(4, current)
+	asserts.Zero(previous)
+}
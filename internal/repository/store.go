@@ -1,7 +1,16 @@
 package repository
 
 type Store struct {
-	UserRepo User
+	AuditRepo       Audit
+	CategoryRepo    Category
+	IdempotencyRepo Idempotency
+	JobRepo         Job
+	KYCRepo         KYC
+	LoginCodeRepo   LoginCode
+	OutboxRepo      Outbox
+	RateLimitRepo   RateLimit
+	UserRepo        User
+	WebhookRepo     Webhook
 }
 
 //type scanner interface {
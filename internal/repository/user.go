@@ -3,54 +3,148 @@ package repository
 import (
 	"bridge/api/v1/pb"
 	"bridge/internal/db"
+	"bridge/internal/encryption"
 	"bridge/internal/logger"
 	"bridge/internal/models"
 	"bridge/internal/rpc_error"
+	"bridge/internal/tracing"
 	"context"
 	"database/sql"
-	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
 	"time"
 )
 
+// User is the repository of the users. The writes taking audit events append them to the audit trail in the same
+// transaction as the change, see audit.Record.
 type User interface {
 	Authenticate(ctx context.Context, email string) (*pb.User, error)
-	Create(ctx context.Context, user *pb.User) error
+	Create(ctx context.Context, user *pb.User, events ...*pb.AuditEvent) error
 	Exists(ctx context.Context, user *pb.User) error
 	FindByEmail(ctx context.Context, email string) (*pb.User, error)
 	FindByID(ctx context.Context, id string) (*pb.User, error)
+	FindByIDNumber(ctx context.Context, idNumber string) (*pb.User, error)
 	FindByPhoneNumber(ctx context.Context, phoneNumber string) (*pb.User, error)
-	Update(ctx context.Context, user *pb.User) error
+	// Invalidate removes the users from the cache, if any, after they were changed outside the repository.
+	Invalidate(ctx context.Context, ids ...string) error
+	ListAccountStatusHistory(ctx context.Context, userID string) ([]*pb.AccountStatusChange, error)
+	Reencrypt(ctx context.Context, latestVersion, limit int) (int, error)
+	// Search returns the users matching search from the best match, along with their score.
+	Search(ctx context.Context, search models.UserSearch) ([]*pb.UserSearchHit, error)
+	Update(ctx context.Context, user *pb.User, events ...*pb.AuditEvent) error
+	UpdateAccountStatus(ctx context.Context, change *pb.AccountStatusChange, events ...*pb.AuditEvent) error
+	// UpdateRole sets the role of the user. The roles can't be changed through the API.
+	UpdateRole(ctx context.Context, id string, role pb.User_Role, events ...*pb.AuditEvent) error
+	// VerifyContact marks the email or phone number of the user as verified, provided it's still contact.
+	VerifyContact(ctx context.Context, id string, channel models.LoginChannel, contact string) error
 }
 
 type userRepo struct {
-	db *sqlx.DB
-	l  zerolog.Logger
+	cipher  encryption.Cipher
+	cluster db.Cluster
+	l       zerolog.Logger
 }
 
 const (
-	_userBaseSelect        = `SELECT id, name, email, phone_number, account_status, meta, created_at, updated_at FROM users `
+	_userBaseSelect = `
+	SELECT id, name, email, phone_number, account_status, meta, created_at, updated_at, role, status_reason,
+		status_expires_at, email_verified, phone_number_verified
+	FROM users `
 	_userFindByID          = _userBaseSelect + `WHERE id = $1 AND deleted_at IS NULL`
 	_userFindByEmail       = _userBaseSelect + `WHERE email = $1 AND deleted_at IS NULL`
 	_userFindByPhoneNumber = _userBaseSelect + `WHERE phone_number = $1 AND deleted_at IS NULL`
+	_userFindByIDNumber    = _userBaseSelect + `WHERE id_number_index = $1 AND deleted_at IS NULL`
 
 	_userAuthenticateByEmail = `SELECT id, email, password FROM users WHERE email = $1 AND deleted_at IS NULL`
 
 	_userCreate = `
-	INSERT INTO users (name, email, phone_number, password, account_status, meta, created_at, updated_at)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+	INSERT INTO users (name, email, phone_number, password, account_status, meta, created_at, updated_at, role,
+		id_number_index, encryption_key_version, email_verified, phone_number_verified)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
 
+	// _userUpdate leaves out the meta, which holds the KYC data written by KYC.UpdateStatus once a submission is
+	// approved, and re-encrypted by Reencrypt.
 	_userUpdate = `
 	UPDATE users
-	SET name           = $1,
-		email          = $2,
-		phone_number   = $3,
-		meta           = $4,
-		account_status = $5,
-		updated_at     = $6
-	WHERE id = $7`
+	SET name                  = $1,
+		email                 = $2,
+		phone_number          = $3,
+		account_status        = $4,
+		updated_at            = $5,
+		email_verified        = $6,
+		phone_number_verified = $7
+	WHERE id = $8`
+
+	_userListStaleEncryption = `
+	SELECT id, meta
+	FROM users
+	WHERE encryption_key_version < $1 AND jsonb_typeof(meta -> 'kyc_data') = 'object'
+	ORDER BY id
+	LIMIT $2 FOR UPDATE SKIP LOCKED`
+
+	_userUpdateEncryption = `
+	UPDATE users
+	SET meta                   = $1,
+		id_number_index        = $2,
+		encryption_key_version = $3
+	WHERE id = $4`
+
+	_userUpdateAccountStatus = `
+	UPDATE users
+	SET account_status    = $1,
+		status_reason     = $2,
+		status_expires_at = $3,
+		updated_at        = $4
+	WHERE id = $5 AND account_status = $6 AND deleted_at IS NULL`
+
+	_userUpdateRole = `
+	UPDATE users
+	SET role       = $1,
+		updated_at = $2
+	WHERE id = $3 AND deleted_at IS NULL`
+
+	_userVerifyEmail = `
+	UPDATE users
+	SET email_verified = true,
+		updated_at     = $1
+	WHERE id = $2 AND email = $3 AND deleted_at IS NULL`
+
+	_userVerifyPhoneNumber = `
+	UPDATE users
+	SET phone_number_verified = true,
+		updated_at            = $1
+	WHERE id = $2 AND phone_number = $3 AND deleted_at IS NULL`
+
+	_userStatusHistoryCreate = `
+	INSERT INTO user_status_history (user_id, from_status, to_status, reason, actor_id, expires_at, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	// _userSearch ranks the users matching the words of $1 higher, then the ones whose name, email or phone number
+	// contains $1, either as the substring pattern $2 or by trigram similarity. The email's punctuation is ignored in
+	// the words like in users.search_vector.
+	_userSearch = `
+	WITH search AS (SELECT websearch_to_tsquery('simple', regexp_replace($1, '[@._+-]', ' ', 'g')) AS query)
+	SELECT id, name, email, phone_number, account_status, meta, created_at, updated_at, role, status_reason,
+		status_expires_at, email_verified, phone_number_verified,
+		ts_rank(users.search_vector, search.query) +
+			greatest(word_similarity($1, name), word_similarity($1, email), word_similarity($1, phone_number)) AS score
+	FROM users, search
+	WHERE deleted_at IS NULL
+		AND (
+			users.search_vector @@ search.query
+			OR name %> $1 OR email %> $1
+			OR name ILIKE $2 OR email ILIKE $2 OR phone_number ILIKE $2
+		)
+	ORDER BY score DESC, created_at DESC, id
+	LIMIT $3 OFFSET $4`
+
+	_userStatusHistoryList = `
+	SELECT id, user_id, from_status, to_status, reason, actor_id, expires_at, created_at
+	FROM user_status_history
+	WHERE user_id = $1
+	ORDER BY created_at DESC`
 )
 
 var userRepoExistsQueries = map[db.UserTblColumn]string{
@@ -58,13 +152,15 @@ var userRepoExistsQueries = map[db.UserTblColumn]string{
 	db.UserPhoneNumber: `SELECT exists( SELECT 1 FROM users WHERE phone_number = $1)`,
 }
 
-func (r *userRepo) scanRow(row *sql.Row) (*pb.User, error) {
-	l := r.l.With().Str("action", "scan row").Logger()
+func (r *userRepo) scanRow(ctx context.Context, row interface{ Scan(dest ...any) error }) (*pb.User, error) {
+	l := logger.FromContext(ctx, r.l).With().Str("action", "scan row").Logger()
 
 	var (
 		u                    = &pb.User{}
 		meta                 = &models.UserMeta{}
 		createdAt, updatedAt time.Time
+		statusReason         sql.NullString
+		statusExpiresAt      sql.NullTime
 	)
 
 	err := row.Scan(
@@ -76,6 +172,11 @@ func (r *userRepo) scanRow(row *sql.Row) (*pb.User, error) {
 		&meta,
 		&createdAt,
 		&updatedAt,
+		&u.Role,
+		&statusReason,
+		&statusExpiresAt,
+		&u.EmailVerified,
+		&u.PhoneNumberVerified,
 	)
 	if err != nil {
 		l.Err(err).Msg("scan row")
@@ -85,25 +186,40 @@ func (r *userRepo) scanRow(row *sql.Row) (*pb.User, error) {
 	u.CreatedAt = timestamppb.New(createdAt)
 	u.UpdatedAt = timestamppb.New(updatedAt)
 	u.Meta = meta.UserMeta
+	u.StatusReason = statusReason.String
+
+	if u.Meta != nil && u.Meta.KycData != nil {
+		if err = decryptKYC(ctx, r.cipher, &u.Meta.KycData.IdNumber, &u.Meta.KycData.KraPin); err != nil {
+			l.Err(err).Msg("decrypt kyc data")
+			return nil, err
+		}
+	}
+
+	if statusExpiresAt.Valid {
+		u.StatusExpiresAt = timestamppb.New(statusExpiresAt.Time)
+	}
 
 	l.Info().Str("user_id", u.ID)
 	return u, nil
 }
 
-func (r *userRepo) Authenticate(ctx context.Context, email string) (*pb.User, error) {
-	l := r.l.With().Str("action", "authenticate").
-		Str("email", email).
+func (r *userRepo) Authenticate(ctx context.Context, email string) (_ *pb.User, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "Authenticate", _userAuthenticateByEmail)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "authenticate").
+		Str("email", logger.Mask(pb.Redaction_REDACTION_PARTIAL, email)).
 		Str("query", _userAuthenticateByEmail).
 		Logger()
 
-	stmt, err := r.db.PrepareContext(ctx, _userAuthenticateByEmail)
-	if err != nil {
-		l.Err(err).Msg("prepare statement")
-		return nil, err
-	}
-
 	user := &pb.User{}
-	if err = stmt.QueryRowContext(ctx, email).Scan(&user.ID, &user.Email, &user.Password); err != nil {
+	err = r.cluster.Primary().
+		QueryRowContext(ctx, _userAuthenticateByEmail, email).
+		Scan(&user.ID, &user.Email, &user.Password)
+	if err != nil {
 		l.Err(err).Msg("scan row")
 		return nil, err
 	}
@@ -112,23 +228,42 @@ func (r *userRepo) Authenticate(ctx context.Context, email string) (*pb.User, er
 	return user, nil
 }
 
-func (r *userRepo) Create(ctx context.Context, user *pb.User) error {
-	l := r.l.With().Str("action", "create").
-		Interface("user", fmt.Sprintf("%+v", user)).
+// Create stores the user and writes the user.registered event to the outbox and the audit events, which target the
+// user unless they have a target, in the same transaction.
+func (r *userRepo) Create(ctx context.Context, user *pb.User, events ...*pb.AuditEvent) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "Create", _userCreate, _outboxCreate, _auditCreate)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "create").
+		Str("email", logger.Mask(pb.Redaction_REDACTION_PARTIAL, user.Email)).
 		Str("query", _userCreate).
 		Logger()
 
-	stmt, err := r.db.PreparexContext(ctx, _userCreate)
+	encryptedMeta, kyc, err := encryptUserMeta(ctx, r.cipher, user.Meta)
 	if err != nil {
-		l.Err(err).Msg("prepare statement")
+		l.Err(err).Msg("encrypt meta")
 		return err
 	}
 
+	tx, err := r.cluster.Writer(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
 	var id string
-	meta := &models.UserMeta{UserMeta: user.Meta}
+	meta := &models.UserMeta{UserMeta: encryptedMeta}
 
-	err = stmt.QueryRowxContext(
+	err = tx.QueryRowxContext(
 		ctx,
+		_userCreate,
 		user.Name,
 		user.Email,
 		user.PhoneNumber,
@@ -137,6 +272,11 @@ func (r *userRepo) Create(ctx context.Context, user *pb.User) error {
 		meta,
 		user.CreatedAt.AsTime(),
 		user.UpdatedAt.AsTime(),
+		user.Role,
+		kyc.idNumberIndex,
+		kyc.keyVersion,
+		user.EmailVerified,
+		user.PhoneNumberVerified,
 	).Scan(&id)
 
 	if err != nil {
@@ -145,11 +285,57 @@ func (r *userRepo) Create(ctx context.Context, user *pb.User) error {
 	}
 
 	user.ID = id
+
+	if err = r.createUserEvent(ctx, tx, models.EventUserRegistered, user); err != nil {
+		l.Err(err).Msg("create outbox event")
+		user.ID = ""
+		return err
+	}
+
+	setAuditTargetID(id, events...)
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		user.ID = ""
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		user.ID = ""
+		return err
+	}
+
+	l.Info().Str("id", id).Msg("completed successfully")
 	return nil
 }
 
-func (r *userRepo) Exists(ctx context.Context, user *pb.User) error {
-	l := r.l.With().Str("action", "exists").Interface("user", fmt.Sprintf("%+v", user)).Logger()
+// createUserEvent writes a user event of eventType to the outbox using tx.
+func (r *userRepo) createUserEvent(ctx context.Context, tx *sqlx.Tx, eventType models.EventType, user *pb.User) error {
+	event, err := models.NewUserEvent(eventType, user)
+	if err != nil {
+		return err
+	}
+
+	return createOutboxEvents(ctx, tx, event)
+}
+
+func (r *userRepo) Exists(ctx context.Context, user *pb.User) (err error) {
+	ctx, span := tracing.StartDBSpan(
+		ctx,
+		"users",
+		"Exists",
+		userRepoExistsQueries[db.UserEmail],
+		userRepoExistsQueries[db.UserPhoneNumber],
+	)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "exists").
+		Str("email", logger.Mask(pb.Redaction_REDACTION_PARTIAL, user.Email)).
+		Logger()
 
 	checks := map[db.UserTblColumn]struct {
 		field string
@@ -168,13 +354,7 @@ func (r *userRepo) Exists(ctx context.Context, user *pb.User) error {
 
 		l = l.With().Str("query", q).Logger()
 
-		stmt, err := r.db.PreparexContext(ctx, q)
-		if err != nil {
-			l.Err(err).Msg("prepare statement")
-			return rpc_error.ErrServerError
-		}
-
-		if err = stmt.QueryRowxContext(ctx, st.field).Scan(&exists); err != nil {
+		if err = r.cluster.Primary().QueryRowxContext(ctx, q, st.field).Scan(&exists); err != nil {
 			l.Err(err).Msg("scan row")
 			return rpc_error.ErrServerError
 		}
@@ -189,19 +369,23 @@ func (r *userRepo) Exists(ctx context.Context, user *pb.User) error {
 	return nil
 }
 
-func (r *userRepo) FindByID(ctx context.Context, id string) (*pb.User, error) {
-	l := r.l.With().Str("action", "find by id").
+func (r *userRepo) FindByID(ctx context.Context, id string) (_ *pb.User, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "FindByID", _userFindByID)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find by id").
 		Str("id", id).
 		Str("query", _userFindByID).
 		Logger()
 
-	stmt, err := r.db.PrepareContext(ctx, _userFindByID)
-	if err != nil {
-		l.Err(err).Msg("prepare statement")
-		return nil, err
-	}
-
-	u, err := r.scanRow(stmt.QueryRowContext(ctx, id))
+	var u *pb.User
+	err = r.cluster.Read(ctx, func(q sqlx.QueryerContext) (err error) {
+		u, err = r.scanRow(ctx, q.QueryRowxContext(ctx, _userFindByID, id))
+		return err
+	})
 	if err != nil {
 		l.Err(err).Msg("scan row")
 		return nil, err
@@ -211,19 +395,23 @@ func (r *userRepo) FindByID(ctx context.Context, id string) (*pb.User, error) {
 	return u, nil
 }
 
-func (r *userRepo) FindByEmail(ctx context.Context, email string) (*pb.User, error) {
-	l := r.l.With().Str("action", "find by email").
-		Str("email", email).
+func (r *userRepo) FindByEmail(ctx context.Context, email string) (_ *pb.User, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "FindByEmail", _userFindByEmail)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find by email").
+		Str("email", logger.Mask(pb.Redaction_REDACTION_PARTIAL, email)).
 		Str("query", _userFindByEmail).
 		Logger()
 
-	stmt, err := r.db.PrepareContext(ctx, _userFindByEmail)
-	if err != nil {
-		l.Err(err).Msg("prepare statement")
-		return nil, err
-	}
-
-	u, err := r.scanRow(stmt.QueryRowContext(ctx, email))
+	var u *pb.User
+	err = r.cluster.Read(ctx, func(q sqlx.QueryerContext) (err error) {
+		u, err = r.scanRow(ctx, q.QueryRowxContext(ctx, _userFindByEmail, email))
+		return err
+	})
 	if err != nil {
 		l.Err(err).Msg("scan row")
 		return nil, err
@@ -233,19 +421,49 @@ func (r *userRepo) FindByEmail(ctx context.Context, email string) (*pb.User, err
 	return u, nil
 }
 
-func (r *userRepo) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*pb.User, error) {
-	l := r.l.With().Str("action", "find by phone number").
-		Str("phone_number", phoneNumber).
+func (r *userRepo) FindByPhoneNumber(ctx context.Context, phoneNumber string) (_ *pb.User, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "FindByPhoneNumber", _userFindByPhoneNumber)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find by phone number").
+		Str("phone_number", logger.Mask(pb.Redaction_REDACTION_PARTIAL, phoneNumber)).
 		Str("query", _userFindByPhoneNumber).
 		Logger()
 
-	stmt, err := r.db.PrepareContext(ctx, _userFindByPhoneNumber)
+	var u *pb.User
+	err = r.cluster.Read(ctx, func(q sqlx.QueryerContext) (err error) {
+		u, err = r.scanRow(ctx, q.QueryRowxContext(ctx, _userFindByPhoneNumber, phoneNumber))
+		return err
+	})
 	if err != nil {
-		l.Err(err).Msg("prepare statement")
+		l.Err(err).Msg("scan row")
 		return nil, err
 	}
 
-	u, err := r.scanRow(stmt.QueryRowContext(ctx, phoneNumber))
+	l.Info().Str("id", u.ID).Msg("completed successfully")
+	return u, nil
+}
+
+// FindByIDNumber looks up the user using the blind index of the KYC ID number since the ID number is encrypted.
+func (r *userRepo) FindByIDNumber(ctx context.Context, idNumber string) (_ *pb.User, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "FindByIDNumber", _userFindByIDNumber)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find by id number").
+		Str("query", _userFindByIDNumber).
+		Logger()
+
+	var u *pb.User
+	err = r.cluster.Read(ctx, func(q sqlx.QueryerContext) (err error) {
+		u, err = r.scanRow(ctx, q.QueryRowxContext(ctx, _userFindByIDNumber, r.cipher.BlindIndex(idNumber)))
+		return err
+	})
 	if err != nil {
 		l.Err(err).Msg("scan row")
 		return nil, err
@@ -255,46 +473,537 @@ func (r *userRepo) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*
 	return u, nil
 }
 
-func (r *userRepo) Update(ctx context.Context, user *pb.User) error {
-	l := r.l.With().Str("action", "user").
-		Interface("user", fmt.Sprintf("%+v", user)).
+// Reencrypt re-encrypts the KYC data of users encrypted with a key version older than latestVersion.
+func (r *userRepo) Reencrypt(ctx context.Context, latestVersion, limit int) (_ int, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "Reencrypt", _userListStaleEncryption, _userUpdateEncryption)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "reencrypt").
+		Int("latest_version", latestVersion).
+		Logger()
+
+	tx, err := r.cluster.Writer(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return 0, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.QueryxContext(ctx, _userListStaleEncryption, latestVersion, limit)
+	if err != nil {
+		l.Err(err).Str("query", _userListStaleEncryption).Msg("query rows")
+		return 0, err
+	}
+
+	type staleUser struct {
+		id   string
+		meta *models.UserMeta
+	}
+
+	var users []staleUser
+	for rows.Next() {
+		u := staleUser{meta: &models.UserMeta{}}
+		if err = rows.Scan(&u.id, u.meta); err != nil {
+			_ = rows.Close()
+			l.Err(err).Msg("scan row")
+			return 0, err
+		}
+
+		users = append(users, u)
+	}
+
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		l.Err(err).Msg("iterate rows")
+		return 0, err
+	}
+
+	for _, u := range users {
+		kycData := u.meta.KycData
+		if err = decryptKYC(ctx, r.cipher, &kycData.IdNumber, &kycData.KraPin); err != nil {
+			l.Err(err).Str("id", u.id).Msg("decrypt kyc data")
+			return 0, err
+		}
+
+		encryptedMeta, kyc, err := encryptUserMeta(ctx, r.cipher, u.meta.UserMeta)
+		if err != nil {
+			l.Err(err).Str("id", u.id).Msg("encrypt meta")
+			return 0, err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			_userUpdateEncryption,
+			&models.UserMeta{UserMeta: encryptedMeta},
+			kyc.idNumberIndex,
+			latestVersion,
+			u.id,
+		)
+		if err != nil {
+			l.Err(err).Str("id", u.id).Str("query", _userUpdateEncryption).Msg("exec query")
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return 0, err
+	}
+
+	l.Info().Int("count", len(users)).Msg("completed successfully")
+	return len(users), nil
+}
+
+// Update stores the changes to the user, except for its meta, and writes the user.updated event to the outbox and the
+// audit events in the same transaction.
+func (r *userRepo) Update(ctx context.Context, user *pb.User, events ...*pb.AuditEvent) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "Update", _userUpdate, _outboxCreate, _auditCreate)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "user").
+		Str("id", user.ID).
 		Str("query", _userUpdate).
 		Logger()
 
-	stmt, err := r.db.PrepareContext(ctx, _userUpdate)
+	tx, err := r.cluster.Writer(ctx).BeginTxx(ctx, nil)
 	if err != nil {
-		l.Err(err).Msg("prepare statement")
+		l.Err(err).Msg("begin transaction")
 		return err
 	}
 
-	var (
-		meta = &models.UserMeta{
-			UserMeta: user.Meta,
-		}
-	)
+	defer func() {
+		_ = tx.Rollback()
+	}()
 
 	user.UpdatedAt = timestamppb.New(time.Now())
 
-	if _, err = stmt.ExecContext(
+	if _, err = tx.ExecContext(
 		ctx,
+		_userUpdate,
 		user.Name,
 		user.Email,
 		user.PhoneNumber,
-		meta,
 		user.AccountStatus,
 		user.UpdatedAt.AsTime(),
+		user.EmailVerified,
+		user.PhoneNumberVerified,
 		user.ID,
 	); err != nil {
 		l.Err(err).Msg("exec query")
 		return err
 	}
 
+	if err = r.createUserEvent(ctx, tx, models.EventUserUpdated, user); err != nil {
+		l.Err(err).Msg("create outbox event")
+		return err
+	}
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
 	l.Info().Str("id", user.ID).Msg("completed successfully")
 	return nil
 }
 
-func NewTestUserRepo(ctx context.Context, db *sqlx.DB, users ...*pb.User) (User, error) {
-	repo := NewUserRepo(db, logger.TestLogger)
+func (r *userRepo) ListAccountStatusHistory(
+	ctx context.Context,
+	userID string,
+) (_ []*pb.AccountStatusChange, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "ListAccountStatusHistory", _userStatusHistoryList)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "list account status history").
+		Str("user_id", userID).
+		Str("query", _userStatusHistoryList).
+		Logger()
+
+	var changes []*pb.AccountStatusChange
+	err = r.cluster.Read(ctx, func(q sqlx.QueryerContext) (err error) {
+		changes, err = listAccountStatusHistory(ctx, l, q, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l.Info().Int("count", len(changes)).Msg("completed successfully")
+	return changes, nil
+}
+
+func (r *userRepo) Search(ctx context.Context, search models.UserSearch) (_ []*pb.UserSearchHit, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "Search", _userSearch)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "search").
+		Str("search_query", logger.Mask(pb.Redaction_REDACTION_PARTIAL, search.Query)).
+		Int("limit", search.Limit).
+		Int("offset", search.Offset).
+		Str("query", _userSearch).
+		Logger()
+
+	var hits []*pb.UserSearchHit
+	err = r.cluster.Read(ctx, func(q sqlx.QueryerContext) error {
+		rows, err := q.QueryxContext(
+			ctx,
+			_userSearch,
+			search.Query,
+			"%"+escapeLike(search.Query)+"%",
+			search.Limit,
+			search.Offset,
+		)
+		if err != nil {
+			l.Err(err).Msg("query rows")
+			return err
+		}
+
+		defer func() {
+			_ = rows.Close()
+		}()
+
+		// The hits are reset in case the read is retried on the primary.
+		hits = nil
+		for rows.Next() {
+			hit := &pb.UserSearchHit{}
+			if hit.User, err = r.scanRow(ctx, scoredRow{row: rows, score: &hit.Score}); err != nil {
+				return err
+			}
+
+			hits = append(hits, hit)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		l.Err(err).Msg("search users")
+		return nil, err
+	}
+
+	l.Info().Int("count", len(hits)).Msg("completed successfully")
+	return hits, nil
+}
+
+// scoredRow scans the score selected after the user's columns.
+type scoredRow struct {
+	row   interface{ Scan(dest ...any) error }
+	score *float64
+}
+
+func (r scoredRow) Scan(dest ...any) error {
+	return r.row.Scan(append(dest, r.score)...)
+}
+
+// escapeLike escapes the wildcards of s so that it's matched literally by LIKE.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// listAccountStatusHistory lists the account status history of the user using q, a primary or a replica.
+func listAccountStatusHistory(
+	ctx context.Context,
+	l zerolog.Logger,
+	q sqlx.QueryerContext,
+	userID string,
+) ([]*pb.AccountStatusChange, error) {
+	rows, err := q.QueryxContext(ctx, _userStatusHistoryList, userID)
+	if err != nil {
+		l.Err(err).Msg("query rows")
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var changes []*pb.AccountStatusChange
+	for rows.Next() {
+		var (
+			change    = &pb.AccountStatusChange{}
+			actorID   sql.NullString
+			expiresAt sql.NullTime
+			createdAt time.Time
+		)
+
+		err = rows.Scan(
+			&change.ID,
+			&change.UserId,
+			&change.From,
+			&change.To,
+			&change.Reason,
+			&actorID,
+			&expiresAt,
+			&createdAt,
+		)
+		if err != nil {
+			l.Err(err).Msg("scan row")
+			return nil, err
+		}
+
+		change.ActorId = actorID.String
+		change.CreatedAt = timestamppb.New(createdAt)
+
+		if expiresAt.Valid {
+			change.ExpiresAt = timestamppb.New(expiresAt.Time)
+		}
+
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Err(err).Msg("iterate rows")
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// UpdateAccountStatus moves the user from change.From to change.To and records the change in the status history.
+// ErrNotFound is returned if the user does not exist or its status is no longer change.From. The
+// user.account_status_changed event is written to the outbox and the audit events to the audit trail in the same
+// transaction.
+func (r *userRepo) UpdateAccountStatus(
+	ctx context.Context,
+	change *pb.AccountStatusChange,
+	events ...*pb.AuditEvent,
+) (err error) {
+	ctx, span := tracing.StartDBSpan(
+		ctx,
+		"users",
+		"UpdateAccountStatus",
+		_userUpdateAccountStatus,
+		_userStatusHistoryCreate,
+		_outboxCreate,
+		_auditCreate,
+	)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "update account status").
+		Str("user_id", change.UserId).
+		Stringer("from", change.From).
+		Stringer("to", change.To).
+		Logger()
+
+	tx, err := r.cluster.Writer(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		now       = time.Now()
+		actorID   = sql.NullString{String: change.ActorId, Valid: change.ActorId != ""}
+		expiresAt sql.NullTime
+	)
+
+	if change.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: change.ExpiresAt.AsTime(), Valid: true}
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		_userUpdateAccountStatus,
+		change.To,
+		change.Reason,
+		expiresAt,
+		now,
+		change.UserId,
+		change.From,
+	)
+	if err != nil {
+		l.Err(err).Str("query", _userUpdateAccountStatus).Msg("exec query")
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return err
+	}
+
+	if rows == 0 {
+		l.Err(sql.ErrNoRows).Msg("user not found or status changed")
+		return sql.ErrNoRows
+	}
+
+	var id string
+	err = tx.QueryRowxContext(
+		ctx,
+		_userStatusHistoryCreate,
+		change.UserId,
+		change.From,
+		change.To,
+		change.Reason,
+		actorID,
+		expiresAt,
+		now,
+	).Scan(&id)
+	if err != nil {
+		l.Err(err).Str("query", _userStatusHistoryCreate).Msg("exec and scan result")
+		return err
+	}
+
+	change.ID = id
+	change.CreatedAt = timestamppb.New(now)
+
+	event, err := models.NewAccountStatusChangedEvent(change)
+	if err != nil {
+		l.Err(err).Msg("create event")
+		return err
+	}
+
+	if err = createOutboxEvents(ctx, tx, event); err != nil {
+		l.Err(err).Str("query", _outboxCreate).Msg("create outbox event")
+		return err
+	}
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	l.Info().Str("id", id).Msg("completed successfully")
+	return nil
+}
+
+// UpdateRole sets the role of the user and writes the audit events in the same transaction.
+func (r *userRepo) UpdateRole(ctx context.Context, id string, role pb.User_Role, events ...*pb.AuditEvent) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "users", "UpdateRole", _userUpdateRole, _auditCreate)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "update role").
+		Str("id", id).
+		Stringer("role", role).
+		Str("query", _userUpdateRole).
+		Logger()
+
+	tx, err := r.cluster.Writer(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, _userUpdateRole, role, time.Now(), id)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return err
+	}
+
+	if rows == 0 {
+		l.Err(sql.ErrNoRows).Msg("user not found")
+		return sql.ErrNoRows
+	}
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func (r *userRepo) VerifyContact(
+	ctx context.Context,
+	id string,
+	channel models.LoginChannel,
+	contact string,
+) (err error) {
+	q := _userVerifyPhoneNumber
+	if channel == models.LoginChannelEmail {
+		q = _userVerifyEmail
+	}
+
+	ctx, span := tracing.StartDBSpan(ctx, "users", "VerifyContact", q)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "verify contact").
+		Str("id", id).
+		Str("channel", string(channel)).
+		Str("query", q).
+		Logger()
+
+	res, err := r.cluster.Writer(ctx).ExecContext(ctx, q, time.Now(), id, contact)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return err
+	}
+
+	if rows == 0 {
+		l.Err(sql.ErrNoRows).Msg("user not found or contact changed")
+		return sql.ErrNoRows
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+// Invalidate does nothing since the users aren't cached. See NewCachedUserRepo.
+func (r *userRepo) Invalidate(_ context.Context, _ ...string) error {
+	return nil
+}
+
+func NewTestUserRepo(ctx context.Context, conn *sqlx.DB, users ...*pb.User) (User, error) {
+	cluster := db.NewCluster(logger.TestLogger, conn, nil, db.DefaultHealthCheckInterval)
+	repo := NewUserRepo(cluster, logger.TestLogger, encryption.TestCipher)
 
 	for _, user := range users {
 		if err := repo.Create(ctx, user); err != nil {
@@ -305,9 +1014,12 @@ func NewTestUserRepo(ctx context.Context, db *sqlx.DB, users ...*pb.User) (User,
 	return repo, nil
 }
 
-func NewUserRepo(db *sqlx.DB, l zerolog.Logger) User {
+// NewUserRepo returns the user repository. The lookups by id, email, phone number and ID number and the account
+// status history are read from the replicas of cluster.
+func NewUserRepo(cluster db.Cluster, l zerolog.Logger, cipher encryption.Cipher) User {
 	return &userRepo{
-		db: db,
-		l:  l.With().Str("repo", "user_sqlx").Logger(),
+		cipher:  cipher,
+		cluster: cluster,
+		l:       l.With().Str("repo", "user_sqlx").Logger(),
 	}
 }
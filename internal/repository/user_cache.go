@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/cache"
+	"bridge/internal/db"
+	"bridge/internal/encryption"
+	"bridge/internal/logger"
+	"bridge/internal/metrics"
+	"bridge/internal/models"
+	"bridge/internal/utils"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// userCacheName labels the lookups of the user cache in the metrics.
+const userCacheName = "users"
+
+type cachedUserRepo struct {
+	User
+
+	backend cache.Backend
+	cipher  encryption.Cipher
+	group   singleflight.Group
+	l       zerolog.Logger
+	ttl     time.Duration
+}
+
+func userCacheKey(id string) string {
+	return "user:" + id
+}
+
+// FindByID returns the cached user, loading it from the repository on a miss. Concurrent misses for the same user
+// are loaded once, from the primary so that a replica lagging behind doesn't cache a stale user. Calls which must
+// read from the primary, such as after a write, bypass the cache.
+func (r *cachedUserRepo) FindByID(ctx context.Context, id string) (*pb.User, error) {
+	if db.ReadsPrimary(ctx) {
+		return r.User.FindByID(ctx, id)
+	}
+
+	var (
+		l   = logger.FromContext(ctx, r.l).With().Str("action", "find by id").Str("id", id).Logger()
+		key = userCacheKey(id)
+	)
+
+	user, err := r.get(ctx, key)
+	if err == nil {
+		metrics.ObserveCacheLookup(userCacheName, metrics.CacheHit)
+		return user, nil
+	}
+
+	if !errors.Is(err, cache.ErrMiss) {
+		l.Err(err).Msg("get cached user")
+	}
+	metrics.ObserveCacheLookup(userCacheName, metrics.CacheMiss)
+
+	ch := r.group.DoChan(key, func() (interface{}, error) {
+		// The load is shared by the callers which were de-duplicated, so it isn't canceled along with the first one.
+		loadCtx := db.WithPrimary(utils.WithoutCancel(ctx))
+
+		user, err := r.User.FindByID(loadCtx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = r.set(loadCtx, key, user); err != nil {
+			l.Err(err).Msg("cache user")
+		}
+		return user, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		// The user is shared by the callers which were de-duplicated, so each gets its own copy.
+		return proto.Clone(res.Val.(*pb.User)).(*pb.User), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// get returns the user cached with key. The users are encrypted since they hold the decrypted KYC data.
+func (r *cachedUserRepo) get(ctx context.Context, key string) (*pb.User, error) {
+	value, err := r.backend.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := r.cipher.Decrypt(ctx, string(value))
+	if err != nil {
+		return nil, err
+	}
+
+	user := &pb.User{}
+	if err = proto.Unmarshal([]byte(plaintext), user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *cachedUserRepo) set(ctx context.Context, key string, user *pb.User) error {
+	plaintext, err := proto.Marshal(user)
+	if err != nil {
+		return err
+	}
+
+	value, err := r.cipher.Encrypt(ctx, string(plaintext))
+	if err != nil {
+		return err
+	}
+	return r.backend.Set(ctx, key, []byte(value), r.ttl)
+}
+
+func (r *cachedUserRepo) Update(ctx context.Context, user *pb.User, events ...*pb.AuditEvent) error {
+	defer r.invalidate(ctx, user.ID)
+	return r.User.Update(ctx, user, events...)
+}
+
+func (r *cachedUserRepo) UpdateAccountStatus(
+	ctx context.Context,
+	change *pb.AccountStatusChange,
+	events ...*pb.AuditEvent,
+) error {
+	defer r.invalidate(ctx, change.UserId)
+	return r.User.UpdateAccountStatus(ctx, change, events...)
+}
+
+func (r *cachedUserRepo) UpdateRole(ctx context.Context, id string, role pb.User_Role, events ...*pb.AuditEvent) error {
+	defer r.invalidate(ctx, id)
+	return r.User.UpdateRole(ctx, id, role, events...)
+}
+
+func (r *cachedUserRepo) VerifyContact(
+	ctx context.Context,
+	id string,
+	channel models.LoginChannel,
+	contact string,
+) error {
+	defer r.invalidate(ctx, id)
+	return r.User.VerifyContact(ctx, id, channel, contact)
+}
+
+func (r *cachedUserRepo) Invalidate(ctx context.Context, ids ...string) error {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, userCacheKey(id))
+	}
+	return r.backend.Delete(ctx, keys...)
+}
+
+// invalidate removes the user from the cache once it changed, even if the change failed since it may have been
+// applied. The error is only logged so that it doesn't fail the change; the user expires after the TTL at the latest.
+func (r *cachedUserRepo) invalidate(ctx context.Context, id string) {
+	if err := r.Invalidate(ctx, id); err != nil {
+		l := logger.FromContext(ctx, r.l)
+		l.Err(err).Str("action", "invalidate").Str("id", id).Msg("invalidate cached user")
+	}
+}
+
+// NewCachedUserRepo returns a User caching the users found by id with next in backend for ttl. The cached users are
+// invalidated when they're updated through the returned repository or Invalidate.
+func NewCachedUserRepo(
+	next User,
+	backend cache.Backend,
+	cipher encryption.Cipher,
+	ttl time.Duration,
+	l zerolog.Logger,
+) User {
+	return &cachedUserRepo{
+		User:    next,
+		backend: backend,
+		cipher:  cipher,
+		l:       l.With().Str("repo", "user_cache").Logger(),
+		ttl:     ttl,
+	}
+}
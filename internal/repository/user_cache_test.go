@@ -0,0 +1,248 @@
+package repository_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/cache"
+	"bridge/internal/db"
+	"bridge/internal/encryption"
+	"bridge/internal/factory"
+	"bridge/internal/logger"
+	"bridge/internal/repository"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingUserRepo serves the users from memory and counts the lookups by id.
+type countingUserRepo struct {
+	repository.User
+
+	mu      sync.Mutex
+	users   map[string]*pb.User
+	lookups atomic.Int32
+	// primaryLookups counts the lookups routed to the primary.
+	primaryLookups atomic.Int32
+	// release, if set, blocks the lookups until it's closed.
+	release chan struct{}
+}
+
+func (r *countingUserRepo) FindByID(ctx context.Context, id string) (*pb.User, error) {
+	r.lookups.Add(1)
+	if db.ReadsPrimary(ctx) {
+		r.primaryLookups.Add(1)
+	}
+
+	if r.release != nil {
+		<-r.release
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return proto.Clone(user).(*pb.User), nil
+}
+
+func (r *countingUserRepo) Update(_ context.Context, user *pb.User, _ ...*pb.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[user.ID] = proto.Clone(user).(*pb.User)
+	return nil
+}
+
+func (r *countingUserRepo) UpdateAccountStatus(
+	_ context.Context,
+	change *pb.AccountStatusChange,
+	_ ...*pb.AuditEvent,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[change.UserId].AccountStatus = change.To
+	return nil
+}
+
+func newCachedUserRepo(t *testing.T, users ...*pb.User) (repository.User, *countingUserRepo) {
+	t.Helper()
+
+	next := &countingUserRepo{users: make(map[string]*pb.User)}
+	for i, user := range users {
+		user.ID = "user-" + string(rune('a'+i))
+		next.users[user.ID] = proto.Clone(user).(*pb.User)
+	}
+
+	backend := cache.NewMemoryBackend(cache.DefaultMemorySize)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	return repository.NewCachedUserRepo(next, backend, encryption.TestCipher, time.Minute, logger.TestLogger), next
+}
+
+func TestCachedUserRepo_FindByID(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		user    = factory.NewUser()
+	)
+
+	repo, next := newCachedUserRepo(t, user)
+
+	for i := 0; i < 3; i++ {
+		got, err := repo.FindByID(ctx, user.ID)
+		asserts.NoError(err)
+		asserts.Equal(user.Email, got.Email)
+	}
+	asserts.EqualValues(1, next.lookups.Load())
+
+	_, err := repo.FindByID(ctx, "missing")
+	asserts.ErrorIs(err, repository.ErrNotFound)
+
+	_, err = repo.FindByID(db.WithPrimary(ctx), user.ID)
+	asserts.NoError(err)
+	asserts.EqualValues(3, next.lookups.Load(), "reads from the primary must bypass the cache")
+}
+
+func TestCachedUserRepo_FindByIDSingleflight(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		user    = factory.NewUser()
+	)
+
+	repo, next := newCachedUserRepo(t, user)
+	next.release = make(chan struct{})
+
+	const callers = 10
+
+	var wg sync.WaitGroup
+	users := make(chan *pb.User, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			got, err := repo.FindByID(ctx, user.ID)
+			asserts.NoError(err)
+			users <- got
+		}()
+	}
+
+	// Lets the callers join the first lookup before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+	close(users)
+
+	asserts.EqualValues(1, next.lookups.Load())
+
+	seen := make(map[*pb.User]bool)
+	for got := range users {
+		asserts.Equal(user.ID, got.ID)
+		asserts.False(seen[got], "callers must not share the user")
+		seen[got] = true
+	}
+}
+
+func TestCachedUserRepo_FindByIDCancelled(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts     = assert.New(t)
+		ctx, cancel = context.WithCancel(context.Background())
+		user        = factory.NewUser()
+	)
+
+	repo, next := newCachedUserRepo(t, user)
+	next.release = make(chan struct{})
+
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := repo.FindByID(ctx, user.ID)
+		cancelled <- err
+	}()
+
+	// Lets the first caller start the lookup before the second one joins it.
+	time.Sleep(50 * time.Millisecond)
+
+	found := make(chan *pb.User, 1)
+	go func() {
+		got, err := repo.FindByID(context.Background(), user.ID)
+		asserts.NoError(err)
+		found <- got
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	asserts.ErrorIs(<-cancelled, context.Canceled)
+
+	// The lookup outlives the first caller, so the second one gets the user.
+	close(next.release)
+	if got := <-found; asserts.NotNil(got) {
+		asserts.Equal(user.ID, got.ID)
+	}
+
+	got, err := repo.FindByID(context.Background(), user.ID)
+	asserts.NoError(err)
+	asserts.Equal(user.ID, got.ID)
+
+	asserts.EqualValues(1, next.lookups.Load())
+	asserts.EqualValues(1, next.primaryLookups.Load(), "the cache must be filled from the primary")
+}
+
+func TestCachedUserRepo_Invalidation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		user    = factory.NewUser()
+	)
+
+	repo, next := newCachedUserRepo(t, user)
+
+	_, err := repo.FindByID(ctx, user.ID)
+	asserts.NoError(err)
+
+	user.Name = "Updated Name"
+	asserts.NoError(repo.Update(ctx, user))
+
+	got, err := repo.FindByID(ctx, user.ID)
+	asserts.NoError(err)
+	asserts.Equal("Updated Name", got.Name)
+
+	asserts.NoError(repo.UpdateAccountStatus(ctx, &pb.AccountStatusChange{
+		UserId: user.ID,
+		From:   user.AccountStatus,
+		To:     pb.User_SUSPENDED,
+	}))
+
+	got, err = repo.FindByID(ctx, user.ID)
+	asserts.NoError(err)
+	asserts.Equal(pb.User_SUSPENDED, got.AccountStatus)
+
+	next.mu.Lock()
+	next.users[user.ID].Name = "Changed Elsewhere"
+	next.mu.Unlock()
+
+	asserts.NoError(repo.Invalidate(ctx, user.ID))
+
+	got, err = repo.FindByID(ctx, user.ID)
+	asserts.NoError(err)
+	asserts.Equal("Changed Elsewhere", got.Name)
+	asserts.EqualValues(4, next.lookups.Load())
+}
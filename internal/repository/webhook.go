@@ -0,0 +1,773 @@
+package repository
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/encryption"
+	"bridge/internal/logger"
+	"bridge/internal/tracing"
+	"context"
+	"database/sql"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// Webhook is the repository of the webhook subscriptions and deliveries. The writes taking audit events append them to
+// the audit trail in the same transaction as the change, see audit.Record.
+type Webhook interface {
+	// CreateDelivery stores a pending delivery. If the event was already delivered to the subscription, the existing
+	// delivery is returned instead since events are relayed at least once.
+	CreateDelivery(ctx context.Context, delivery *pb.WebhookDelivery, events ...*pb.AuditEvent) error
+	CreateSubscription(ctx context.Context, subscription *pb.WebhookSubscription, events ...*pb.AuditEvent) error
+	DeleteSubscription(ctx context.Context, id string, events ...*pb.AuditEvent) error
+	FindDeliveryByID(ctx context.Context, id string) (*pb.WebhookDelivery, error)
+	// FindSubscriptionByID returns the subscription along with its secret.
+	FindSubscriptionByID(ctx context.Context, id string) (*pb.WebhookSubscription, error)
+	ListDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*pb.WebhookDelivery, error)
+	ListSubscriptions(ctx context.Context) ([]*pb.WebhookSubscription, error)
+	// ListSubscriptionsForEvent returns the active subscriptions to eventType.
+	ListSubscriptionsForEvent(ctx context.Context, eventType string) ([]*pb.WebhookSubscription, error)
+	// RecordDeliveryAttempt stores the result of an attempt to deliver delivery. The subscription's consecutive
+	// failures are reset once a delivery succeeds and incremented once a delivery fails for good, disabling the
+	// subscription when they reach disableAfter. It reports whether the subscription was disabled.
+	RecordDeliveryAttempt(ctx context.Context, delivery *pb.WebhookDelivery, disableAfter int) (bool, error)
+	UpdateSubscription(ctx context.Context, subscription *pb.WebhookSubscription, events ...*pb.AuditEvent) error
+}
+
+type webhookRepo struct {
+	cipher encryption.Cipher
+	db     *sqlx.DB
+	l      zerolog.Logger
+}
+
+const (
+	_webhookSubscriptionBaseSelect = `
+	SELECT id, url, event_types, secret, status, consecutive_failures, created_by, created_at, updated_at, disabled_at
+	FROM webhook_subscriptions `
+	_webhookSubscriptionFindByID  = _webhookSubscriptionBaseSelect + `WHERE id = $1`
+	_webhookSubscriptionList      = _webhookSubscriptionBaseSelect + `ORDER BY created_at`
+	_webhookSubscriptionsForEvent = _webhookSubscriptionBaseSelect + `WHERE status = $1 AND $2 = ANY(event_types)`
+
+	_webhookSubscriptionCreate = `
+	INSERT INTO webhook_subscriptions (url, event_types, secret, status, created_by, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	_webhookSubscriptionUpdate = `
+	UPDATE webhook_subscriptions
+	SET url                  = $1,
+		event_types          = $2,
+		status               = $3,
+		consecutive_failures = CASE WHEN status = $3 THEN consecutive_failures ELSE 0 END,
+		disabled_at          = $4,
+		updated_at           = $5
+	WHERE id = $6
+	RETURNING consecutive_failures`
+
+	_webhookSubscriptionDelete = `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	_webhookSubscriptionResetFailures = `
+	UPDATE webhook_subscriptions SET consecutive_failures = 0 WHERE id = $1`
+
+	_webhookSubscriptionRecordFailure = `
+	UPDATE webhook_subscriptions
+	SET consecutive_failures = consecutive_failures + 1,
+		status               = CASE WHEN consecutive_failures + 1 >= $1 THEN $2 ELSE status END,
+		disabled_at          = CASE WHEN consecutive_failures + 1 >= $1 THEN $3 ELSE disabled_at END,
+		updated_at           = $3
+	WHERE id = $4 AND status = $5
+	RETURNING status`
+
+	_webhookDeliveryBaseSelect = `
+	SELECT id, subscription_id, event_id, event_type, payload, status, attempts, response_status, last_error,
+		replay_of, delivered_at, created_at, updated_at
+	FROM webhook_deliveries `
+	_webhookDeliveryFindByID = _webhookDeliveryBaseSelect + `WHERE id = $1`
+	_webhookDeliveryList     = _webhookDeliveryBaseSelect + `WHERE subscription_id = $1 ORDER BY created_at DESC LIMIT $2`
+
+	// The no-op update makes the existing delivery returned on conflict.
+	_webhookDeliveryCreate = `
+	INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, replay_of, created_at,
+		updated_at)
+	VALUES ($1, $2, $3, $4::jsonb, $5, $6, $7, $8)
+	ON CONFLICT (subscription_id, event_id) WHERE replay_of IS NULL
+	DO UPDATE SET updated_at = webhook_deliveries.updated_at
+	RETURNING id, status, attempts, created_at`
+
+	_webhookDeliveryRecordAttempt = `
+	UPDATE webhook_deliveries
+	SET status          = $1,
+		attempts        = attempts + 1,
+		response_status = $2,
+		last_error      = $3,
+		delivered_at    = $4,
+		updated_at      = $5
+	WHERE id = $6
+	RETURNING attempts`
+)
+
+type webhookScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *webhookRepo) scanSubscription(
+	ctx context.Context,
+	row webhookScanner,
+	withSecret bool,
+) (*pb.WebhookSubscription, error) {
+	var (
+		subscription = &pb.WebhookSubscription{}
+		secret       string
+		createdBy    sql.NullString
+		createdAt    time.Time
+		updatedAt    time.Time
+		disabledAt   sql.NullTime
+	)
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.Url,
+		pgtype.NewMap().SQLScanner(&subscription.EventTypes),
+		&secret,
+		&subscription.Status,
+		&subscription.ConsecutiveFailures,
+		&createdBy,
+		&createdAt,
+		&updatedAt,
+		&disabledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if withSecret {
+		if subscription.Secret, err = r.cipher.Decrypt(ctx, secret); err != nil {
+			return nil, err
+		}
+	}
+
+	subscription.CreatedBy = createdBy.String
+	subscription.CreatedAt = timestamppb.New(createdAt)
+	subscription.UpdatedAt = timestamppb.New(updatedAt)
+
+	if disabledAt.Valid {
+		subscription.DisabledAt = timestamppb.New(disabledAt.Time)
+	}
+
+	return subscription, nil
+}
+
+func scanDelivery(row webhookScanner) (*pb.WebhookDelivery, error) {
+	var (
+		delivery       = &pb.WebhookDelivery{}
+		responseStatus sql.NullInt32
+		lastError      sql.NullString
+		replayOf       sql.NullString
+		deliveredAt    sql.NullTime
+		createdAt      time.Time
+		updatedAt      time.Time
+	)
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionId,
+		&delivery.EventId,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&responseStatus,
+		&lastError,
+		&replayOf,
+		&deliveredAt,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.ResponseStatus = responseStatus.Int32
+	delivery.LastError = lastError.String
+	delivery.ReplayOf = replayOf.String
+	delivery.CreatedAt = timestamppb.New(createdAt)
+	delivery.UpdatedAt = timestamppb.New(updatedAt)
+
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = timestamppb.New(deliveredAt.Time)
+	}
+
+	return delivery, nil
+}
+
+func (r *webhookRepo) listSubscriptions(
+	ctx context.Context,
+	l zerolog.Logger,
+	query string,
+	args ...any,
+) ([]*pb.WebhookSubscription, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		l.Err(err).Msg("query rows")
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var subscriptions []*pb.WebhookSubscription
+	for rows.Next() {
+		subscription, err := r.scanSubscription(ctx, rows, false)
+		if err != nil {
+			l.Err(err).Msg("scan row")
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Err(err).Msg("iterate rows")
+		return nil, err
+	}
+
+	l.Info().Int("count", len(subscriptions)).Msg("completed successfully")
+	return subscriptions, nil
+}
+
+func (r *webhookRepo) CreateDelivery(
+	ctx context.Context,
+	delivery *pb.WebhookDelivery,
+	events ...*pb.AuditEvent,
+) (err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "webhook_deliveries", "CreateDelivery", _webhookDeliveryCreate, _auditCreate)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "create delivery").
+		Str("subscription_id", delivery.SubscriptionId).
+		Str("event_id", delivery.EventId).
+		Str("query", _webhookDeliveryCreate).
+		Logger()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		now       = time.Now()
+		replayOf  = sql.NullString{String: delivery.ReplayOf, Valid: delivery.ReplayOf != ""}
+		createdAt time.Time
+	)
+
+	err = tx.QueryRowxContext(
+		ctx,
+		_webhookDeliveryCreate,
+		delivery.SubscriptionId,
+		delivery.EventId,
+		delivery.EventType,
+		delivery.Payload,
+		pb.WebhookDelivery_PENDING,
+		replayOf,
+		now,
+		now,
+	).Scan(&delivery.ID, &delivery.Status, &delivery.Attempts, &createdAt)
+
+	if err != nil {
+		l.Err(err).Msg("exec and scan result")
+		return err
+	}
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	delivery.CreatedAt = timestamppb.New(createdAt)
+	delivery.UpdatedAt = timestamppb.New(now)
+
+	l.Info().Str("id", delivery.ID).Msg("completed successfully")
+	return nil
+}
+
+// CreateSubscription stores the subscription and writes the audit events, which target the subscription unless they
+// have a target, in the same transaction.
+func (r *webhookRepo) CreateSubscription(
+	ctx context.Context,
+	subscription *pb.WebhookSubscription,
+	events ...*pb.AuditEvent,
+) (err error) {
+	ctx, span := tracing.StartDBSpan(
+		ctx,
+		"webhook_subscriptions",
+		"CreateSubscription",
+		_webhookSubscriptionCreate,
+		_auditCreate,
+	)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "create subscription").
+		Str("query", _webhookSubscriptionCreate).
+		Logger()
+
+	secret, err := r.cipher.Encrypt(ctx, subscription.Secret)
+	if err != nil {
+		l.Err(err).Msg("encrypt secret")
+		return err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		now       = time.Now()
+		createdBy = sql.NullString{String: subscription.CreatedBy, Valid: subscription.CreatedBy != ""}
+		id        string
+	)
+
+	err = tx.QueryRowxContext(
+		ctx,
+		_webhookSubscriptionCreate,
+		subscription.Url,
+		subscription.EventTypes,
+		secret,
+		subscription.Status,
+		createdBy,
+		now,
+		now,
+	).Scan(&id)
+
+	if err != nil {
+		l.Err(err).Msg("exec and scan result")
+		return err
+	}
+
+	setAuditTargetID(id, events...)
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	subscription.ID = id
+	subscription.CreatedAt = timestamppb.New(now)
+	subscription.UpdatedAt = timestamppb.New(now)
+
+	l.Info().Str("id", id).Msg("completed successfully")
+	return nil
+}
+
+func (r *webhookRepo) DeleteSubscription(ctx context.Context, id string, events ...*pb.AuditEvent) (err error) {
+	ctx, span := tracing.StartDBSpan(
+		ctx,
+		"webhook_subscriptions",
+		"DeleteSubscription",
+		_webhookSubscriptionDelete,
+		_auditCreate,
+	)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "delete subscription").
+		Str("id", id).
+		Str("query", _webhookSubscriptionDelete).
+		Logger()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, _webhookSubscriptionDelete, id)
+	if err != nil {
+		l.Err(err).Msg("exec query")
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		l.Err(err).Msg("rows affected")
+		return err
+	}
+
+	if rows == 0 {
+		l.Err(sql.ErrNoRows).Msg("subscription not found")
+		return sql.ErrNoRows
+	}
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func (r *webhookRepo) FindDeliveryByID(ctx context.Context, id string) (_ *pb.WebhookDelivery, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "webhook_deliveries", "FindDeliveryByID", _webhookDeliveryFindByID)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find delivery by id").
+		Str("id", id).
+		Str("query", _webhookDeliveryFindByID).
+		Logger()
+
+	delivery, err := scanDelivery(r.db.QueryRowContext(ctx, _webhookDeliveryFindByID, id))
+	if err != nil {
+		l.Err(err).Msg("scan row")
+		return nil, err
+	}
+
+	l.Info().Msg("completed successfully")
+	return delivery, nil
+}
+
+func (r *webhookRepo) FindSubscriptionByID(ctx context.Context, id string) (_ *pb.WebhookSubscription, err error) {
+	ctx, span := tracing.StartDBSpan(
+		ctx,
+		"webhook_subscriptions",
+		"FindSubscriptionByID",
+		_webhookSubscriptionFindByID,
+	)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "find subscription by id").
+		Str("id", id).
+		Str("query", _webhookSubscriptionFindByID).
+		Logger()
+
+	subscription, err := r.scanSubscription(ctx, r.db.QueryRowContext(ctx, _webhookSubscriptionFindByID, id), true)
+	if err != nil {
+		l.Err(err).Msg("scan row")
+		return nil, err
+	}
+
+	l.Info().Msg("completed successfully")
+	return subscription, nil
+}
+
+func (r *webhookRepo) ListDeliveries(
+	ctx context.Context,
+	subscriptionID string,
+	limit int,
+) (_ []*pb.WebhookDelivery, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "webhook_deliveries", "ListDeliveries", _webhookDeliveryList)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "list deliveries").
+		Str("subscription_id", subscriptionID).
+		Str("query", _webhookDeliveryList).
+		Logger()
+
+	rows, err := r.db.QueryxContext(ctx, _webhookDeliveryList, subscriptionID, limit)
+	if err != nil {
+		l.Err(err).Msg("query rows")
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var deliveries []*pb.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			l.Err(err).Msg("scan row")
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Err(err).Msg("iterate rows")
+		return nil, err
+	}
+
+	l.Info().Int("count", len(deliveries)).Msg("completed successfully")
+	return deliveries, nil
+}
+
+func (r *webhookRepo) ListSubscriptions(ctx context.Context) (_ []*pb.WebhookSubscription, err error) {
+	ctx, span := tracing.StartDBSpan(ctx, "webhook_subscriptions", "ListSubscriptions", _webhookSubscriptionList)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "list subscriptions").
+		Str("query", _webhookSubscriptionList).
+		Logger()
+
+	return r.listSubscriptions(ctx, l, _webhookSubscriptionList)
+}
+
+func (r *webhookRepo) ListSubscriptionsForEvent(
+	ctx context.Context,
+	eventType string,
+) (_ []*pb.WebhookSubscription, err error) {
+	ctx, span := tracing.StartDBSpan(
+		ctx,
+		"webhook_subscriptions",
+		"ListSubscriptionsForEvent",
+		_webhookSubscriptionsForEvent,
+	)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "list subscriptions for event").
+		Str("event_type", eventType).
+		Str("query", _webhookSubscriptionsForEvent).
+		Logger()
+
+	return r.listSubscriptions(ctx, l, _webhookSubscriptionsForEvent, pb.WebhookSubscription_ACTIVE, eventType)
+}
+
+func (r *webhookRepo) RecordDeliveryAttempt(
+	ctx context.Context,
+	delivery *pb.WebhookDelivery,
+	disableAfter int,
+) (_ bool, err error) {
+	ctx, span := tracing.StartDBSpan(
+		ctx,
+		"webhook_deliveries",
+		"RecordDeliveryAttempt",
+		_webhookDeliveryRecordAttempt,
+		_webhookSubscriptionResetFailures,
+		_webhookSubscriptionRecordFailure,
+	)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "record delivery attempt").
+		Str("id", delivery.ID).
+		Stringer("status", delivery.Status).
+		Logger()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return false, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		now            = time.Now()
+		responseStatus = sql.NullInt32{Int32: delivery.ResponseStatus, Valid: delivery.ResponseStatus != 0}
+		lastError      = sql.NullString{String: delivery.LastError, Valid: delivery.LastError != ""}
+		deliveredAt    sql.NullTime
+	)
+
+	if delivery.Status == pb.WebhookDelivery_SUCCEEDED {
+		deliveredAt = sql.NullTime{Time: now, Valid: true}
+	}
+
+	err = tx.QueryRowxContext(
+		ctx,
+		_webhookDeliveryRecordAttempt,
+		delivery.Status,
+		responseStatus,
+		lastError,
+		deliveredAt,
+		now,
+		delivery.ID,
+	).Scan(&delivery.Attempts)
+	if err != nil {
+		l.Err(err).Str("query", _webhookDeliveryRecordAttempt).Msg("exec and scan result")
+		return false, err
+	}
+
+	disabled := false
+
+	switch delivery.Status {
+	case pb.WebhookDelivery_SUCCEEDED:
+		if _, err = tx.ExecContext(ctx, _webhookSubscriptionResetFailures, delivery.SubscriptionId); err != nil {
+			l.Err(err).Str("query", _webhookSubscriptionResetFailures).Msg("exec query")
+			return false, err
+		}
+	case pb.WebhookDelivery_FAILED:
+		var status pb.WebhookSubscription_Status
+		err = tx.QueryRowxContext(
+			ctx,
+			_webhookSubscriptionRecordFailure,
+			disableAfter,
+			pb.WebhookSubscription_DISABLED,
+			now,
+			delivery.SubscriptionId,
+			pb.WebhookSubscription_ACTIVE,
+		).Scan(&status)
+
+		// The subscription was already disabled.
+		if err != nil && err != sql.ErrNoRows {
+			l.Err(err).Str("query", _webhookSubscriptionRecordFailure).Msg("exec and scan result")
+			return false, err
+		}
+
+		disabled = err == nil && status == pb.WebhookSubscription_DISABLED
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return false, err
+	}
+
+	delivery.UpdatedAt = timestamppb.New(now)
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = timestamppb.New(now)
+	}
+
+	l.Info().Int32("attempts", delivery.Attempts).Bool("disabled", disabled).Msg("completed successfully")
+	return disabled, nil
+}
+
+func (r *webhookRepo) UpdateSubscription(
+	ctx context.Context,
+	subscription *pb.WebhookSubscription,
+	events ...*pb.AuditEvent,
+) (err error) {
+	ctx, span := tracing.StartDBSpan(
+		ctx,
+		"webhook_subscriptions",
+		"UpdateSubscription",
+		_webhookSubscriptionUpdate,
+		_auditCreate,
+	)
+	defer func() {
+		err = TranslateError(err)
+		tracing.EndSpan(span, err)
+	}()
+
+	l := logger.FromContext(ctx, r.l).With().Str("action", "update subscription").
+		Str("id", subscription.ID).
+		Str("query", _webhookSubscriptionUpdate).
+		Logger()
+
+	var (
+		now        = time.Now()
+		disabledAt sql.NullTime
+	)
+
+	if subscription.Status == pb.WebhookSubscription_DISABLED {
+		disabledAt = sql.NullTime{Time: now, Valid: true}
+		if subscription.DisabledAt != nil {
+			disabledAt.Time = subscription.DisabledAt.AsTime()
+		}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		l.Err(err).Msg("begin transaction")
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = tx.QueryRowxContext(
+		ctx,
+		_webhookSubscriptionUpdate,
+		subscription.Url,
+		subscription.EventTypes,
+		subscription.Status,
+		disabledAt,
+		now,
+		subscription.ID,
+	).Scan(&subscription.ConsecutiveFailures)
+
+	if err != nil {
+		l.Err(err).Msg("exec and scan result")
+		return err
+	}
+
+	if err = appendAuditEvents(ctx, tx, events...); err != nil {
+		l.Err(err).Msg("append audit events")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		l.Err(err).Msg("commit transaction")
+		return err
+	}
+
+	subscription.UpdatedAt = timestamppb.New(now)
+	subscription.DisabledAt = nil
+	if disabledAt.Valid {
+		subscription.DisabledAt = timestamppb.New(disabledAt.Time)
+	}
+
+	l.Info().Msg("completed successfully")
+	return nil
+}
+
+func NewTestWebhookRepo(db *sqlx.DB) Webhook {
+	return NewWebhookRepo(db, logger.TestLogger, encryption.TestCipher)
+}
+
+func NewWebhookRepo(db *sqlx.DB, l zerolog.Logger, cipher encryption.Cipher) Webhook {
+	return &webhookRepo{
+		cipher: cipher,
+		db:     db,
+		l:      l.With().Str("repo", "webhook_sqlx").Logger(),
+	}
+}
@@ -0,0 +1,135 @@
+package repository_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWebhookRepo(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts      = assert.New(t)
+		ctx          = context.Background()
+		repo         = repository.NewTestWebhookRepo(testDB)
+		eventType    = string(models.EventCategoryCreated)
+		subscription = &pb.WebhookSubscription{
+			Url:        "https://example.com/webhooks",
+			EventTypes: []string{eventType},
+			Secret:     "whsec_0123456789abcdef0123456789abcdef",
+			Status:     pb.WebhookSubscription_ACTIVE,
+		}
+	)
+
+	asserts.NoError(repo.CreateSubscription(ctx, subscription))
+	asserts.NotEmpty(subscription.ID)
+
+	var storedSecret string
+	err := testDB.QueryRowContext(ctx, `SELECT secret FROM webhook_subscriptions WHERE id = $1`, subscription.ID).
+		Scan(&storedSecret)
+	asserts.NoError(err)
+	asserts.NotEqual(subscription.Secret, storedSecret)
+
+	gotSubscription, err := repo.FindSubscriptionByID(ctx, subscription.ID)
+	asserts.NoError(err)
+	asserts.Equal(subscription.Secret, gotSubscription.Secret)
+	asserts.Equal(subscription.EventTypes, gotSubscription.EventTypes)
+
+	subscriptions, err := repo.ListSubscriptionsForEvent(ctx, eventType)
+	asserts.NoError(err)
+	asserts.Contains(subscriptionIDs(subscriptions), subscription.ID)
+
+	delivery := &pb.WebhookDelivery{
+		SubscriptionId: subscription.ID,
+		EventId:        uuid.NewString(),
+		EventType:      eventType,
+		Payload:        `{"type":"category.created"}`,
+	}
+
+	asserts.NoError(repo.CreateDelivery(ctx, delivery))
+	asserts.Equal(pb.WebhookDelivery_PENDING, delivery.Status)
+
+	// The event is only delivered once to the subscription, unless it's replayed.
+	duplicate := &pb.WebhookDelivery{
+		SubscriptionId: subscription.ID,
+		EventId:        delivery.EventId,
+		EventType:      eventType,
+		Payload:        delivery.Payload,
+	}
+	asserts.NoError(repo.CreateDelivery(ctx, duplicate))
+	asserts.Equal(delivery.ID, duplicate.ID)
+
+	delivery.Status = pb.WebhookDelivery_SUCCEEDED
+	delivery.ResponseStatus = 200
+
+	disabled, err := repo.RecordDeliveryAttempt(ctx, delivery, 2)
+	asserts.NoError(err)
+	asserts.False(disabled)
+	asserts.Equal(int32(1), delivery.Attempts)
+
+	replay := &pb.WebhookDelivery{
+		SubscriptionId: subscription.ID,
+		EventId:        delivery.EventId,
+		EventType:      eventType,
+		Payload:        delivery.Payload,
+		ReplayOf:       delivery.ID,
+	}
+	asserts.NoError(repo.CreateDelivery(ctx, replay))
+	asserts.NotEqual(delivery.ID, replay.ID)
+
+	failed := &pb.WebhookDelivery{
+		SubscriptionId: subscription.ID,
+		EventId:        uuid.NewString(),
+		EventType:      eventType,
+		Payload:        delivery.Payload,
+	}
+	asserts.NoError(repo.CreateDelivery(ctx, failed))
+
+	// The subscription is disabled once two deliveries in a row fail.
+	for i, d := range []*pb.WebhookDelivery{replay, failed} {
+		d.Status = pb.WebhookDelivery_FAILED
+		d.LastError = "unexpected response status 500"
+
+		disabled, err = repo.RecordDeliveryAttempt(ctx, d, 2)
+		asserts.NoError(err)
+		asserts.Equal(i == 1, disabled)
+	}
+
+	gotSubscription, err = repo.FindSubscriptionByID(ctx, subscription.ID)
+	asserts.NoError(err)
+	asserts.Equal(pb.WebhookSubscription_DISABLED, gotSubscription.Status)
+	asserts.NotNil(gotSubscription.DisabledAt)
+
+	deliveries, err := repo.ListDeliveries(ctx, subscription.ID, 10)
+	asserts.NoError(err)
+	asserts.Len(deliveries, 3)
+	asserts.Equal(failed.ID, deliveries[0].ID)
+	asserts.Equal(pb.WebhookDelivery_FAILED, deliveries[0].Status)
+	asserts.Equal(delivery.ID, deliveries[1].ReplayOf)
+
+	// Re-enabling the subscription resets its failures.
+	gotSubscription.Status = pb.WebhookSubscription_ACTIVE
+	asserts.NoError(repo.UpdateSubscription(ctx, gotSubscription))
+	asserts.Zero(gotSubscription.ConsecutiveFailures)
+	asserts.Nil(gotSubscription.DisabledAt)
+
+	asserts.NoError(repo.DeleteSubscription(ctx, subscription.ID))
+	asserts.ErrorIs(repo.DeleteSubscription(ctx, subscription.ID), sql.ErrNoRows)
+
+	_, err = repo.FindDeliveryByID(ctx, delivery.ID)
+	asserts.ErrorIs(err, sql.ErrNoRows)
+}
+
+func subscriptionIDs(subscriptions []*pb.WebhookSubscription) []string {
+	ids := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ids = append(ids, subscription.ID)
+	}
+	return ids
+}
@@ -1,30 +1,122 @@
 package rpc_error
 
 import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
 )
 
+// Domain is the ErrorInfo domain of the errors returned by the api.
+const Domain = "bridge"
+
+// ReasonValidationFailed is the ErrorInfo reason of the errors returned for requests failing validation.
+const ReasonValidationFailed = "VALIDATION_FAILED"
+
+// The errors returned by the api. Their ErrorInfo reasons are stable, so that clients can rely on them rather than
+// on the messages which may change.
 var (
-	//ErrCreateResourceFailed         = NewError(codes.Internal, "Failed to create specified resource.")
-	ErrResourceNotFound             = NewError(codes.NotFound, "Resource not found.")
-	ErrCategoryExists               = NewError(codes.AlreadyExists, "Category already exists.")
-	ErrCategoryNotFound             = NewError(codes.NotFound, "Category not found.")
-	ErrEmailExists                  = NewError(codes.AlreadyExists, "Email is already in use.")
-	ErrExpiredToken                 = NewError(codes.Unauthenticated, "Expired access token provided.")
-	ErrInactiveAccount              = NewError(codes.Unauthenticated, "Account has been deactivated.")
-	ErrInvalidAuthorizationScheme   = NewError(codes.Unauthenticated, "Invalid authorization scheme provided.")
-	ErrInvalidToken                 = NewError(codes.Unauthenticated, "Invalid access token provided.")
-	ErrMissingAuthHeader            = NewError(codes.Unauthenticated, "Missing authorization header.")
-	ErrMissingCtxAuthMetadata       = NewError(codes.Unauthenticated, "Missing context authentication metadata.")
-	ErrMissingMalformedToken        = NewError(codes.Unauthenticated, "Malformed authorization token.")
-	ErrPasswordConfirmationMismatch = NewError(codes.InvalidArgument, "The password confirmation does not match.")
-	ErrPhoneNumberExists            = NewError(codes.AlreadyExists, "Phone number is already in use.")
-	ErrServerError                  = NewError(codes.Internal, "Internal server error.")
-	ErrUnauthenticated              = NewError(codes.Unauthenticated, codes.Unauthenticated.String())
+	//ErrCreateResourceFailed = NewError(codes.Internal, "CREATE_RESOURCE_FAILED", "Failed to create specified resource.")
+	ErrResourceNotFound             = NewError(codes.NotFound, "RESOURCE_NOT_FOUND", "Resource not found.")
+	ErrAccountStatusChangeForbidden = NewError(codes.InvalidArgument, "ACCOUNT_STATUS_CHANGE_FORBIDDEN",
+		"Account status cannot be changed through update.")
+	ErrAlreadyExists          = NewError(codes.AlreadyExists, "ALREADY_EXISTS", "Resource already exists.")
+	ErrCategoryExists         = NewError(codes.AlreadyExists, "CATEGORY_EXISTS", "Category already exists.")
+	ErrCategoryNotFound       = NewError(codes.NotFound, "CATEGORY_NOT_FOUND", "Category not found.")
+	ErrConcurrentModification = NewError(codes.Aborted, "CONCURRENT_MODIFICATION",
+		"The resource was modified concurrently, try again.")
+	ErrConstraintViolation = NewError(codes.InvalidArgument, "CONSTRAINT_VIOLATION",
+		"The request violates a data constraint.")
+	ErrContactAlreadyVerified = NewError(codes.FailedPrecondition, "CONTACT_ALREADY_VERIFIED",
+		"The email or phone number is already verified.")
+	ErrEmailExists              = NewError(codes.AlreadyExists, "EMAIL_EXISTS", "Email is already in use.")
+	ErrExpiredToken             = NewError(codes.Unauthenticated, "TOKEN_EXPIRED", "Expired access token provided.")
+	ErrIdempotencyKeyInProgress = NewError(codes.Aborted, "IDEMPOTENCY_KEY_IN_PROGRESS",
+		"A request with the same idempotency key is in progress.")
+	ErrIdempotencyKeyReused = NewError(codes.InvalidArgument, "IDEMPOTENCY_KEY_REUSED",
+		"Idempotency key was used for a different request.")
+	ErrInactiveAccount            = NewError(codes.Unauthenticated, "ACCOUNT_INACTIVE", "Account has been deactivated.")
+	ErrInvalidAuthorizationScheme = NewError(codes.Unauthenticated, "INVALID_AUTHORIZATION_SCHEME",
+		"Invalid authorization scheme provided.")
+	ErrInvalidEventType      = NewError(codes.InvalidArgument, "INVALID_EVENT_TYPE", "Unknown event type provided.")
+	ErrInvalidIdempotencyKey = NewError(codes.InvalidArgument, "INVALID_IDEMPOTENCY_KEY",
+		"Invalid idempotency key provided.")
+	ErrInvalidLoginCode = NewError(codes.Unauthenticated, "INVALID_LOGIN_CODE", "Invalid or expired login code.")
+	ErrInvalidReference = NewError(codes.FailedPrecondition, "INVALID_REFERENCE",
+		"A referenced resource does not exist or is still in use.")
+	ErrInvalidStatusTransition = NewError(codes.FailedPrecondition, "INVALID_STATUS_TRANSITION",
+		"Account status transition is not allowed.")
+	ErrInvalidTimeRange  = NewError(codes.InvalidArgument, "INVALID_TIME_RANGE", "Invalid time range provided.")
+	ErrInvalidToken      = NewError(codes.Unauthenticated, "INVALID_TOKEN", "Invalid access token provided.")
+	ErrInvalidWebhookURL = NewError(codes.InvalidArgument, "INVALID_WEBHOOK_URL",
+		"Webhook URL must use https and a public address.")
+	ErrInvalidKYCTransition = NewError(codes.FailedPrecondition, "INVALID_KYC_TRANSITION",
+		"KYC submission has already been reviewed.")
+	ErrKYCRequired         = NewError(codes.PermissionDenied, "KYC_REQUIRED", "An approved KYC verification is required.")
+	ErrKYCSubmissionExists = NewError(codes.FailedPrecondition, "KYC_SUBMISSION_EXISTS",
+		"A KYC submission is already pending or approved.")
+	ErrKYCSubmissionNotFound     = NewError(codes.NotFound, "KYC_SUBMISSION_NOT_FOUND", "KYC submission not found.")
+	ErrLoginCodeAttemptsExceeded = NewError(codes.ResourceExhausted, "LOGIN_CODE_ATTEMPTS_EXCEEDED",
+		"Too many failed attempts, request a new login code.")
+	ErrMissingAuthHeader      = NewError(codes.Unauthenticated, "MISSING_AUTH_HEADER", "Missing authorization header.")
+	ErrMissingCtxAuthMetadata = NewError(codes.Unauthenticated, "MISSING_AUTH_METADATA",
+		"Missing context authentication metadata.")
+	ErrMissingMalformedToken        = NewError(codes.Unauthenticated, "MALFORMED_TOKEN", "Malformed authorization token.")
+	ErrPasswordConfirmationMismatch = NewError(codes.InvalidArgument, "PASSWORD_CONFIRMATION_MISMATCH",
+		"The password confirmation does not match.")
+	ErrPermissionDenied = NewError(codes.PermissionDenied, "PERMISSION_DENIED",
+		"You are not allowed to perform this action.")
+	ErrPhoneNumberExists = NewError(codes.AlreadyExists, "PHONE_NUMBER_EXISTS",
+		"Phone number is already in use.")
+	ErrRateLimited = NewError(codes.ResourceExhausted, "RATE_LIMITED",
+		"Too many requests, try again later.")
+	ErrServerError      = NewError(codes.Internal, "INTERNAL_ERROR", "Internal server error.")
+	ErrSuspendedAccount = NewError(codes.Unauthenticated, "ACCOUNT_SUSPENDED", "Account has been suspended.")
+	ErrTimeout          = NewError(codes.DeadlineExceeded, "TIMEOUT", "The request timed out, try again later.")
+	ErrUnauthenticated  = NewError(codes.Unauthenticated, "UNAUTHENTICATED", codes.Unauthenticated.String())
+	ErrUnavailable      = NewError(codes.Unavailable, "UNAVAILABLE",
+		"The service is temporarily unavailable, try again later.")
+	ErrWebhookDeliveryNotFound     = NewError(codes.NotFound, "WEBHOOK_DELIVERY_NOT_FOUND", "Webhook delivery not found.")
+	ErrWebhookSubscriptionDisabled = NewError(codes.FailedPrecondition, "WEBHOOK_SUBSCRIPTION_DISABLED",
+		"Webhook subscription is disabled.")
+	ErrWebhookSubscriptionNotFound = NewError(codes.NotFound, "WEBHOOK_SUBSCRIPTION_NOT_FOUND",
+		"Webhook subscription not found.")
 )
 
-// NewError creates an error representing code and msg.
-func NewError(code codes.Code, msg string) error {
-	return status.Error(code, msg)
+// NewError creates an error representing code and msg, with an ErrorInfo detail holding reason.
+func NewError(code codes.Code, reason, msg string) error {
+	return withDetails(status.New(code, msg), &errdetails.ErrorInfo{Reason: reason, Domain: Domain})
+}
+
+// NewValidationError creates an InvalidArgument error with a BadRequest detail listing violations.
+func NewValidationError(violations []*errdetails.BadRequest_FieldViolation) error {
+	return withDetails(
+		status.New(codes.InvalidArgument, "Request validation failed."),
+		&errdetails.ErrorInfo{Reason: ReasonValidationFailed, Domain: Domain},
+		&errdetails.BadRequest{FieldViolations: violations},
+	)
+}
+
+// Reason returns the ErrorInfo reason of err, or an empty string if it has none.
+func Reason(err error) string {
+	s, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+// withDetails returns the error of s with details, or without them if they can't be marshalled.
+func withDetails(s *status.Status, details ...protoiface.MessageV1) error {
+	withDetails, err := s.WithDetails(details...)
+	if err != nil {
+		return s.Err()
+	}
+	return withDetails.Err()
 }
@@ -0,0 +1,109 @@
+package sender
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/config"
+	"bridge/internal/logger"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Message is a notification delivered to a single recipient. To holds an email address or a phone number
+// depending on the channel the Sender delivers to.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers a Message to its recipient through a specific channel e.g. email or SMS.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Senders groups the Sender used for each delivery channel.
+type Senders struct {
+	Email Sender
+	SMS   Sender
+}
+
+// ErrNoProvider is returned by NewSenders in the environments where the messages must be delivered, since no email
+// or SMS provider is implemented yet.
+var ErrNoProvider = errors.New("sender: no email or SMS provider configured")
+
+// NewSenders returns the Senders used in env. The messages are only logged in development and tests, and
+// ErrNoProvider is returned in the other environments so that the application doesn't start without delivering the
+// login codes.
+func NewSenders(l zerolog.Logger, env string) (Senders, error) {
+	switch env {
+	case config.DevelopmentEnvironment, config.TestEnvironment:
+		return Senders{
+			Email: NewLogSender(l, "email"),
+			SMS:   NewLogSender(l, "sms"),
+		}, nil
+	}
+	return Senders{}, fmt.Errorf("%w for the %q environment", ErrNoProvider, env)
+}
+
+type logSender struct {
+	l zerolog.Logger
+}
+
+// Send writes the recipient and subject of the message to the logger instead of delivering it. The body is left out
+// since it holds secrets such as login codes.
+func (s *logSender) Send(_ context.Context, msg Message) error {
+	s.l.Info().
+		Str("to", logger.Mask(pb.Redaction_REDACTION_PARTIAL, msg.To)).
+		Str("subject", msg.Subject).
+		Msg("message not delivered, no provider configured")
+	return nil
+}
+
+// NewLogSender creates a Sender that logs messages. It is meant for local development where no email or SMS
+// provider is configured.
+func NewLogSender(l zerolog.Logger, channel string) Sender {
+	return &logSender{
+		l: l.With().Str("sender", channel).Logger(),
+	}
+}
+
+// RecordingSender keeps every sent message in memory, keyed by the recipient.
+type RecordingSender struct {
+	mu       sync.RWMutex
+	messages map[string][]Message
+}
+
+// Send records the message.
+func (s *RecordingSender) Send(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages[msg.To] = append(s.messages[msg.To], msg)
+	return nil
+}
+
+// Last returns the most recent message sent to the recipient.
+func (s *RecordingSender) Last(to string) (Message, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	messages := s.messages[to]
+	if len(messages) == 0 {
+		return Message{}, false
+	}
+	return messages[len(messages)-1], true
+}
+
+// NewRecordingSender creates a new RecordingSender
+func NewRecordingSender() *RecordingSender {
+	return &RecordingSender{
+		messages: make(map[string][]Message),
+	}
+}
+
+// TestSender is a RecordingSender shared by tests so that they can read the messages sent by the services.
+var TestSender = NewRecordingSender()
@@ -0,0 +1,45 @@
+package sender_test
+
+import (
+	"bridge/internal/config"
+	"bridge/internal/sender"
+	"bytes"
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewSenders(t *testing.T) {
+	t.Parallel()
+
+	for _, env := range []string{config.DevelopmentEnvironment, config.TestEnvironment} {
+		_, err := sender.NewSenders(zerolog.Nop(), env)
+		assert.NoError(t, err, env)
+	}
+
+	for _, env := range []string{config.ProductionEnvironment, "staging", ""} {
+		_, err := sender.NewSenders(zerolog.Nop(), env)
+		assert.ErrorIs(t, err, sender.ErrNoProvider, env)
+	}
+}
+
+func TestLogSender_Send(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		buf     bytes.Buffer
+		s       = sender.NewLogSender(zerolog.New(&buf), "email")
+	)
+
+	err := s.Send(context.Background(), sender.Message{
+		To:      "jane.doe@example.com",
+		Subject: "Your login code",
+		Body:    "Your login code is 482913",
+	})
+	asserts.NoError(err)
+	asserts.Contains(buf.String(), "Your login code")
+	asserts.NotContains(buf.String(), "482913")
+	asserts.NotContains(buf.String(), "jane.doe@example.com")
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"bridge/internal/i18n"
+	"bridge/internal/idempotency"
+	"bridge/internal/interceptors"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"net/textproto"
+)
+
+// HTTP forms of the headers exchanged with the grpc server.
+var (
+	headerAcceptLanguage      = textproto.CanonicalMIMEHeaderKey(i18n.HeaderAcceptLanguage)
+	headerIdempotencyKey      = textproto.CanonicalMIMEHeaderKey(idempotency.HeaderKey)
+	headerIdempotencyReplayed = textproto.CanonicalMIMEHeaderKey(idempotency.HeaderReplayed)
+	headerIncidentID          = textproto.CanonicalMIMEHeaderKey(interceptors.HeaderIncidentID)
+	headerRequestID           = textproto.CanonicalMIMEHeaderKey(interceptors.HeaderRequestID)
+	headerRetryAfter          = textproto.CanonicalMIMEHeaderKey(interceptors.HeaderRetryAfter)
+)
+
+// NewGatewayMux creates a new gRPC-Gateway mux that forwards the X-Request-Id, Idempotency-Key and Accept-Language
+// headers to the grpc server and returns the request ID assigned by the server in the response, along with the
+// Idempotent-Replayed header for replayed responses. Rate limited calls are returned as 429 with the
+// Retry-After header. Errors are returned as an ErrorBody, along with the X-Incident-Id header for recovered panics.
+func NewGatewayMux() *runtime.ServeMux {
+	return runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithErrorHandler(errorHandler),
+	)
+}
+
+// incomingHeaderMatcher maps the headers of the HTTP requests to the metadata sent to the grpc server.
+func incomingHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case headerRequestID:
+		return interceptors.HeaderRequestID, true
+	case headerIdempotencyKey:
+		return idempotency.HeaderKey, true
+	case headerAcceptLanguage:
+		return i18n.HeaderAcceptLanguage, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher maps the header metadata returned by the grpc server to the headers of the HTTP responses.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	switch key {
+	case interceptors.HeaderRequestID:
+		return headerRequestID, true
+	case interceptors.HeaderRetryAfter:
+		return headerRetryAfter, true
+	case idempotency.HeaderReplayed:
+		return headerIdempotencyReplayed, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"bridge/internal/interceptors"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+// ErrorBody is the JSON body of the error responses returned by the gateway:
+//
+//	{
+//	  "error": {
+//	    "code": 400,
+//	    "status": "INVALID_ARGUMENT",
+//	    "message": "Request validation failed.",
+//	    "reason": "VALIDATION_FAILED",
+//	    "domain": "bridge",
+//	    "field_violations": [
+//	      {"field": "email", "description": "value must be a valid email address"}
+//	    ],
+//	    "request_id": "01HCZ5M3J8Y7Q9V6K2W4T0R1SN",
+//	    "incident_id": "01HCZ5N0A4B6C8D0E2F4G6H8J0"
+//	  }
+//	}
+type ErrorBody struct {
+	Error ErrorDetails `json:"error"`
+}
+
+// ErrorDetails describes an error returned by the gateway.
+type ErrorDetails struct {
+	// Code is the HTTP status code of the response.
+	Code int `json:"code"`
+	// Status is the name of the gRPC status code, such as NOT_FOUND.
+	Status string `json:"status"`
+	// Message is a human-readable description of the error in the locale negotiated from the Accept-Language header,
+	// which may change.
+	Message string `json:"message"`
+	// Reason is the stable machine-readable reason of the error, such as EMAIL_EXISTS. It's empty for the errors
+	// returned by the gateway itself.
+	Reason string `json:"reason,omitempty"`
+	// Domain is the domain of Reason.
+	Domain string `json:"domain,omitempty"`
+	// FieldViolations lists the invalid fields of the request, named by their JSON names.
+	FieldViolations []FieldViolation `json:"field_violations,omitempty"`
+	// RequestID is the ID assigned to the request, also returned in the X-Request-Id header.
+	RequestID string `json:"request_id,omitempty"`
+	// IncidentID identifies the logs of an internal error, also returned in the X-Incident-Id header. It's only set
+	// for recovered panics.
+	IncidentID string `json:"incident_id,omitempty"`
+}
+
+// FieldViolation describes an invalid field of a request.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// fallbackErrorBody is returned when the ErrorBody can't be marshalled.
+const fallbackErrorBody = `{"error":{"code":500,"status":"INTERNAL","message":"Internal server error."}}`
+
+// errorHandler writes err as an ErrorBody. Like runtime.DefaultHTTPErrorHandler, it forwards the header metadata
+// returned by the grpc server, which holds the request ID and the Retry-After header of rate limited calls. The
+// incident ID of recovered panics is returned in the trailer metadata, which is dropped with the error response, so
+// it's added to the body and the headers instead.
+func errorHandler(
+	ctx context.Context,
+	_ *runtime.ServeMux,
+	_ runtime.Marshaler,
+	w http.ResponseWriter,
+	r *http.Request,
+	err error,
+) {
+	var customStatus *runtime.HTTPStatusError
+	if errors.As(err, &customStatus) {
+		err = customStatus.Err
+	}
+
+	s := status.Convert(err)
+
+	httpStatus := runtime.HTTPStatusFromCode(s.Code())
+	if customStatus != nil {
+		httpStatus = customStatus.HTTPStatus
+	}
+
+	body := ErrorBody{Error: ErrorDetails{
+		Code:      httpStatus,
+		Status:    code.Code(s.Code()).String(),
+		Message:   s.Message(),
+		RequestID: r.Header.Get(headerRequestID),
+	}}
+
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			body.Error.Reason, body.Error.Domain = d.GetReason(), d.GetDomain()
+		case *errdetails.LocalizedMessage:
+			body.Error.Message = d.GetMessage()
+			w.Header().Set("Content-Language", d.GetLocale())
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				body.Error.FieldViolations = append(body.Error.FieldViolations, FieldViolation{
+					Field:       v.GetField(),
+					Description: v.GetDescription(),
+				})
+			}
+		}
+	}
+
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", "application/json")
+
+	if s.Code() == codes.Unauthenticated {
+		w.Header().Set("WWW-Authenticate", s.Message())
+	}
+
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for k, vs := range md.HeaderMD {
+			if h, ok := outgoingHeaderMatcher(k); ok {
+				for _, v := range vs {
+					w.Header().Add(h, v)
+				}
+			}
+		}
+
+		if ids := md.HeaderMD.Get(interceptors.HeaderRequestID); len(ids) > 0 {
+			body.Error.RequestID = ids[0]
+		}
+
+		if ids := md.TrailerMD.Get(interceptors.HeaderIncidentID); len(ids) > 0 {
+			body.Error.IncidentID = ids[0]
+			w.Header().Set(headerIncidentID, ids[0])
+		}
+	}
+
+	buf, err := json.Marshal(body)
+	if err != nil {
+		grpclog.Infof("Failed to marshal error body %q: %v", s, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		buf = []byte(fallbackErrorBody)
+	} else {
+		w.WriteHeader(httpStatus)
+	}
+
+	if _, err = w.Write(buf); err != nil {
+		grpclog.Infof("Failed to write response: %v", err)
+	}
+}
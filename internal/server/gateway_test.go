@@ -0,0 +1,240 @@
+package server_test
+
+import (
+	"bridge/internal/i18n"
+	"bridge/internal/interceptors"
+	"bridge/internal/logger"
+	"bridge/internal/rpc_error"
+	"bridge/internal/server"
+	"context"
+	"encoding/json"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGatewayErrorBody(t *testing.T) {
+	t.Parallel()
+
+	mux := server.NewGatewayMux()
+
+	tests := []struct {
+		name       string
+		err        error
+		md         metadata.MD
+		trailer    metadata.MD
+		wantStatus int
+		wantBody   server.ErrorBody
+		wantHeader http.Header
+	}{
+		{
+			name:       "rpc error",
+			err:        rpc_error.ErrEmailExists,
+			md:         metadata.Pairs(interceptors.HeaderRequestID, "request-1"),
+			wantStatus: http.StatusConflict,
+			wantBody: server.ErrorBody{Error: server.ErrorDetails{
+				Code:      http.StatusConflict,
+				Status:    "ALREADY_EXISTS",
+				Message:   "Email is already in use.",
+				Reason:    "EMAIL_EXISTS",
+				Domain:    rpc_error.Domain,
+				RequestID: "request-1",
+			}},
+			wantHeader: http.Header{"X-Request-Id": {"request-1"}},
+		},
+		{
+			name:       "localized error",
+			err:        i18n.Localize(i18n.Swahili, rpc_error.ErrEmailExists),
+			wantStatus: http.StatusConflict,
+			wantBody: server.ErrorBody{Error: server.ErrorDetails{
+				Code:    http.StatusConflict,
+				Status:  "ALREADY_EXISTS",
+				Message: "Barua pepe tayari inatumika.",
+				Reason:  "EMAIL_EXISTS",
+				Domain:  rpc_error.Domain,
+			}},
+			wantHeader: http.Header{"Content-Language": {i18n.Swahili}},
+		},
+		{
+			name: "validation error",
+			err: rpc_error.NewValidationError([]*errdetails.BadRequest_FieldViolation{
+				{Field: "email", Description: "value must be a valid email address"},
+			}),
+			wantStatus: http.StatusBadRequest,
+			wantBody: server.ErrorBody{Error: server.ErrorDetails{
+				Code:    http.StatusBadRequest,
+				Status:  "INVALID_ARGUMENT",
+				Message: "Request validation failed.",
+				Reason:  rpc_error.ReasonValidationFailed,
+				Domain:  rpc_error.Domain,
+				FieldViolations: []server.FieldViolation{
+					{Field: "email", Description: "value must be a valid email address"},
+				},
+			}},
+		},
+		{
+			name:       "rate limited",
+			err:        rpc_error.ErrRateLimited,
+			md:         metadata.Pairs(interceptors.HeaderRetryAfter, "30"),
+			wantStatus: http.StatusTooManyRequests,
+			wantBody: server.ErrorBody{Error: server.ErrorDetails{
+				Code:    http.StatusTooManyRequests,
+				Status:  "RESOURCE_EXHAUSTED",
+				Message: "Too many requests, try again later.",
+				Reason:  "RATE_LIMITED",
+				Domain:  rpc_error.Domain,
+			}},
+			wantHeader: http.Header{"Retry-After": {"30"}},
+		},
+		{
+			name:       "recovered panic",
+			err:        rpc_error.ErrServerError,
+			md:         metadata.Pairs(interceptors.HeaderRequestID, "request-2"),
+			trailer:    metadata.Pairs(interceptors.HeaderIncidentID, "incident-1"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody: server.ErrorBody{Error: server.ErrorDetails{
+				Code:       http.StatusInternalServerError,
+				Status:     "INTERNAL",
+				Message:    "Internal server error.",
+				Reason:     "INTERNAL_ERROR",
+				Domain:     rpc_error.Domain,
+				RequestID:  "request-2",
+				IncidentID: "incident-1",
+			}},
+			wantHeader: http.Header{"X-Request-Id": {"request-2"}, "X-Incident-Id": {"incident-1"}},
+		},
+		{
+			name:       "gateway error",
+			err:        &runtime.HTTPStatusError{HTTPStatus: http.StatusMethodNotAllowed, Err: rpc_error.ErrUnauthenticated},
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody: server.ErrorBody{Error: server.ErrorDetails{
+				Code:    http.StatusMethodNotAllowed,
+				Status:  "UNAUTHENTICATED",
+				Message: "Unauthenticated",
+				Reason:  "UNAUTHENTICATED",
+				Domain:  rpc_error.Domain,
+			}},
+			wantHeader: http.Header{"Www-Authenticate": {"Unauthenticated"}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				asserts = assert.New(t)
+				ctx     = runtime.NewServerMetadataContext(
+					context.Background(),
+					runtime.ServerMetadata{HeaderMD: tt.md, TrailerMD: tt.trailer},
+				)
+				rec = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", nil)
+			)
+
+			runtime.HTTPError(ctx, mux, &runtime.JSONPb{}, rec, req, tt.err)
+
+			asserts.Equal(tt.wantStatus, rec.Code)
+			asserts.Equal("application/json", rec.Header().Get("Content-Type"))
+
+			for key, values := range tt.wantHeader {
+				asserts.Equal(values, rec.Header().Values(key))
+			}
+
+			var body server.ErrorBody
+			asserts.NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+			asserts.Equal(tt.wantBody, body)
+		})
+	}
+}
+
+func TestGatewayClientIP(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mux          = server.NewGatewayMux()
+		balancers, _ = logger.ParseTrustedProxies("127.0.0.1,10.0.0.0/8")
+	)
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		remoteAddr string
+		proxies    []*net.IPNet
+		want       string
+	}{
+		{
+			name: "remote address",
+			want: "203.0.113.7",
+		},
+		{
+			name:   "forwarded for sent by the client",
+			header: http.Header{"X-Forwarded-For": {"198.51.100.1"}},
+			want:   "203.0.113.7",
+		},
+		{
+			name: "forwarded for sent by the client as metadata",
+			header: http.Header{
+				"X-Forwarded-For":                 {"198.51.100.1, 198.51.100.2"},
+				"Grpc-Metadata-X-Forwarded-For":   {"198.51.100.3"},
+				"Grpc-Metadata-X-Forwarded-Host":  {"example.com"},
+				"Grpc-Metadata-X-Forwarded-Proto": {"https"},
+			},
+			want: "203.0.113.7",
+		},
+		{
+			name:       "forwarded for by a trusted load balancer",
+			header:     http.Header{"X-Forwarded-For": {"198.51.100.1, 198.51.100.2, 10.0.0.3"}},
+			remoteAddr: "10.0.0.2:52114",
+			proxies:    balancers,
+			want:       "198.51.100.2",
+		},
+		{
+			name:       "forwarded for to an untrusted load balancer",
+			header:     http.Header{"X-Forwarded-For": {"198.51.100.1"}},
+			remoteAddr: "10.0.0.2:52114",
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "invalid forwarded for",
+			header:     http.Header{"X-Forwarded-For": {"198.51.100.1, unknown"}},
+			remoteAddr: "10.0.0.2:52114",
+			proxies:    balancers,
+			want:       "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			asserts := assert.New(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+			req.RemoteAddr = "203.0.113.7:52114"
+			if tt.remoteAddr != "" {
+				req.RemoteAddr = tt.remoteAddr
+			}
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+
+			ctx, err := runtime.AnnotateContext(context.Background(), mux, req, "/api.v1.AuthService/Login")
+			asserts.NoError(err)
+
+			// The grpc server receives the metadata of the gateway, which runs on the loopback interface.
+			md, _ := metadata.FromOutgoingContext(ctx)
+			ctx = metadata.NewIncomingContext(context.Background(), md)
+			ctx = logger.ContextWithRequest(ctx, &logger.Request{Peer: "127.0.0.1:40122", TrustedProxies: tt.proxies})
+
+			asserts.Equal(tt.want, logger.ClientIP(ctx))
+		})
+	}
+}
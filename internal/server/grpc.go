@@ -1,19 +1,98 @@
 package server
 
 import (
+	"bridge/api/v1/pb"
+	"bridge/internal/audit"
+	"bridge/internal/idempotency"
 	"bridge/internal/interceptors"
+	"bridge/internal/ratelimit"
 	"bridge/services/auth"
+	"bridge/services/kyc"
+	"context"
+	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"net"
 )
 
 // NewGrpcSrv creates a new grpc server with required server options set up.
-func NewGrpcSrv(authFunc auth.Authenticator, unarySrvInterceptors interceptors.UnaryServerInterceptor) *grpc.Server {
+func NewGrpcSrv(
+	l zerolog.Logger,
+	authFunc auth.Authenticator,
+	kycEnforcer kyc.Enforcer,
+	auditor audit.Auditor,
+	limiter ratelimit.Limiter,
+	guard idempotency.Guard,
+	trustedProxies []*net.IPNet,
+	unarySrvInterceptors interceptors.UnaryServerInterceptor,
+	streamSrvInterceptors interceptors.StreamServerInterceptor,
+) *grpc.Server {
+	authenticator := authFunc.Authenticate()
+
 	opts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(
+			unarySrvInterceptors.UnaryServerTracing(),
+			unarySrvInterceptors.UnaryServerAccessLog(l, trustedProxies),
+			unarySrvInterceptors.UnaryServerMetrics(),
+			unarySrvInterceptors.UnaryServerLocalizer(),
+			unarySrvInterceptors.UnaryServerAudit(auditor),
+			unarySrvInterceptors.UnaryServerRecovery(l),
+			unarySrvInterceptors.UnaryServerReadYourWrites(),
 			unarySrvInterceptors.UnaryServerValidator(),
 			// TODO: add after implementing an authenticator
-			unarySrvInterceptors.UnaryServerAuthenticator(authFunc.Authenticate()),
+			unarySrvInterceptors.UnaryServerAuthenticator(authenticator),
+			unarySrvInterceptors.UnaryServerRateLimit(limiter),
+			unarySrvInterceptors.UnaryServerIdempotency(guard),
+			unarySrvInterceptors.UnaryServerKYCEnforcer(kycEnforcer),
+		),
+		grpc.ChainStreamInterceptor(
+			streamSrvInterceptors.StreamServerTracing(),
+			streamSrvInterceptors.StreamServerAccessLog(l, trustedProxies),
+			streamSrvInterceptors.StreamServerMetrics(),
+			streamSrvInterceptors.StreamServerLocalizer(),
+			streamSrvInterceptors.StreamServerAudit(auditor),
+			streamSrvInterceptors.StreamServerRecovery(l),
+			streamSrvInterceptors.StreamServerReadYourWrites(),
+			streamSrvInterceptors.StreamServerValidator(),
+			streamSrvInterceptors.StreamServerAuthenticator(authenticator),
+			streamSrvInterceptors.StreamServerRateLimit(limiter),
+			streamSrvInterceptors.StreamServerKYCEnforcer(kycEnforcer),
 		),
 	}
 	return grpc.NewServer(opts...)
 }
+
+// Services are the gRPC services served by the API.
+type Services struct {
+	Audit   pb.AuditServiceServer
+	Auth    pb.AuthServiceServer
+	KYC     pb.KYCServiceServer
+	User    pb.UserServiceServer
+	Webhook pb.WebhookServiceServer
+}
+
+// RegisterServices registers svcs with srv.
+func RegisterServices(srv grpc.ServiceRegistrar, svcs Services) {
+	pb.RegisterAuthServiceServer(srv, svcs.Auth)
+	pb.RegisterUserServiceServer(srv, svcs.User)
+	pb.RegisterKYCServiceServer(srv, svcs.KYC)
+	pb.RegisterWebhookServiceServer(srv, svcs.Webhook)
+	pb.RegisterAuditServiceServer(srv, svcs.Audit)
+}
+
+// GracefulStop stops srv once the pending RPCs complete. The open connections are closed forcefully if they don't
+// complete before ctx is done.
+func GracefulStop(ctx context.Context, srv *grpc.Server) error {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		srv.Stop()
+		return ctx.Err()
+	}
+}
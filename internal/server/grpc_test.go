@@ -0,0 +1,36 @@
+package server_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/server"
+	"bridge/services/kyc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+func TestRegisterServices_KYCRequiredMethods(t *testing.T) {
+	t.Parallel()
+
+	srv := grpc.NewServer()
+	server.RegisterServices(srv, server.Services{
+		Audit:   pb.UnimplementedAuditServiceServer{},
+		Auth:    pb.UnimplementedAuthServiceServer{},
+		KYC:     pb.UnimplementedKYCServiceServer{},
+		User:    pb.UnimplementedUserServiceServer{},
+		Webhook: pb.UnimplementedWebhookServiceServer{},
+	})
+
+	registered := make(map[string]bool)
+	for name, info := range srv.GetServiceInfo() {
+		for _, method := range info.Methods {
+			registered["/"+name+"/"+method.Name] = true
+		}
+	}
+
+	asserts := assert.New(t)
+	asserts.NotEmpty(kyc.RequiredMethods)
+	for _, method := range kyc.RequiredMethods {
+		asserts.True(registered[method], "%s is not a registered method", method)
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+)
+
+// magicLinkScript logs the user in with the code of the link once they confirm. The code isn't used when the page
+// loads since some link scanners run the scripts of the pages they open.
+const magicLinkScript = `
+const params = new URLSearchParams(location.hash.slice(1));
+history.replaceState(null, "", location.pathname);
+
+const button = document.getElementById("login");
+const result = document.getElementById("result");
+
+button.addEventListener("click", async () => {
+  button.disabled = true;
+  try {
+    const res = await fetch("/v1/auth/login-code/verify", {
+      method: "POST",
+      headers: {"Content-Type": "application/json"},
+      body: JSON.stringify({email: params.get("email"), code: params.get("code")}),
+    });
+    const body = await res.json();
+    if (!res.ok) {
+      result.textContent = body.error.message;
+      return;
+    }
+    sessionStorage.setItem("access_token", body.access_token);
+    result.textContent = "You are logged in, you can close this page.";
+  } catch (err) {
+    result.textContent = "Failed to log in, try again.";
+    button.disabled = false;
+  }
+});
+`
+
+const magicLinkPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Log in</title>
+</head>
+<body>
+<button id="login" type="button">Log in</button>
+<p id="result" role="status"></p>
+<script>` + magicLinkScript + `</script>
+</body>
+</html>
+`
+
+// magicLinkCSP only allows the page's own script, which only calls the API.
+var magicLinkCSP = func() string {
+	sum := sha256.Sum256([]byte(magicLinkScript))
+	return "default-src 'none'; script-src 'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'; " +
+		"connect-src 'self'; base-uri 'none'; form-action 'none'; frame-ancestors 'none'"
+}()
+
+// MagicLinkHandler serves the page opened by the login links sent by email at auth.MagicLinkPath, which posts the code
+// of the link to AuthService.LoginWithCode when the user clicks to log in.
+func MagicLinkHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		h := w.Header()
+		h.Set("Content-Type", "text/html; charset=utf-8")
+		h.Set("Content-Security-Policy", magicLinkCSP)
+		h.Set("Cache-Control", "no-store")
+		h.Set("Referrer-Policy", "no-referrer")
+		h.Set("X-Content-Type-Options", "nosniff")
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(magicLinkPage))
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package server_test
+
+import (
+	"bridge/internal/server"
+	"bridge/services/auth"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMagicLinkHandler(t *testing.T) {
+	t.Parallel()
+
+	asserts := assert.New(t)
+	handler := server.MagicLinkHandler()
+
+	// Opening the link only serves the page, the code is posted once the user clicks to log in.
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, auth.MagicLinkPath, nil))
+
+	asserts.Equal(http.StatusOK, rec.Code)
+	asserts.Equal("text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	asserts.Equal("no-store", rec.Header().Get("Cache-Control"))
+	asserts.Equal("no-referrer", rec.Header().Get("Referrer-Policy"))
+	asserts.Contains(rec.Header().Get("Content-Security-Policy"), "script-src 'sha256-")
+	asserts.Contains(rec.Body.String(), `fetch("/v1/auth/login-code/verify"`)
+	asserts.True(strings.Index(rec.Body.String(), "addEventListener(\"click\"") <
+		strings.Index(rec.Body.String(), "fetch("))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, auth.MagicLinkPath, nil))
+
+	asserts.Equal(http.StatusMethodNotAllowed, rec.Code)
+	asserts.Equal("GET, HEAD", rec.Header().Get("Allow"))
+}
@@ -60,7 +60,7 @@ func NewPostgresSrv() (*PostgresSrv, func() error, error) {
 	pool.MaxWait = 20 * time.Second
 
 	err = pool.Retry(func() error {
-		conn, err := db.NewConnection(pgSrv.DSN)
+		conn, err := db.NewConnection(pgSrv.DSN, db.DefaultConfig)
 		if err != nil {
 			return err
 		}
@@ -1,13 +1,21 @@
 package testutils
 
 import (
-	"bridge/api/v1/pb"
+	auditlog "bridge/internal/audit"
 	"bridge/internal/client"
+	"bridge/internal/idempotency"
 	"bridge/internal/interceptors"
+	"bridge/internal/logger"
+	"bridge/internal/ratelimit"
 	"bridge/internal/repository"
+	"bridge/internal/sender"
 	"bridge/internal/server"
+	"bridge/internal/worker"
+	"bridge/services/audit"
 	"bridge/services/auth"
+	"bridge/services/kyc"
 	"bridge/services/user"
+	"bridge/services/webhook"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -24,17 +32,42 @@ func TestGRPCSrv(
 	rs repository.Store,
 ) string {
 	var (
-		authSvc = auth.NewService(jwtManager, l, rs)
-		userSvc = user.NewService(l, rs)
+		senders  = sender.Senders{Email: sender.TestSender, SMS: sender.TestSender}
+		authSvc  = auth.NewService(jwtManager, l, rs, senders)
+		userSvc  = user.NewService(l, rs)
+		kycSvc   = kyc.NewService(l, rs)
+		hookSvc  = webhook.NewService(l, rs, worker.NewEnqueuer(rs.JobRepo), nil)
+		auditSvc = audit.NewService(l, rs)
 
-		unarySrvInterceptors = interceptors.NewUnaryServerInterceptors()
-		authProcessor        = auth.NewAuthProcessor(jwtManager, l, rs)
-		srv                  = server.NewGrpcSrv(authProcessor, unarySrvInterceptors)
-		asserts              = assert.New(t)
+		unarySrvInterceptors  = interceptors.NewUnaryServerInterceptors()
+		streamSrvInterceptors = interceptors.NewStreamServerInterceptors()
+		authProcessor         = auth.NewAuthProcessor(jwtManager, l, rs)
+		kycEnforcer           = kyc.NewEnforcer(l, rs, kyc.RequiredMethods...)
+		auditor               = auditlog.NewAuditor(l, rs.AuditRepo, logger.NewRedactor(nil), auditlog.Methods...)
+		// The tests log in repeatedly from the same address, so no method is rate limited.
+		limiter = ratelimit.NewLimiter(l, ratelimit.NewMemoryBackend(0), nil)
+		guard   = idempotency.NewGuard(l, rs.IdempotencyRepo, 0, idempotency.Methods...)
+		srv     = server.NewGrpcSrv(
+			l,
+			authProcessor,
+			kycEnforcer,
+			auditor,
+			limiter,
+			guard,
+			nil,
+			unarySrvInterceptors,
+			streamSrvInterceptors,
+		)
+		asserts = assert.New(t)
 	)
 
-	pb.RegisterAuthServiceServer(srv, authSvc)
-	pb.RegisterUserServiceServer(srv, userSvc)
+	server.RegisterServices(srv, server.Services{
+		Audit:   auditSvc,
+		Auth:    authSvc,
+		KYC:     kycSvc,
+		User:    userSvc,
+		Webhook: hookSvc,
+	})
 
 	lis, err := net.Listen("tcp", ":0")
 	asserts.NoError(err)
@@ -0,0 +1,59 @@
+package tracing_test
+
+import (
+	"bridge/internal/tracing"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+)
+
+func TestSetup(t *testing.T) {
+	asserts := assert.New(t)
+
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{})
+	asserts.NoError(err)
+	asserts.NoError(shutdown(context.Background()))
+
+	_, err = tracing.Setup(context.Background(), tracing.Config{Exporter: "zipkin"})
+	asserts.ErrorIs(err, tracing.ErrUnknownExporter)
+}
+
+func TestStartDBSpan(t *testing.T) {
+	var (
+		asserts  = assert.New(t)
+		recorder = tracetest.NewSpanRecorder()
+		tp       = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		query    = `SELECT id FROM users WHERE email = $1`
+	)
+
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+	})
+
+	_, span := tracing.StartDBSpan(context.Background(), "users", "FindByEmail", query)
+	tracing.EndSpan(span, sql.ErrNoRows)
+
+	_, span = tracing.StartDBSpan(context.Background(), "users", "Create", query)
+	tracing.EndSpan(span, errors.New("connection refused"))
+
+	spans := recorder.Ended()
+	if !asserts.Len(spans, 2) {
+		return
+	}
+
+	asserts.Equal("users.FindByEmail", spans[0].Name())
+	asserts.Contains(spans[0].Attributes(), attribute.String("db.statement", query))
+	asserts.Contains(spans[0].Attributes(), attribute.String("db.system", "postgresql"))
+	asserts.Equal(codes.Unset, spans[0].Status().Code)
+
+	asserts.Equal(codes.Error, spans[1].Status().Code)
+	asserts.Len(spans[1].Events(), 1)
+}
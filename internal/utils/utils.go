@@ -1,12 +1,10 @@
 package utils
 
 import (
-	"bridge/internal/rpc_error"
-	"database/sql"
-	"errors"
-	"github.com/lib/pq"
+	"context"
+	cryptorand "crypto/rand"
 	"golang.org/x/crypto/bcrypt"
-	"google.golang.org/grpc/codes"
+	"math/big"
 	"math/rand"
 	"regexp"
 	"strings"
@@ -15,7 +13,10 @@ import (
 
 const BcryptCost = bcrypt.DefaultCost
 
-const charset = "abcdefghjklmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ123456789"
+const (
+	charset      = "abcdefghjklmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ123456789"
+	digitCharset = "0123456789"
+)
 
 var (
 	seededRand = rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -36,22 +37,6 @@ func CompareHash(hash, value string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(value)) == nil
 }
 
-// ParseDBError parses db errors to return more information to the caller.
-func ParseDBError(err error) error {
-	if v, ok := err.(*pq.Error); ok {
-		switch v.Code {
-		case "23505":
-			return rpc_error.NewError(codes.AlreadyExists, v.Detail)
-		}
-	}
-
-	if errors.Is(err, sql.ErrNoRows) {
-		return rpc_error.ErrResourceNotFound
-	}
-
-	return rpc_error.ErrServerError
-}
-
 func String(length int) string {
 	return StringWithCharset(length, charset)
 }
@@ -64,6 +49,56 @@ func StringWithCharset(length int, charset string) string {
 	return string(b)
 }
 
+// SecureString returns a random string of the given length generated using crypto/rand. It should be used
+// whenever the value is a secret such as a one-time code.
+func SecureString(length int) (string, error) {
+	return secureStringWithCharset(length, charset)
+}
+
+// SecureDigits returns a random numeric string of the given length generated using crypto/rand.
+func SecureDigits(length int) (string, error) {
+	return secureStringWithCharset(length, digitCharset)
+}
+
+func secureStringWithCharset(length int, charset string) (string, error) {
+	var (
+		b   = make([]byte, length)
+		max = big.NewInt(int64(len(charset)))
+	)
+
+	for i := range b {
+		n, err := cryptorand.Int(cryptorand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = charset[n.Int64()]
+	}
+	return string(b), nil
+}
+
 func Slugify(s string) string {
 	return strings.Trim(strReg.ReplaceAllString(strings.ToLower(s), "-"), "-")
 }
+
+// detached keeps the values of a context, such as the request and the span, without its cancellation.
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
+
+// WithoutCancel returns a context with the values of ctx which isn't canceled when ctx is. It's used for the writes
+// which must happen even if the client went away, such as recording the outcome of a call.
+func WithoutCancel(ctx context.Context) context.Context {
+	return detached{ctx}
+}
@@ -0,0 +1,207 @@
+package webhooks
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/worker"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// JobTypeDeliver is the type of the jobs delivering a webhook.
+	JobTypeDeliver = "webhook.deliver"
+	// Queue is the worker queue the deliveries are processed from.
+	Queue = "webhooks"
+	// DefaultMaxAttempts is how many times a delivery is attempted. With the default worker backoff the last attempt
+	// happens about 4 hours after the first one.
+	DefaultMaxAttempts = 15
+	// DefaultDisableAfter is how many deliveries in a row can fail for good before the subscription is disabled.
+	DefaultDisableAfter = 5
+	// DefaultTimeout is how long the receiver has to respond to a delivery.
+	DefaultTimeout = 10 * time.Second
+
+	// maxErrorBodySize is how much of an unexpected response is kept in the delivery log.
+	maxErrorBodySize = 512
+	userAgent        = "bridge-webhooks/1.0"
+)
+
+// DeliverPayload is the payload of the JobTypeDeliver jobs.
+type DeliverPayload struct {
+	DeliveryID string `json:"delivery_id"`
+}
+
+// EnqueueDelivery adds the job delivering the webhook with deliveryID.
+func EnqueueDelivery(ctx context.Context, enqueuer worker.Enqueuer, deliveryID string) (*models.Job, error) {
+	return enqueuer.Enqueue(
+		ctx,
+		JobTypeDeliver,
+		DeliverPayload{DeliveryID: deliveryID},
+		worker.OnQueue(Queue),
+		worker.MaxAttempts(DefaultMaxAttempts),
+	)
+}
+
+type deliverer struct {
+	client       *http.Client
+	disableAfter int
+	l            zerolog.Logger
+	repo         repository.Webhook
+}
+
+func (d *deliverer) Handle(ctx context.Context, job *models.Job) error {
+	var payload DeliverPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return worker.Permanent(fmt.Errorf("decode payload: %w", err))
+	}
+
+	l := logger.FromContext(ctx, d.l).With().Str("action", "deliver").
+		Str("delivery_id", payload.DeliveryID).
+		Int("attempt", job.Attempts).
+		Logger()
+
+	delivery, err := d.repo.FindDeliveryByID(ctx, payload.DeliveryID)
+	if err != nil {
+		l.Err(err).Msg("failed to find delivery")
+		// The delivery is deleted along with its subscription.
+		if errors.Is(err, repository.ErrNotFound) {
+			return worker.Permanent(err)
+		}
+		return err
+	}
+
+	if delivery.Status != pb.WebhookDelivery_PENDING {
+		l.Info().Stringer("status", delivery.Status).Msg("delivery already completed")
+		return nil
+	}
+
+	subscription, err := d.repo.FindSubscriptionByID(ctx, delivery.SubscriptionId)
+	if err != nil {
+		l.Err(err).Msg("failed to find subscription")
+		if errors.Is(err, repository.ErrNotFound) {
+			return worker.Permanent(err)
+		}
+		return err
+	}
+
+	l = l.With().Str("subscription_id", subscription.ID).Logger()
+
+	if subscription.Status != pb.WebhookSubscription_ACTIVE {
+		delivery.Status = pb.WebhookDelivery_FAILED
+		delivery.LastError = "subscription is disabled"
+
+		if _, err = d.repo.RecordDeliveryAttempt(ctx, delivery, d.disableAfter); err != nil {
+			l.Err(err).Msg("failed to record delivery attempt")
+			return err
+		}
+
+		l.Warn().Msg("delivery skipped, subscription is disabled")
+		return nil
+	}
+
+	delivery.ResponseStatus, err = d.send(ctx, subscription, delivery)
+
+	switch {
+	case err == nil:
+		delivery.Status = pb.WebhookDelivery_SUCCEEDED
+		delivery.LastError = ""
+	case job.Attempts >= job.MaxAttempts, errors.Is(err, ErrDestinationNotAllowed):
+		delivery.Status = pb.WebhookDelivery_FAILED
+		delivery.LastError = err.Error()
+	default:
+		delivery.LastError = err.Error()
+	}
+
+	disabled, recordErr := d.repo.RecordDeliveryAttempt(ctx, delivery, d.disableAfter)
+	if recordErr != nil {
+		l.Err(recordErr).Msg("failed to record delivery attempt")
+		return recordErr
+	}
+
+	if disabled {
+		l.Warn().Int("failures", d.disableAfter).Msg("subscription disabled after repeated failures")
+	}
+
+	if err != nil {
+		l.Warn().Err(err).Int32("response_status", delivery.ResponseStatus).Msg("delivery failed")
+		if errors.Is(err, ErrDestinationNotAllowed) {
+			return worker.Permanent(err)
+		}
+		return err
+	}
+
+	l.Info().Int32("response_status", delivery.ResponseStatus).Msg("delivered successfully")
+	return nil
+}
+
+// send posts the signed delivery to the subscription's URL and returns the response status. Any status other than
+// 2xx is a failure.
+func (d *deliverer) send(
+	ctx context.Context,
+	subscription *pb.WebhookSubscription,
+	delivery *pb.WebhookDelivery,
+) (int32, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderID, delivery.ID)
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	SignRequest(req, subscription.Secret, time.Now(), body)
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	resBody, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return int32(res.StatusCode), fmt.Errorf("unexpected response status %d: %s", res.StatusCode, resBody)
+	}
+
+	return int32(res.StatusCode), nil
+}
+
+// NewDeliverHandler creates the worker.Handler for the JobTypeDeliver jobs. The defaults are used if client is nil or
+// disableAfter is zero, the default client only delivering to https URLs at public addresses.
+func NewDeliverHandler(
+	l zerolog.Logger,
+	repo repository.Webhook,
+	client *http.Client,
+	disableAfter int,
+) worker.Handler {
+	if client == nil {
+		client = NewClient(nil)
+	}
+
+	if disableAfter <= 0 {
+		disableAfter = DefaultDisableAfter
+	}
+
+	return &deliverer{
+		client:       client,
+		disableAfter: disableAfter,
+		l:            l.With().Str("component", "webhooks").Logger(),
+		repo:         repo,
+	}
+}
@@ -0,0 +1,177 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// dialTimeout is how long connecting to the receiver can take.
+const dialTimeout = 5 * time.Second
+
+// ErrDestinationNotAllowed is returned for the webhook URLs which aren't https, and when connecting to a private,
+// loopback or link-local address, unless their host is allowlisted.
+var ErrDestinationNotAllowed = errors.New("webhook destination not allowed")
+
+// blockedNetworks are the non public networks which aren't covered by the net.IP methods.
+var blockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // "this" network
+	"100.64.0.0/10", // carrier-grade NAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// Allowlist is the set of hosts the webhooks can be delivered to over http or at a private, loopback or link-local
+// address, such as receivers in the same network or on a developer's machine. Every other host must use https and
+// resolve to a public address.
+type Allowlist map[string]struct{}
+
+// Allows returns whether host is allowlisted.
+func (a Allowlist) Allows(host string) bool {
+	_, ok := a[strings.ToLower(host)]
+	return ok
+}
+
+// ParseAllowlist parses a comma separated list of hosts, for example "localhost,receiver.internal".
+func ParseAllowlist(s string) Allowlist {
+	allowlist := make(Allowlist)
+	for _, host := range strings.Split(s, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			allowlist[host] = struct{}{}
+		}
+	}
+	return allowlist
+}
+
+// ValidateURL returns ErrDestinationNotAllowed if rawURL isn't an https URL, or if its host is an IP address which
+// isn't public, unless the host is allowlisted. The addresses the host resolves to are checked when delivering.
+func ValidateURL(rawURL string, allowlist Allowlist) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDestinationNotAllowed, err)
+	}
+
+	host := u.Hostname()
+	if host == "" || u.User != nil {
+		return fmt.Errorf("%w: %q must have a host and no credentials", ErrDestinationNotAllowed, rawURL)
+	}
+
+	if allowlist.Allows(host) {
+		if u.Scheme != "https" && u.Scheme != "http" {
+			return fmt.Errorf("%w: unsupported scheme %q", ErrDestinationNotAllowed, u.Scheme)
+		}
+		return nil
+	}
+
+	if u.Scheme != "https" {
+		return fmt.Errorf("%w: %q must use https", ErrDestinationNotAllowed, host)
+	}
+
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return fmt.Errorf("%w: %q is a loopback host", ErrDestinationNotAllowed, host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s is not a public address", ErrDestinationNotAllowed, ip)
+	}
+
+	return nil
+}
+
+// isPublicIP returns whether ip is a public unicast address.
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() {
+		return false
+	}
+
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// controlPublic is a net.Dialer Control refusing to connect to addresses which aren't public. It runs once the host
+// is resolved, so that a host can't pass the validation and resolve to an internal address when delivering.
+func controlPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s is not a public address", ErrDestinationNotAllowed, host)
+	}
+	return nil
+}
+
+// allowlistTransport validates the URL of the requests before sending them, since the subscriptions may have been
+// created before the allowlist changed.
+type allowlistTransport struct {
+	allowlist Allowlist
+	next      http.RoundTripper
+}
+
+func (t *allowlistTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := ValidateURL(req.URL.String(), t.allowlist); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// NewClient returns the http.Client delivering the webhooks. It only sends to https URLs at public addresses, except
+// for the allowlisted hosts, and doesn't follow redirects, which are reported as failed deliveries.
+func NewClient(allowlist Allowlist) *http.Client {
+	var (
+		publicDialer  = &net.Dialer{Timeout: dialTimeout, Control: controlPublic}
+		allowedDialer = &net.Dialer{Timeout: dialTimeout}
+		transport     = http.DefaultTransport.(*http.Transport).Clone()
+	)
+
+	// A proxy would connect to the receiver on the client's behalf, bypassing the address check.
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		if allowlist.Allows(host) {
+			return allowedDialer.DialContext(ctx, network, addr)
+		}
+		return publicDialer.DialContext(ctx, network, addr)
+	}
+
+	return &http.Client{
+		Timeout:   DefaultTimeout,
+		Transport: &allowlistTransport{allowlist: allowlist, next: transport},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/events"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/worker"
+	"context"
+	"encoding/json"
+
+	"github.com/rs/zerolog"
+)
+
+type publisher struct {
+	enqueuer worker.Enqueuer
+	l        zerolog.Logger
+	repo     repository.Webhook
+}
+
+func (p *publisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	subscriptions, err := p.repo.ListSubscriptionsForEvent(ctx, string(event.Type))
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(events.NewEnvelope(event))
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		delivery := &pb.WebhookDelivery{
+			SubscriptionId: subscription.ID,
+			EventId:        event.ID,
+			EventType:      string(event.Type),
+			Payload:        string(payload),
+		}
+
+		if err = p.repo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+
+		// The delivery exists already if the event is published again. It's only enqueued again if it wasn't
+		// attempted yet, since enqueuing it may be what failed the last time.
+		if delivery.Status != pb.WebhookDelivery_PENDING || delivery.Attempts > 0 {
+			continue
+		}
+
+		if _, err = EnqueueDelivery(ctx, p.enqueuer, delivery.ID); err != nil {
+			return err
+		}
+
+		p.l.Info().
+			Str("event_id", event.ID).
+			Str("subscription_id", subscription.ID).
+			Str("delivery_id", delivery.ID).
+			Msg("webhook delivery enqueued")
+	}
+
+	return nil
+}
+
+func (p *publisher) Close() error {
+	return nil
+}
+
+// NewPublisher creates an events.Publisher which creates a delivery for every active subscription to the published
+// event and enqueues the job delivering it.
+func NewPublisher(l zerolog.Logger, repo repository.Webhook, enqueuer worker.Enqueuer) events.Publisher {
+	return &publisher{
+		enqueuer: enqueuer,
+		l:        l.With().Str("component", "webhooks").Logger(),
+		repo:     repo,
+	}
+}
@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every delivery.
+const (
+	// HeaderID is the ID of the delivery. It's the same across retries, so receivers can use it to discard
+	// duplicates, while replays are sent with a new ID.
+	HeaderID = "Webhook-Id"
+	// HeaderEvent is the type of the delivered event.
+	HeaderEvent = "Webhook-Event"
+	// HeaderTimestamp is the time the delivery was signed, in seconds since the Unix epoch.
+	HeaderTimestamp = "Webhook-Timestamp"
+	// HeaderSignature is the signature of the delivery, see Sign.
+	HeaderSignature = "Webhook-Signature"
+)
+
+const (
+	// DefaultTolerance is how old a signed delivery can be before Verify rejects it as a replay.
+	DefaultTolerance = 5 * time.Minute
+
+	signatureVersion = "v1"
+	secretPrefix     = "whsec_"
+	secretSize       = 32
+)
+
+var (
+	ErrInvalidSignature = errors.New("webhooks: invalid signature")
+	ErrInvalidTimestamp = errors.New("webhooks: timestamp outside the tolerance")
+)
+
+// NewSecret generates a random secret for signing the deliveries of a subscription.
+func NewSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Sign returns the signature of body sent at ts, which is the hex encoded HMAC-SHA256 of "<ts>.<body>" keyed with
+// secret and prefixed with the signature version, e.g. "v1=5257a869...". Signing the timestamp prevents a captured
+// delivery from being replayed later on.
+func Sign(secret string, ts time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest sets the timestamp and signature headers of req, whose body is body.
+func SignRequest(req *http.Request, secret string, ts time.Time, body []byte) {
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(secret, ts, body))
+}
+
+// Verify checks the signature headers of a delivery whose body is body, rejecting deliveries signed more than
+// tolerance ago. It's what receivers are expected to do, and it's used by the tests.
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	ts := time.Unix(unix, 0)
+	if d := time.Since(ts); d > tolerance || d < -tolerance {
+		return ErrInvalidTimestamp
+	}
+
+	expected := Sign(secret, ts, body)
+
+	// Several signatures are accepted so that secrets can be rotated without downtime.
+	for _, signature := range strings.Fields(header.Get(HeaderSignature)) {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
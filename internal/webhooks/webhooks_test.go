@@ -0,0 +1,378 @@
+package webhooks_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/webhooks"
+	"bridge/internal/worker"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSecret = "whsec_0123456789abcdef0123456789abcdef"
+
+// memoryWebhookRepo is an in-memory repository.Webhook.
+type memoryWebhookRepo struct {
+	mu            sync.Mutex
+	deliveries    map[string]*pb.WebhookDelivery
+	subscriptions map[string]*pb.WebhookSubscription
+}
+
+func (r *memoryWebhookRepo) CreateDelivery(_ context.Context, delivery *pb.WebhookDelivery, _ ...*pb.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range r.deliveries {
+		if d.ReplayOf == "" && delivery.ReplayOf == "" &&
+			d.SubscriptionId == delivery.SubscriptionId && d.EventId == delivery.EventId {
+			delivery.ID, delivery.Status, delivery.Attempts = d.ID, d.Status, d.Attempts
+			return nil
+		}
+	}
+
+	delivery.ID = strconv.Itoa(len(r.deliveries) + 1)
+	delivery.Status = pb.WebhookDelivery_PENDING
+	r.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (r *memoryWebhookRepo) CreateSubscription(
+	_ context.Context,
+	subscription *pb.WebhookSubscription,
+	_ ...*pb.AuditEvent,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscription.ID = strconv.Itoa(len(r.subscriptions) + 1)
+	r.subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+func (r *memoryWebhookRepo) DeleteSubscription(_ context.Context, id string, _ ...*pb.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.subscriptions, id)
+	return nil
+}
+
+func (r *memoryWebhookRepo) FindDeliveryByID(_ context.Context, id string) (*pb.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return nil, repository.TranslateError(sql.ErrNoRows)
+	}
+
+	return proto.Clone(delivery).(*pb.WebhookDelivery), nil
+}
+
+func (r *memoryWebhookRepo) FindSubscriptionByID(_ context.Context, id string) (*pb.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return nil, repository.TranslateError(sql.ErrNoRows)
+	}
+
+	return proto.Clone(subscription).(*pb.WebhookSubscription), nil
+}
+
+func (r *memoryWebhookRepo) ListDeliveries(context.Context, string, int) ([]*pb.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (r *memoryWebhookRepo) ListSubscriptions(context.Context) ([]*pb.WebhookSubscription, error) {
+	return nil, nil
+}
+
+func (r *memoryWebhookRepo) ListSubscriptionsForEvent(
+	_ context.Context,
+	eventType string,
+) ([]*pb.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var subscriptions []*pb.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		for _, t := range subscription.EventTypes {
+			if t == eventType && subscription.Status == pb.WebhookSubscription_ACTIVE {
+				subscriptions = append(subscriptions, subscription)
+			}
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *memoryWebhookRepo) RecordDeliveryAttempt(
+	_ context.Context,
+	delivery *pb.WebhookDelivery,
+	disableAfter int,
+) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery.Attempts++
+	r.deliveries[delivery.ID] = proto.Clone(delivery).(*pb.WebhookDelivery)
+
+	subscription := r.subscriptions[delivery.SubscriptionId]
+
+	switch delivery.Status {
+	case pb.WebhookDelivery_SUCCEEDED:
+		subscription.ConsecutiveFailures = 0
+	case pb.WebhookDelivery_FAILED:
+		if subscription.Status != pb.WebhookSubscription_ACTIVE {
+			return false, nil
+		}
+
+		subscription.ConsecutiveFailures++
+		if int(subscription.ConsecutiveFailures) >= disableAfter {
+			subscription.Status = pb.WebhookSubscription_DISABLED
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *memoryWebhookRepo) UpdateSubscription(context.Context, *pb.WebhookSubscription, ...*pb.AuditEvent) error {
+	return nil
+}
+
+func newMemoryWebhookRepo() *memoryWebhookRepo {
+	return &memoryWebhookRepo{
+		deliveries:    make(map[string]*pb.WebhookDelivery),
+		subscriptions: make(map[string]*pb.WebhookSubscription),
+	}
+}
+
+// memoryEnqueuer is a worker.Enqueuer keeping the jobs in memory.
+type memoryEnqueuer struct {
+	jobs []*models.Job
+}
+
+func (e *memoryEnqueuer) Enqueue(
+	_ context.Context,
+	jobType string,
+	payload any,
+	opts ...worker.EnqueueOption,
+) (*models.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.Job{Type: jobType, Payload: data, Queue: worker.DefaultQueue, MaxAttempts: 1}
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	e.jobs = append(e.jobs, job)
+	return job, nil
+}
+
+// receiver is a webhook endpoint responding with status and recording the deliveries whose signature is valid.
+type receiver struct {
+	mu       sync.Mutex
+	status   int
+	received []string
+	srv      *httptest.Server
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	if err := webhooks.Verify(testSecret, r.Header, body, webhooks.DefaultTolerance); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.received = append(rc.received, r.Header.Get(webhooks.HeaderID))
+	w.WriteHeader(rc.status)
+}
+
+func newReceiver(t *testing.T) *receiver {
+	rc := &receiver{status: http.StatusNoContent}
+	rc.srv = httptest.NewServer(rc)
+	t.Cleanup(rc.srv.Close)
+	return rc
+}
+
+func TestSign(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		body    = []byte(`{"type":"user.registered"}`)
+		now     = time.Now()
+	)
+
+	tests := []struct {
+		name    string
+		secret  string
+		ts      time.Time
+		body    []byte
+		wantErr error
+	}{
+		{name: "valid signature", secret: testSecret, ts: now, body: body},
+		{name: "wrong secret", secret: "whsec_other", ts: now, body: body, wantErr: webhooks.ErrInvalidSignature},
+		{name: "tampered body", secret: testSecret, ts: now, body: []byte(`{}`), wantErr: webhooks.ErrInvalidSignature},
+		{
+			name:    "replayed delivery",
+			secret:  testSecret,
+			ts:      now.Add(-webhooks.DefaultTolerance - time.Minute),
+			body:    body,
+			wantErr: webhooks.ErrInvalidTimestamp,
+		},
+	}
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		webhooks.SignRequest(req, tc.secret, tc.ts, tc.body)
+
+		err := webhooks.Verify(testSecret, req.Header, body, webhooks.DefaultTolerance)
+		asserts.ErrorIs(err, tc.wantErr, tc.name)
+	}
+
+	secret, err := webhooks.NewSecret()
+	asserts.NoError(err)
+	asserts.Regexp(`^whsec_[\w-]{43}$`, secret)
+}
+
+func TestPublisher_Publish(t *testing.T) {
+	var (
+		asserts   = assert.New(t)
+		ctx       = context.Background()
+		repo      = newMemoryWebhookRepo()
+		enqueuer  = &memoryEnqueuer{}
+		publisher = webhooks.NewPublisher(logger.TestLogger, repo, enqueuer)
+	)
+
+	asserts.NoError(repo.CreateSubscription(ctx, &pb.WebhookSubscription{
+		EventTypes: []string{string(models.EventUserRegistered)},
+		Status:     pb.WebhookSubscription_ACTIVE,
+	}))
+
+	event, err := models.NewUserEvent(models.EventUserRegistered, &pb.User{ID: "42"})
+	asserts.NoError(err)
+	event.ID = "1"
+
+	asserts.NoError(publisher.Publish(ctx, event))
+	asserts.Len(repo.deliveries, 1)
+	asserts.Len(enqueuer.jobs, 1)
+	asserts.Equal(webhooks.Queue, enqueuer.jobs[0].Queue)
+	asserts.Equal(webhooks.JobTypeDeliver, enqueuer.jobs[0].Type)
+
+	// Publishing the event again doesn't create another delivery.
+	repo.deliveries["1"].Attempts = 1
+	asserts.NoError(publisher.Publish(ctx, event))
+	asserts.Len(repo.deliveries, 1)
+	asserts.Len(enqueuer.jobs, 1)
+
+	other, err := models.NewUserEvent(models.EventUserUpdated, &pb.User{ID: "42"})
+	asserts.NoError(err)
+
+	asserts.NoError(publisher.Publish(ctx, other))
+	asserts.Len(repo.deliveries, 1)
+}
+
+func TestDeliverHandler_Handle(t *testing.T) {
+	var (
+		asserts      = assert.New(t)
+		ctx          = context.Background()
+		rc           = newReceiver(t)
+		repo         = newMemoryWebhookRepo()
+		disableAfter = 2
+		handler      = webhooks.NewDeliverHandler(logger.TestLogger, repo, rc.srv.Client(), disableAfter)
+		subscription = &pb.WebhookSubscription{
+			Url:    rc.srv.URL,
+			Secret: testSecret,
+			Status: pb.WebhookSubscription_ACTIVE,
+		}
+	)
+
+	asserts.NoError(repo.CreateSubscription(ctx, subscription))
+
+	// deliver creates a delivery and runs its job, as the given attempt out of maxAttempts.
+	deliver := func(attempt, maxAttempts int) (*pb.WebhookDelivery, error) {
+		delivery := &pb.WebhookDelivery{
+			SubscriptionId: subscription.ID,
+			EventId:        strconv.Itoa(len(repo.deliveries) + 1),
+			EventType:      string(models.EventUserRegistered),
+			Payload:        `{"type":"user.registered"}`,
+		}
+		asserts.NoError(repo.CreateDelivery(ctx, delivery))
+
+		payload, err := json.Marshal(webhooks.DeliverPayload{DeliveryID: delivery.ID})
+		asserts.NoError(err)
+
+		err = handler.Handle(ctx, &models.Job{Payload: payload, Attempts: attempt, MaxAttempts: maxAttempts})
+
+		delivery, findErr := repo.FindDeliveryByID(ctx, delivery.ID)
+		asserts.NoError(findErr)
+		return delivery, err
+	}
+
+	delivery, err := deliver(1, 3)
+	asserts.NoError(err)
+	asserts.Equal(pb.WebhookDelivery_SUCCEEDED, delivery.Status)
+	asserts.Equal(int32(http.StatusNoContent), delivery.ResponseStatus)
+	asserts.Equal([]string{delivery.ID}, rc.received)
+
+	rc.status = http.StatusInternalServerError
+
+	// The delivery is retried until it runs out of attempts.
+	delivery, err = deliver(1, 3)
+	asserts.Error(err)
+	asserts.Equal(pb.WebhookDelivery_PENDING, delivery.Status)
+	asserts.Equal(int32(http.StatusInternalServerError), delivery.ResponseStatus)
+	asserts.Zero(subscription.ConsecutiveFailures)
+
+	for i := 1; i <= disableAfter; i++ {
+		delivery, err = deliver(3, 3)
+		asserts.Error(err)
+		asserts.Equal(pb.WebhookDelivery_FAILED, delivery.Status)
+		asserts.Equal(int32(i), subscription.ConsecutiveFailures)
+	}
+
+	asserts.Equal(pb.WebhookSubscription_DISABLED, subscription.Status)
+
+	// Deliveries to a disabled subscription fail without being sent.
+	received := len(rc.received)
+
+	delivery, err = deliver(1, 3)
+	asserts.NoError(err)
+	asserts.Equal(pb.WebhookDelivery_FAILED, delivery.Status)
+	asserts.Len(rc.received, received)
+
+	// Unknown deliveries aren't retried.
+	err = handler.Handle(ctx, &models.Job{Payload: json.RawMessage(`{"delivery_id":"404"}`), Attempts: 1})
+	asserts.True(worker.IsPermanent(err))
+
+	// Deliveries to a destination which isn't allowed fail without being retried.
+	subscription.Status = pb.WebhookSubscription_ACTIVE
+	handler = webhooks.NewDeliverHandler(logger.TestLogger, repo, nil, disableAfter)
+	received = len(rc.received)
+
+	delivery, err = deliver(1, 3)
+	asserts.ErrorIs(err, webhooks.ErrDestinationNotAllowed)
+	asserts.True(worker.IsPermanent(err))
+	asserts.Equal(pb.WebhookDelivery_FAILED, delivery.Status)
+	asserts.Len(rc.received, received)
+}
@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EnqueueOption configures a job before it's enqueued.
+type EnqueueOption func(job *models.Job)
+
+// OnQueue puts the job on the named queue instead of DefaultQueue.
+func OnQueue(name string) EnqueueOption {
+	return func(job *models.Job) {
+		job.Queue = name
+	}
+}
+
+// RunAt schedules the job to run at t instead of as soon as possible.
+func RunAt(t time.Time) EnqueueOption {
+	return func(job *models.Job) {
+		job.RunAt = t
+	}
+}
+
+// Delay schedules the job to run after d.
+func Delay(d time.Duration) EnqueueOption {
+	return func(job *models.Job) {
+		job.RunAt = time.Now().Add(d)
+	}
+}
+
+// MaxAttempts sets how many times the job runs before it's moved to the dead-letter state. DefaultMaxAttempts is used
+// otherwise.
+func MaxAttempts(n int) EnqueueOption {
+	return func(job *models.Job) {
+		job.MaxAttempts = n
+	}
+}
+
+// Enqueuer adds jobs to the queues. It's used by the API to hand work off to the workers.
+type Enqueuer interface {
+	// Enqueue adds a job of jobType, whose payload is encoded as JSON and decoded by the handler registered for the
+	// type.
+	Enqueue(ctx context.Context, jobType string, payload any, opts ...EnqueueOption) (*models.Job, error)
+}
+
+type enqueuer struct {
+	repo repository.Job
+}
+
+func (e *enqueuer) Enqueue(
+	ctx context.Context,
+	jobType string,
+	payload any,
+	opts ...EnqueueOption,
+) (*models.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode payload: %w", err)
+	}
+
+	job := &models.Job{
+		Queue:       DefaultQueue,
+		Type:        jobType,
+		Payload:     data,
+		MaxAttempts: DefaultMaxAttempts,
+	}
+
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	if err = e.repo.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// NewEnqueuer creates a new Enqueuer storing the jobs using repo.
+func NewEnqueuer(repo repository.Job) Enqueuer {
+	return &enqueuer{repo: repo}
+}
@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"bridge/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Handler runs jobs of a single type. A job is retried with a backoff when Handle returns an error, unless the error
+// is Permanent or the job has run out of attempts, in which case it's moved to the dead-letter state.
+type Handler interface {
+	Handle(ctx context.Context, job *models.Job) error
+}
+
+// HandlerFunc is an adapter to allow using ordinary functions as a Handler.
+type HandlerFunc func(ctx context.Context, job *models.Job) error
+
+func (f HandlerFunc) Handle(ctx context.Context, job *models.Job) error {
+	return f(ctx, job)
+}
+
+// NewHandler creates a Handler which decodes the job payload into T before calling fn. Payloads which can't be
+// decoded fail permanently since retrying them won't help.
+func NewHandler[T any](fn func(ctx context.Context, payload T) error) Handler {
+	return HandlerFunc(func(ctx context.Context, job *models.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("decode payload: %w", err))
+		}
+
+		return fn(ctx, payload)
+	})
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err as not worth retrying, the job is moved to the dead-letter state straight away.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+// IsPermanent checks whether err was marked using Permanent.
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
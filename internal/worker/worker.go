@@ -0,0 +1,303 @@
+package worker
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/tracing"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// DefaultQueue is the queue jobs are added to unless another one is chosen.
+	DefaultQueue = "default"
+	// DefaultMaxAttempts is how many times a job runs before it's moved to the dead-letter state.
+	DefaultMaxAttempts = 10
+	// DefaultPollInterval is how long an idle worker waits before looking for due jobs again.
+	DefaultPollInterval = time.Second
+	// DefaultLockTimeout is how long a job can run. Jobs locked for longer are assumed to belong to a worker which
+	// stopped and are returned to the queue.
+	DefaultLockTimeout = 15 * time.Minute
+)
+
+const (
+	// rescueInterval is how often the jobs with a stale lock are returned to the queue.
+	rescueInterval = time.Minute
+	// updateTimeout is the deadline for recording the result of a job.
+	updateTimeout = 5 * time.Second
+)
+
+// ErrNoHandler is returned for jobs whose type doesn't have a registered handler.
+var ErrNoHandler = errors.New("worker: no handler registered for the job type")
+
+// BackoffFunc returns how long to wait before retrying a job which failed on the given attempt, starting from 1.
+type BackoffFunc func(attempt int) time.Duration
+
+// ExponentialBackoff doubles the delay after every attempt starting from base, up to max.
+func ExponentialBackoff(base, max time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		if attempt < 1 {
+			attempt = 1
+		}
+
+		d := float64(base) * math.Pow(2, float64(attempt-1))
+		if d > float64(max) {
+			return max
+		}
+
+		return time.Duration(d)
+	}
+}
+
+// DefaultBackoff retries after 1s, 2s, 4s and so on, waiting at most an hour.
+var DefaultBackoff = ExponentialBackoff(time.Second, time.Hour)
+
+// Queue is a named queue and how many of its jobs can run at the same time.
+type Queue struct {
+	Name        string
+	Concurrency int
+}
+
+// ParseQueues parses a comma separated list of queues in the name:concurrency format, for example
+// "default:4,emails:2". The concurrency defaults to 1 if it's omitted.
+func ParseQueues(s string) ([]Queue, error) {
+	var queues []Queue
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, concurrency, found := strings.Cut(part, ":")
+
+		q := Queue{Name: strings.TrimSpace(name), Concurrency: 1}
+		if found {
+			n, err := strconv.Atoi(strings.TrimSpace(concurrency))
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("worker: invalid concurrency for queue %q", q.Name)
+			}
+
+			q.Concurrency = n
+		}
+
+		if q.Name == "" {
+			return nil, fmt.Errorf("worker: invalid queue %q", part)
+		}
+
+		queues = append(queues, q)
+	}
+
+	if len(queues) == 0 {
+		return []Queue{{Name: DefaultQueue, Concurrency: 1}}, nil
+	}
+
+	return queues, nil
+}
+
+// Options configures a Worker. The defaults are used for the fields which are zero.
+type Options struct {
+	Backoff      BackoffFunc
+	LockTimeout  time.Duration
+	PollInterval time.Duration
+}
+
+// Worker claims due jobs from its queues and runs them using the handler registered for their type.
+type Worker interface {
+	// Handle registers the handler for jobType. Handlers must be registered before Run is called.
+	Handle(jobType string, h Handler)
+	// Run processes jobs until ctx is done, then waits for the running jobs to finish.
+	Run(ctx context.Context)
+}
+
+type worker struct {
+	handlers map[string]Handler
+	l        zerolog.Logger
+	opts     Options
+	queues   []Queue
+	repo     repository.Job
+}
+
+func (w *worker) Handle(jobType string, h Handler) {
+	w.handlers[jobType] = h
+}
+
+func (w *worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, q := range w.queues {
+		for i := 0; i < q.Concurrency; i++ {
+			wg.Add(1)
+			go func(queue string) {
+				defer wg.Done()
+				w.poll(ctx, queue)
+			}(q.Name)
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.rescue(ctx)
+	}()
+
+	wg.Wait()
+}
+
+// poll runs the due jobs on queue one at a time, waiting for the poll interval whenever the queue is empty.
+func (w *worker) poll(ctx context.Context, queue string) {
+	l := w.l.With().Str("action", "poll").Str("queue", queue).Logger()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		jobs, err := w.repo.Claim(ctx, queue, 1)
+		if err != nil && ctx.Err() == nil {
+			l.Err(err).Msg("failed to claim job")
+		}
+
+		if len(jobs) > 0 {
+			w.process(jobs[0])
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.opts.PollInterval):
+		}
+	}
+}
+
+// rescue returns the jobs with a stale lock to the queue until ctx is done.
+func (w *worker) rescue(ctx context.Context) {
+	var (
+		l      = w.l.With().Str("action", "rescue").Logger()
+		ticker = time.NewTicker(rescueInterval)
+	)
+	defer ticker.Stop()
+
+	for {
+		n, err := w.repo.RescueStale(ctx, time.Now().Add(-w.opts.LockTimeout))
+		if err != nil && ctx.Err() == nil {
+			l.Err(err).Msg("failed to rescue stale jobs")
+		}
+
+		if n > 0 {
+			l.Warn().Int("rescued", n).Msg("returned stale jobs to the queue")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run calls the handler for the job, turning panics into errors.
+func (w *worker) run(ctx context.Context, job *models.Job) (err error) {
+	h, ok := w.handlers[job.Type]
+	if !ok {
+		return Permanent(ErrNoHandler)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("worker: handler panicked: %v", r)
+		}
+	}()
+
+	return h.Handle(ctx, job)
+}
+
+// process runs a claimed job and records the result. The job isn't canceled on shutdown, it runs until it's done or
+// the lock timeout passes.
+func (w *worker) process(job *models.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.opts.LockTimeout)
+	ctx, span := tracing.StartSpan(
+		ctx,
+		"job "+job.Type,
+		attribute.String("job.id", job.ID),
+		attribute.String("job.queue", job.Queue),
+		attribute.Int("job.attempt", job.Attempts),
+	)
+
+	l := logger.FromContext(ctx, w.l).With().Str("action", "process").
+		Str("job_id", job.ID).
+		Str("queue", job.Queue).
+		Str("type", job.Type).
+		Int("attempt", job.Attempts).
+		Logger()
+
+	start := time.Now()
+	err := w.run(ctx, job)
+
+	tracing.EndSpan(span, err)
+	cancel()
+
+	l = l.With().Dur("duration", time.Since(start)).Logger()
+
+	updateCtx, cancelUpdate := context.WithTimeout(context.Background(), updateTimeout)
+	defer cancelUpdate()
+
+	switch {
+	case err == nil:
+		if err = w.repo.Complete(updateCtx, job.ID); err != nil {
+			l.Err(err).Msg("failed to complete job")
+			return
+		}
+
+		l.Info().Msg("job succeeded")
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		if killErr := w.repo.Kill(updateCtx, job.ID, err.Error()); killErr != nil {
+			l.Err(killErr).Msg("failed to kill job")
+			return
+		}
+
+		l.Err(err).Msg("job moved to the dead-letter state")
+	default:
+		runAt := time.Now().Add(w.opts.Backoff(job.Attempts))
+		if retryErr := w.repo.Retry(updateCtx, job.ID, runAt, err.Error()); retryErr != nil {
+			l.Err(retryErr).Msg("failed to retry job")
+			return
+		}
+
+		l.Warn().Err(err).Time("run_at", runAt).Msg("job failed, retrying")
+	}
+}
+
+// NewWorker creates a new Worker processing jobs from queues.
+func NewWorker(l zerolog.Logger, repo repository.Job, queues []Queue, opts Options) Worker {
+	if opts.Backoff == nil {
+		opts.Backoff = DefaultBackoff
+	}
+
+	if opts.LockTimeout <= 0 {
+		opts.LockTimeout = DefaultLockTimeout
+	}
+
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	return &worker{
+		handlers: make(map[string]Handler),
+		l:        l.With().Str("component", "worker").Logger(),
+		opts:     opts,
+		queues:   queues,
+		repo:     repo,
+	}
+}
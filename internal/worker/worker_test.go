@@ -0,0 +1,302 @@
+package worker_test
+
+import (
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/worker"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryJobRepo is an in-memory repository.Job.
+type memoryJobRepo struct {
+	mu   sync.Mutex
+	jobs []*models.Job
+}
+
+func (r *memoryJobRepo) Claim(_ context.Context, queue string, limit int) ([]*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var claimed []*models.Job
+	for _, job := range r.jobs {
+		if len(claimed) == limit {
+			break
+		}
+
+		if job.Queue != queue || job.Status != models.JobStatusPending || job.RunAt.After(time.Now()) {
+			continue
+		}
+
+		job.Status = models.JobStatusRunning
+		job.Attempts++
+
+		c := *job
+		claimed = append(claimed, &c)
+	}
+
+	return claimed, nil
+}
+
+func (r *memoryJobRepo) finish(id string, fn func(job *models.Job)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, job := range r.jobs {
+		if job.ID == id {
+			fn(job)
+			return nil
+		}
+	}
+
+	return errors.New("job not found")
+}
+
+func (r *memoryJobRepo) Complete(_ context.Context, id string) error {
+	return r.finish(id, func(job *models.Job) {
+		job.Status = models.JobStatusSucceeded
+	})
+}
+
+func (r *memoryJobRepo) Enqueue(_ context.Context, job *models.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job.ID = strconv.Itoa(len(r.jobs) + 1)
+	job.Status = models.JobStatusPending
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now()
+	}
+
+	c := *job
+	r.jobs = append(r.jobs, &c)
+	return nil
+}
+
+func (r *memoryJobRepo) FindByID(_ context.Context, id string) (*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, job := range r.jobs {
+		if job.ID == id {
+			c := *job
+			return &c, nil
+		}
+	}
+
+	return nil, errors.New("job not found")
+}
+
+func (r *memoryJobRepo) Kill(_ context.Context, id string, reason string) error {
+	return r.finish(id, func(job *models.Job) {
+		job.Status = models.JobStatusDead
+		job.LastError = &reason
+	})
+}
+
+func (r *memoryJobRepo) RescueStale(context.Context, time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *memoryJobRepo) Retry(_ context.Context, id string, runAt time.Time, reason string) error {
+	return r.finish(id, func(job *models.Job) {
+		job.Status = models.JobStatusPending
+		job.RunAt = runAt
+		job.LastError = &reason
+	})
+}
+
+type greeting struct {
+	Name string `json:"name"`
+}
+
+// runWorker runs w until cond is met.
+func runWorker(t *testing.T, w worker.Worker, cond func() bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		w.Run(ctx)
+	}()
+
+	assert.Eventually(t, cond, 2*time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func jobStatus(t *testing.T, repo *memoryJobRepo, id string) models.JobStatus {
+	job, err := repo.FindByID(context.Background(), id)
+	assert.NoError(t, err)
+	return job.Status
+}
+
+func TestWorker_Run(t *testing.T) {
+	var (
+		asserts  = assert.New(t)
+		ctx      = context.Background()
+		repo     = &memoryJobRepo{}
+		enqueuer = worker.NewEnqueuer(repo)
+		greeted  = make(chan string, 1)
+	)
+
+	w := worker.NewWorker(logger.TestLogger, repo, []worker.Queue{{Name: "emails", Concurrency: 2}}, worker.Options{
+		PollInterval: time.Millisecond,
+	})
+
+	w.Handle("greet", worker.NewHandler(func(_ context.Context, g greeting) error {
+		greeted <- g.Name
+		return nil
+	}))
+
+	job, err := enqueuer.Enqueue(ctx, "greet", greeting{Name: "Jane"}, worker.OnQueue("emails"))
+	asserts.NoError(err)
+
+	// Jobs on other queues aren't processed.
+	other, err := enqueuer.Enqueue(ctx, "greet", greeting{Name: "John"})
+	asserts.NoError(err)
+
+	runWorker(t, w, func() bool {
+		return jobStatus(t, repo, job.ID) == models.JobStatusSucceeded
+	})
+
+	asserts.Equal("Jane", <-greeted)
+	asserts.Equal(models.JobStatusPending, jobStatus(t, repo, other.ID))
+}
+
+func TestWorker_Run_Failures(t *testing.T) {
+	var (
+		asserts  = assert.New(t)
+		ctx      = context.Background()
+		repo     = &memoryJobRepo{}
+		enqueuer = worker.NewEnqueuer(repo)
+		calls    atomic.Int32
+	)
+
+	w := worker.NewWorker(logger.TestLogger, repo, []worker.Queue{{Name: worker.DefaultQueue, Concurrency: 1}},
+		worker.Options{
+			Backoff:      func(int) time.Duration { return 0 },
+			PollInterval: time.Millisecond,
+		},
+	)
+
+	w.Handle("flaky", worker.HandlerFunc(func(context.Context, *models.Job) error {
+		calls.Add(1)
+		return errors.New("connection refused")
+	}))
+
+	w.Handle("invalid", worker.HandlerFunc(func(context.Context, *models.Job) error {
+		return worker.Permanent(errors.New("invalid recipient"))
+	}))
+
+	w.Handle("panics", worker.HandlerFunc(func(context.Context, *models.Job) error {
+		panic("nil map")
+	}))
+
+	flaky, err := enqueuer.Enqueue(ctx, "flaky", nil, worker.MaxAttempts(3))
+	asserts.NoError(err)
+
+	invalid, err := enqueuer.Enqueue(ctx, "invalid", nil)
+	asserts.NoError(err)
+
+	panics, err := enqueuer.Enqueue(ctx, "panics", nil, worker.MaxAttempts(1))
+	asserts.NoError(err)
+
+	unknown, err := enqueuer.Enqueue(ctx, "unknown", nil)
+	asserts.NoError(err)
+
+	scheduled, err := enqueuer.Enqueue(ctx, "flaky", nil, worker.Delay(time.Hour))
+	asserts.NoError(err)
+
+	runWorker(t, w, func() bool {
+		for _, id := range []string{flaky.ID, invalid.ID, panics.ID, unknown.ID} {
+			if jobStatus(t, repo, id) != models.JobStatusDead {
+				return false
+			}
+		}
+		return true
+	})
+
+	asserts.Equal(int32(3), calls.Load())
+	asserts.Equal(models.JobStatusPending, jobStatus(t, repo, scheduled.ID))
+
+	got, err := repo.FindByID(ctx, unknown.ID)
+	asserts.NoError(err)
+	asserts.Equal(1, got.Attempts)
+	asserts.Equal(worker.ErrNoHandler.Error(), *got.LastError)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := worker.ExponentialBackoff(time.Second, time.Minute)
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: time.Second},
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 4, want: 8 * time.Second},
+		{attempt: 7, want: time.Minute},
+		{attempt: 100, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, backoff(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func TestParseQueues(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []worker.Queue
+		wantErr bool
+	}{
+		{
+			name: "empty uses the default queue",
+			s:    "",
+			want: []worker.Queue{{Name: worker.DefaultQueue, Concurrency: 1}},
+		},
+		{
+			name: "multiple queues",
+			s:    "default:4, emails:2,webhooks",
+			want: []worker.Queue{
+				{Name: "default", Concurrency: 4},
+				{Name: "emails", Concurrency: 2},
+				{Name: "webhooks", Concurrency: 1},
+			},
+		},
+		{
+			name:    "invalid concurrency",
+			s:       "default:0",
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			s:       ":2",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := worker.ParseQueues(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package audit_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/config"
+	"bridge/internal/config/vault"
+	"bridge/internal/factory"
+	"bridge/internal/logger"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/internal/testutils"
+	"bridge/internal/testutils/docker_test"
+	"bridge/services/auth"
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+type testService struct {
+	db *sqlx.DB
+}
+
+var testSvc = &testService{}
+
+func testMain(m *testing.M) int {
+	pgSrv, postgresCleanup, err := docker_test.NewPostgresSrv()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	defer func() {
+		if err = postgresCleanup(); err != nil {
+			log.Fatalln(err)
+		}
+	}()
+
+	vaultClient, vaultCleanup, err := docker_test.NewVaultClient()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	defer func() {
+		if err = vaultCleanup(); err != nil {
+			log.Fatalln(err)
+		}
+	}()
+
+	vaultProvider, err := vault.NewProvider(vaultClient.Address, vaultClient.Path, vaultClient.Token)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	appConfig := config.NewConfig(vaultProvider)
+	if err = appConfig.Load(context.Background(), ""); err != nil {
+		log.Fatalln(err)
+	}
+
+	testSvc.db = pgSrv.DB
+	return m.Run()
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(testMain(m))
+}
+func TestServer_ListAuditEvents(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+		admin   = factory.NewUser()
+	)
+
+	admin.Role = pb.User_ADMIN
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testSvc.db, u, admin)
+	asserts.NoError(err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.UserRepo = userRepo
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr    = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		adminConn  = testutils.TestClientConnWithToken(t, srvAddr, admin.Email, factory.DefaultPassword)
+		userClient = pb.NewAuditServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, u.Email, factory.DefaultPassword),
+		)
+		adminClient = pb.NewAuditServiceClient(adminConn)
+	)
+
+	_, err = userClient.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{})
+	statusFromError, ok := status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrPermissionDenied.Error())
+
+	_, err = pb.NewUserServiceClient(adminConn).SuspendUser(ctx, &pb.SuspendUserRequest{
+		UserId: u.ID,
+		Reason: "suspicious activity",
+	})
+	asserts.NoError(err)
+
+	res, err := adminClient.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{TargetType: "user", TargetId: u.ID})
+	asserts.NoError(err)
+	asserts.Len(res.Events, 1)
+
+	event := res.Events[0]
+	asserts.Equal("user.account_status_change", event.Action)
+	asserts.Equal(admin.ID, event.ActorId)
+	asserts.Equal(pb.User_ADMIN.String(), event.ActorRole)
+	asserts.NotEmpty(event.RequestId)
+	asserts.NotEmpty(event.Hash)
+	asserts.Len(event.Changes, 2)
+
+	// Audited calls are recorded even when they are denied.
+	res, err = adminClient.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{ActorId: u.ID})
+	asserts.NoError(err)
+	asserts.NotEmpty(res.Events)
+	asserts.Equal("/api.v1.AuditService/ListAuditEvents", res.Events[0].Action)
+	asserts.Equal(codes.PermissionDenied.String(), res.Events[0].Code)
+
+	res, err = adminClient.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{ActorId: admin.ID, Limit: 1})
+	asserts.NoError(err)
+	asserts.Len(res.Events, 1)
+	asserts.NotZero(res.NextBeforeSequence)
+
+	now := time.Now()
+	_, err = adminClient.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{
+		From: timestamppb.New(now),
+		To:   timestamppb.New(now.Add(-time.Hour)),
+	})
+	statusFromError, ok = status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrInvalidTimeRange.Error())
+}
@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/services/auth"
+	"context"
+	"github.com/rs/zerolog"
+)
+
+// defaultEventsLimit is the number of events listed when the request doesn't set a limit.
+const defaultEventsLimit = 50
+
+type service struct {
+	pb.UnimplementedAuditServiceServer
+
+	l  zerolog.Logger
+	rs repository.Store
+}
+
+func (s *service) ListAuditEvents(
+	ctx context.Context,
+	req *pb.ListAuditEventsRequest,
+) (*pb.ListAuditEventsResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "list audit events").Logger()
+
+	if _, err := auth.RequireRole(ctx, pb.User_ADMIN); err != nil {
+		l.Err(err).Msg("user not allowed to list audit events")
+		return nil, err
+	}
+
+	filter := models.AuditFilter{
+		ActorID:        req.ActorId,
+		Action:         req.Action,
+		TargetType:     req.TargetType,
+		TargetID:       req.TargetId,
+		BeforeSequence: req.BeforeSequence,
+		Limit:          int(req.Limit),
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultEventsLimit
+	}
+
+	if req.From != nil {
+		from := req.From.AsTime()
+		filter.From = &from
+	}
+
+	if req.To != nil {
+		to := req.To.AsTime()
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		l.Error().Time("from", *filter.From).Time("to", *filter.To).Msg("invalid time range")
+		return nil, rpc_error.ErrInvalidTimeRange
+	}
+
+	events, err := s.rs.AuditRepo.List(ctx, filter)
+	if err != nil {
+		l.Err(err).Msg("failed to list audit events")
+		return nil, rpc_error.ErrServerError
+	}
+
+	res := &pb.ListAuditEventsResponse{Events: events}
+	if len(events) == filter.Limit {
+		res.NextBeforeSequence = events[len(events)-1].Sequence
+	}
+
+	return res, nil
+}
+
+func NewService(l zerolog.Logger, rs repository.Store) pb.AuditServiceServer {
+	return &service{
+		l:  l.With().Str("service", "audit").Logger(),
+		rs: rs,
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+)
+
+// SuspensionExpiredReason is recorded in the status history when an expired suspension is lifted.
+const SuspensionExpiredReason = "Suspension expired."
+
+// liftExpiredSuspension reactivates a user whose suspension has expired.
+func liftExpiredSuspension(ctx context.Context, l zerolog.Logger, rs repository.Store, u *pb.User) (*pb.User, error) {
+	change := &pb.AccountStatusChange{
+		UserId: u.ID,
+		From:   pb.User_SUSPENDED,
+		To:     pb.User_ACTIVE,
+		Reason: SuspensionExpiredReason,
+	}
+
+	err := rs.UserRepo.UpdateAccountStatus(ctx, change)
+	if err == nil {
+		l.Info().Msg("expired suspension lifted")
+
+		u.AccountStatus = pb.User_ACTIVE
+		u.StatusReason = change.Reason
+		u.StatusExpiresAt = nil
+		return u, nil
+	}
+
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	// The status was changed by a concurrent request, use the latest one.
+	return rs.UserRepo.FindByID(ctx, u.ID)
+}
+
+// checkAccountStatus ensures the user is allowed to authenticate. It is used by every authentication path so that
+// they all enforce the same account status rules.
+func checkAccountStatus(ctx context.Context, l zerolog.Logger, rs repository.Store, u *pb.User) (*pb.User, error) {
+	if models.SuspensionExpired(u) {
+		var err error
+		if u, err = liftExpiredSuspension(ctx, l, rs, u); err != nil {
+			l.Err(err).Msg("failed to lift expired suspension")
+			return nil, rpc_error.ErrServerError
+		}
+	}
+
+	switch u.AccountStatus {
+	case pb.User_INACTIVE:
+		l.Error().Msg("inactive user account status")
+		return nil, rpc_error.ErrInactiveAccount
+	case pb.User_SUSPENDED:
+		l.Error().Msg("suspended user account status")
+		return nil, rpc_error.ErrSuspendedAccount
+	}
+
+	return u, nil
+}
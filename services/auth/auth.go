@@ -2,10 +2,11 @@ package auth
 
 import (
 	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/metrics"
 	"bridge/internal/repository"
 	"bridge/internal/rpc_error"
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/metadata"
@@ -45,6 +46,35 @@ var (
 	HeaderAuthorize     = "authorization"
 )
 
+type userCtxKey struct{}
+
+// ContextWithUser returns a copy of ctx carrying the authenticated user.
+func ContextWithUser(ctx context.Context, u *pb.User) context.Context {
+	return context.WithValue(ctx, userCtxKey{}, u)
+}
+
+// UserFromContext returns the authenticated user added to the context by the Authenticator.
+func UserFromContext(ctx context.Context) (*pb.User, bool) {
+	u, ok := ctx.Value(userCtxKey{}).(*pb.User)
+	return u, ok
+}
+
+// RequireRole returns the authenticated user if they have one of the provided roles. rpc_error.ErrPermissionDenied
+// is returned otherwise.
+func RequireRole(ctx context.Context, roles ...pb.User_Role) (*pb.User, error) {
+	u, ok := UserFromContext(ctx)
+	if !ok {
+		return nil, rpc_error.ErrUnauthenticated
+	}
+
+	for _, role := range roles {
+		if u.Role == role {
+			return u, nil
+		}
+	}
+	return nil, rpc_error.ErrPermissionDenied
+}
+
 type authProcessor struct {
 	jwtManager JWTManager
 	l          zerolog.Logger
@@ -53,7 +83,7 @@ type authProcessor struct {
 
 func (ap *authProcessor) Authenticate() AuthenticatorFunc {
 	return func(ctx context.Context) (context.Context, error) {
-		l := ap.l.With().Str("action", "authenticating request").Logger()
+		l := logger.FromContext(ctx, ap.l).With().Str("action", "authenticating request").Logger()
 
 		md, ok := metadata.FromIncomingContext(ctx)
 		if !ok {
@@ -69,7 +99,7 @@ func (ap *authProcessor) Authenticate() AuthenticatorFunc {
 
 		splits := strings.SplitN(header[0], " ", 2)
 		if len(splits) < 2 {
-			l.Error().Interface("header", header).Msg("malformed token")
+			l.Error().Msg("malformed token")
 			return ctx, rpc_error.ErrMissingMalformedToken
 		}
 
@@ -83,32 +113,34 @@ func (ap *authProcessor) Authenticate() AuthenticatorFunc {
 			l.Error().Err(err).Msg("failed to verify token")
 			switch {
 			case errors.Is(err, ErrInvalidToken):
+				metrics.ObserveTokenVerificationFailure("invalid_token")
 				return nil, rpc_error.ErrInvalidToken
 			case errors.Is(err, ErrExpiredToken):
+				metrics.ObserveTokenVerificationFailure("expired_token")
 				return nil, rpc_error.ErrExpiredToken
 			default:
 				return nil, rpc_error.ErrServerError
 			}
 		}
 
-		l = l.With().Interface("claims", claims).Logger()
+		l = l.With().Str("subject", claims.Subject).Logger()
 
 		u, err := ap.rs.UserRepo.FindByID(ctx, claims.User.ID)
 		if err != nil {
 			l.Error().Err(err).Msg("failed to find user")
 
-			if errors.Is(err, sql.ErrNoRows) {
+			if errors.Is(err, repository.ErrNotFound) {
 				return ctx, rpc_error.ErrUnauthenticated
 			}
 			return nil, rpc_error.ErrServerError
 		}
 
-		if u.AccountStatus == pb.User_INACTIVE {
-			l.Error().Msg("inactive user account status")
-			return ctx, rpc_error.ErrInactiveAccount
+		if u, err = checkAccountStatus(ctx, l, ap.rs, u); err != nil {
+			return ctx, err
 		}
 
-		return ctx, nil
+		logger.SetUserID(ctx, u.ID)
+		return ContextWithUser(ctx, u), nil
 	}
 }
 
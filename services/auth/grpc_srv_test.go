@@ -8,6 +8,7 @@ import (
 	"bridge/internal/logger"
 	"bridge/internal/repository"
 	"bridge/internal/rpc_error"
+	"bridge/internal/sender"
 	"bridge/internal/testutils"
 	"bridge/internal/testutils/docker_test"
 	"bridge/internal/utils"
@@ -19,9 +20,13 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"log"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func testAuthClient(t *testing.T, addr string) pb.AuthServiceClient {
@@ -93,6 +98,7 @@ func TestServer_Login(t *testing.T) {
 	asserts.NoError(err)
 
 	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
 	rs.UserRepo = userRepo
 
 	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
@@ -156,6 +162,43 @@ func TestServer_Login(t *testing.T) {
 			},
 			wantErr: rpc_error.ErrInactiveAccount,
 		},
+		{
+			name: "authentication fails if user account is suspended",
+			setup: func(t *testing.T) (*pb.User, string) {
+				t.Helper()
+
+				u := factory.NewUser()
+				u.AccountStatus = pb.User_SUSPENDED
+
+				err = userRepo.Create(ctx, u)
+				asserts.NoError(err)
+
+				return u, factory.DefaultPassword
+			},
+			wantErr: rpc_error.ErrSuspendedAccount,
+		},
+		{
+			name: "expired suspension is lifted on authentication",
+			setup: func(t *testing.T) (*pb.User, string) {
+				t.Helper()
+
+				u := factory.NewUser()
+
+				err = userRepo.Create(ctx, u)
+				asserts.NoError(err)
+
+				err = userRepo.UpdateAccountStatus(ctx, &pb.AccountStatusChange{
+					UserId:    u.ID,
+					From:      pb.User_ACTIVE,
+					To:        pb.User_SUSPENDED,
+					Reason:    "temporary suspension",
+					ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute)),
+				})
+				asserts.NoError(err)
+
+				return u, factory.DefaultPassword
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -186,6 +229,7 @@ func TestServer_Login(t *testing.T) {
 			asserts.NoError(err)
 			asserts.Equal(res.User.ID, accessTokenPayload.Subject)
 			asserts.Equal(res.User, accessTokenPayload.User)
+			asserts.Equal(pb.User_ACTIVE, res.User.AccountStatus)
 		})
 	}
 }
@@ -206,6 +250,7 @@ func TestServer_Register(t *testing.T) {
 	asserts.NoError(err)
 
 	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
 	rs.UserRepo = userRepo
 
 	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
@@ -299,7 +344,7 @@ func TestServer_Register(t *testing.T) {
 					Password:    factory.DefaultPassword,
 				}
 			},
-			wantErr: rpc_error.NewError(codes.InvalidArgument, "invalid RegisterRequest.Name: value length must be at least 3 runes"),
+			wantErr: rpc_error.NewValidationError(nil),
 		},
 	}
 
@@ -336,3 +381,323 @@ func TestServer_Register(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_LoginWithCode(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+	)
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testSvc.db)
+	asserts.NoError(err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.LoginCodeRepo = repository.NewTestLoginCodeRepo(testSvc.db)
+	rs.UserRepo = userRepo
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr    = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		authClient = testAuthClient(t, srvAddr)
+	)
+
+	// sentCode extracts the login code from the last message sent to the recipient.
+	sentCode := func(t *testing.T, to string) string {
+		t.Helper()
+
+		msg, ok := sender.TestSender.Last(to)
+		asserts.True(ok)
+
+		if idx := strings.Index(msg.Body, "code="); idx != -1 {
+			return msg.Body[idx+len("code="):]
+		}
+
+		fields := strings.Fields(msg.Body)
+		return strings.TrimSuffix(fields[4], ".")
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T) *pb.LoginWithCodeRequest
+		wantErr error
+	}{
+		{
+			name: "user can log in with an email magic link",
+			setup: func(t *testing.T) *pb.LoginWithCodeRequest {
+				t.Helper()
+
+				u := factory.NewUser()
+				asserts.NoError(userRepo.Create(ctx, u))
+
+				_, err := authClient.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{
+					Destination: &pb.RequestLoginCodeRequest_Email{Email: u.Email},
+				})
+				asserts.NoError(err)
+
+				return &pb.LoginWithCodeRequest{
+					Destination: &pb.LoginWithCodeRequest_Email{Email: u.Email},
+					Code:        sentCode(t, u.Email),
+				}
+			},
+		},
+		{
+			name: "user can log in with an sms otp",
+			setup: func(t *testing.T) *pb.LoginWithCodeRequest {
+				t.Helper()
+
+				u := factory.NewUser()
+				asserts.NoError(userRepo.Create(ctx, u))
+
+				_, err := authClient.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{
+					Destination: &pb.RequestLoginCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+				})
+				asserts.NoError(err)
+
+				return &pb.LoginWithCodeRequest{
+					Destination: &pb.LoginWithCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+					Code:        sentCode(t, u.PhoneNumber),
+				}
+			},
+		},
+		{
+			name: "login fails if an incorrect code is provided",
+			setup: func(t *testing.T) *pb.LoginWithCodeRequest {
+				t.Helper()
+
+				u := factory.NewUser()
+				asserts.NoError(userRepo.Create(ctx, u))
+
+				_, err := authClient.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{
+					Destination: &pb.RequestLoginCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+				})
+				asserts.NoError(err)
+
+				return &pb.LoginWithCodeRequest{
+					Destination: &pb.LoginWithCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+					Code:        "000000",
+				}
+			},
+			wantErr: rpc_error.ErrInvalidLoginCode,
+		},
+		{
+			name: "login fails once the maximum attempts are exceeded",
+			setup: func(t *testing.T) *pb.LoginWithCodeRequest {
+				t.Helper()
+
+				u := factory.NewUser()
+				asserts.NoError(userRepo.Create(ctx, u))
+
+				_, err := authClient.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{
+					Destination: &pb.RequestLoginCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+				})
+				asserts.NoError(err)
+
+				req := &pb.LoginWithCodeRequest{
+					Destination: &pb.LoginWithCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+					Code:        "abcdef",
+				}
+
+				for i := 0; i < auth.LoginCodeMaxAttempts; i++ {
+					_, err = authClient.LoginWithCode(ctx, req)
+					asserts.Error(err)
+				}
+
+				req.Code = sentCode(t, u.PhoneNumber)
+				return req
+			},
+			wantErr: rpc_error.ErrLoginCodeAttemptsExceeded,
+		},
+		{
+			name: "concurrent attempts cannot exceed the maximum",
+			setup: func(t *testing.T) *pb.LoginWithCodeRequest {
+				t.Helper()
+
+				u := factory.NewUser()
+				asserts.NoError(userRepo.Create(ctx, u))
+
+				_, err := authClient.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{
+					Destination: &pb.RequestLoginCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+				})
+				asserts.NoError(err)
+
+				req := &pb.LoginWithCodeRequest{
+					Destination: &pb.LoginWithCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+					Code:        "abcdef",
+				}
+
+				var wg sync.WaitGroup
+				for i := 0; i < 4*auth.LoginCodeMaxAttempts; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+
+						_, err := authClient.LoginWithCode(ctx, req)
+						asserts.Error(err)
+					}()
+				}
+				wg.Wait()
+
+				return &pb.LoginWithCodeRequest{
+					Destination: req.Destination,
+					Code:        sentCode(t, u.PhoneNumber),
+				}
+			},
+			wantErr: rpc_error.ErrLoginCodeAttemptsExceeded,
+		},
+		{
+			name: "codes requested again during the cooldown are not replaced",
+			setup: func(t *testing.T) *pb.LoginWithCodeRequest {
+				t.Helper()
+
+				u := factory.NewUser()
+				asserts.NoError(userRepo.Create(ctx, u))
+
+				requestCode := func() {
+					_, err := authClient.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{
+						Destination: &pb.RequestLoginCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+					})
+					asserts.NoError(err)
+				}
+
+				requestCode()
+				code := sentCode(t, u.PhoneNumber)
+
+				requestCode()
+				asserts.Equal(code, sentCode(t, u.PhoneNumber))
+
+				return &pb.LoginWithCodeRequest{
+					Destination: &pb.LoginWithCodeRequest_PhoneNumber{PhoneNumber: u.PhoneNumber},
+					Code:        code,
+				}
+			},
+		},
+		{
+			name: "login fails if the user does not exist",
+			setup: func(t *testing.T) *pb.LoginWithCodeRequest {
+				t.Helper()
+
+				return &pb.LoginWithCodeRequest{
+					Destination: &pb.LoginWithCodeRequest_Email{Email: factory.NewUser().Email},
+					Code:        "123456",
+				}
+			},
+			wantErr: rpc_error.ErrInvalidLoginCode,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := tt.setup(t)
+
+			res, err := authClient.LoginWithCode(ctx, req)
+			if wantErr := tt.wantErr; wantErr != nil {
+				statusFromError, ok := status.FromError(err)
+				asserts.True(ok)
+				asserts.EqualError(statusFromError.Err(), wantErr.Error())
+				asserts.Nil(res)
+				return
+			}
+
+			asserts.NoError(err)
+			asserts.NotNil(res)
+
+			accessTokenPayload, err := jwtManager.Verify(res.AccessToken)
+			asserts.NoError(err)
+			asserts.Equal(res.User.ID, accessTokenPayload.Subject)
+
+			// A code can only be used once.
+			_, err = authClient.LoginWithCode(ctx, req)
+			statusFromError, ok := status.FromError(err)
+			asserts.True(ok)
+			asserts.EqualError(statusFromError.Err(), rpc_error.ErrInvalidLoginCode.Error())
+		})
+	}
+}
+
+func TestServer_VerifyContact(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+	)
+
+	// The email was changed and isn't verified yet.
+	u.EmailVerified = false
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testSvc.db, u)
+	asserts.NoError(err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.LoginCodeRepo = repository.NewTestLoginCodeRepo(testSvc.db)
+	rs.UserRepo = userRepo
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr    = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		authClient = testAuthClient(t, srvAddr)
+		userClient = pb.NewAuthServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, u.Email, factory.DefaultPassword),
+		)
+	)
+
+	// No login code is sent to an unverified email.
+	_, err = authClient.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{
+		Destination: &pb.RequestLoginCodeRequest_Email{Email: u.Email},
+	})
+	asserts.NoError(err)
+
+	_, ok := sender.TestSender.Last(u.Email)
+	asserts.False(ok)
+
+	// The verification codes are only sent to the authenticated users.
+	_, err = authClient.RequestVerificationCode(ctx, &pb.RequestVerificationCodeRequest{
+		Channel: pb.ContactChannel_CONTACT_CHANNEL_EMAIL,
+	})
+	asserts.Equal(codes.Unauthenticated, status.Code(err))
+
+	_, err = userClient.RequestVerificationCode(ctx, &pb.RequestVerificationCodeRequest{
+		Channel: pb.ContactChannel_CONTACT_CHANNEL_EMAIL,
+	})
+	asserts.NoError(err)
+
+	msg, ok := sender.TestSender.Last(u.Email)
+	asserts.True(ok)
+
+	code := strings.Fields(msg.Body[strings.Index(msg.Body, "code is ")+len("code is "):])[0]
+	code = strings.TrimSuffix(code, ".")
+
+	_, err = userClient.VerifyContact(ctx, &pb.VerifyContactRequest{
+		Channel: pb.ContactChannel_CONTACT_CHANNEL_EMAIL,
+		Code:    "000000",
+	})
+	statusFromError, ok := status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrInvalidLoginCode.Error())
+
+	res, err := userClient.VerifyContact(ctx, &pb.VerifyContactRequest{
+		Channel: pb.ContactChannel_CONTACT_CHANNEL_EMAIL,
+		Code:    code,
+	})
+	asserts.NoError(err)
+	asserts.True(res.User.EmailVerified)
+
+	_, err = userClient.RequestVerificationCode(ctx, &pb.RequestVerificationCodeRequest{
+		Channel: pb.ContactChannel_CONTACT_CHANNEL_EMAIL,
+	})
+	statusFromError, ok = status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrContactAlreadyVerified.Error())
+}
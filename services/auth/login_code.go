@@ -0,0 +1,280 @@
+package auth
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/config"
+	"bridge/internal/logger"
+	"bridge/internal/metrics"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/internal/sender"
+	"bridge/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/url"
+	"time"
+)
+
+const (
+	// LoginCodeMaxAttempts is the number of attempts after which a login code can no longer be used.
+	LoginCodeMaxAttempts = 5
+	// LoginCodeCooldown is the time a user waits before requesting another login code through the same channel.
+	LoginCodeCooldown = time.Minute
+
+	magicLinkCodeLength = 32
+	magicLinkTTL        = 15 * time.Minute
+	otpCodeLength       = 6
+	otpTTL              = 5 * time.Minute
+)
+
+// MagicLinkPath is the path of the page opened by the login links sent by email. The email and the code are in the
+// fragment of the link, for example /v1/auth/magic-link#email=jane%40example.com&code=..., so that they're never sent
+// to the server, nor to the link scanners and prefetchers which open the links before the users.
+const MagicLinkPath = "/v1/auth/magic-link"
+
+// loginDestination resolves the user and the delivery channel from the request destination.
+type loginDestination interface {
+	GetEmail() string
+	GetPhoneNumber() string
+}
+
+// errContactNotVerified is returned when the destination of a login code wasn't verified since it was changed. It's
+// handled like an unknown destination, since it could have been set to someone else's.
+var errContactNotVerified = fmt.Errorf("%w: contact not verified", repository.ErrNotFound)
+
+func (s *service) findLoginUser(ctx context.Context, dst loginDestination) (*pb.User, models.LoginChannel, error) {
+	var (
+		u       *pb.User
+		channel = models.LoginChannelSMS
+		err     error
+	)
+
+	if email := dst.GetEmail(); email != "" {
+		channel = models.LoginChannelEmail
+		u, err = s.rs.UserRepo.FindByEmail(ctx, email)
+	} else {
+		u, err = s.rs.UserRepo.FindByPhoneNumber(ctx, dst.GetPhoneNumber())
+	}
+
+	if err == nil && !contactVerified(u, channel) {
+		return nil, channel, errContactNotVerified
+	}
+	return u, channel, err
+}
+
+func (s *service) newLoginCodeMessage(channel models.LoginChannel, u *pb.User) (sender.Message, string, error) {
+	if channel == models.LoginChannelEmail {
+		code, err := utils.SecureString(magicLinkCodeLength)
+		if err != nil {
+			return sender.Message{}, "", err
+		}
+
+		link := fmt.Sprintf(
+			"%s%s#email=%s&code=%s",
+			config.EnvKey.URL,
+			MagicLinkPath,
+			url.QueryEscape(u.Email),
+			code,
+		)
+
+		return sender.Message{
+			To:      u.Email,
+			Subject: fmt.Sprintf("Your %s login link", config.EnvKey.Name),
+			Body: fmt.Sprintf(
+				"Hi %s, use the link below to log in. It expires in %d minutes.\n\n%s",
+				u.Name, int(magicLinkTTL.Minutes()), link,
+			),
+		}, code, nil
+	}
+
+	code, err := utils.SecureDigits(otpCodeLength)
+	if err != nil {
+		return sender.Message{}, "", err
+	}
+
+	return sender.Message{
+		To: u.PhoneNumber,
+		Body: fmt.Sprintf(
+			"Your %s login code is %s. It expires in %d minutes.",
+			config.EnvKey.Name, code, int(otpTTL.Minutes()),
+		),
+	}, code, nil
+}
+
+func (s *service) RequestLoginCode(
+	ctx context.Context,
+	req *pb.RequestLoginCodeRequest,
+) (*pb.RequestLoginCodeResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "request login code").Logger()
+
+	u, channel, err := s.findLoginUser(ctx, req)
+
+	ttl := otpTTL
+	if channel == models.LoginChannelEmail {
+		ttl = magicLinkTTL
+	}
+
+	var (
+		now = time.Now()
+		res = &pb.RequestLoginCodeResponse{ExpiresAt: timestamppb.New(now.Add(ttl))}
+	)
+
+	l = l.With().Str("channel", string(channel)).Logger()
+
+	// The response is the same whether the user exists or not to avoid leaking registered accounts.
+	if err != nil {
+		l.Err(err).Msg("failed to find user")
+		if errors.Is(err, repository.ErrNotFound) {
+			return res, nil
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	l = l.With().Str("user_id", u.ID).Logger()
+
+	if models.AccountStatusBlocksAuth(u.AccountStatus) && !models.SuspensionExpired(u) {
+		l.Error().Stringer("account_status", u.AccountStatus).Msg("account status blocks authentication")
+		return res, nil
+	}
+
+	msg, code, err := s.newLoginCodeMessage(channel, u)
+	if err != nil {
+		l.Err(err).Msg("failed to generate login code")
+		return nil, rpc_error.ErrServerError
+	}
+
+	codeHash, err := utils.HashString(code)
+	if err != nil {
+		l.Err(err).Msg("failed to hash login code")
+		return nil, rpc_error.ErrServerError
+	}
+
+	loginCode := &models.LoginCode{
+		UserID:    u.ID,
+		Channel:   channel,
+		CodeHash:  codeHash,
+		ExpiresAt: res.ExpiresAt.AsTime(),
+		CreatedAt: now,
+	}
+
+	// The previous codes are replaced so that only the last one sent can be used.
+	if err = s.rs.LoginCodeRepo.Replace(ctx, loginCode, LoginCodeCooldown); err != nil {
+		l.Err(err).Msg("failed to replace login code")
+		if errors.Is(err, repository.ErrLoginCodeCooldown) {
+			return res, nil
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	codeSender := s.senders.SMS
+	if channel == models.LoginChannelEmail {
+		codeSender = s.senders.Email
+	}
+
+	if err = codeSender.Send(ctx, msg); err != nil {
+		l.Err(err).Msg("failed to send login code")
+		return nil, rpc_error.ErrServerError
+	}
+
+	l.Info().Msg("login code sent successfully")
+	return res, nil
+}
+
+func (s *service) verifyLoginCode(
+	ctx context.Context,
+	l zerolog.Logger,
+	u *pb.User,
+	channel models.LoginChannel,
+	code string,
+) error {
+	loginCode, err := s.rs.LoginCodeRepo.FindActive(ctx, u.ID, channel)
+	if err != nil {
+		l.Err(err).Msg("failed to find active login code")
+		if errors.Is(err, repository.ErrNotFound) {
+			return rpc_error.ErrInvalidLoginCode
+		}
+		return rpc_error.ErrServerError
+	}
+
+	l = l.With().Str("login_code_id", loginCode.ID).Logger()
+
+	if loginCode.Expired() {
+		l.Error().Msg("login code expired")
+		return rpc_error.ErrInvalidLoginCode
+	}
+
+	// The attempt is counted before the code is compared so that concurrent guesses can't exceed the maximum.
+	attempts, err := s.rs.LoginCodeRepo.IncrementAttempts(ctx, loginCode.ID, LoginCodeMaxAttempts)
+	if err != nil {
+		l.Err(err).Msg("failed to increment attempts")
+		if errors.Is(err, repository.ErrNotFound) {
+			return rpc_error.ErrLoginCodeAttemptsExceeded
+		}
+		return rpc_error.ErrServerError
+	}
+
+	if !utils.CompareHash(loginCode.CodeHash, code) {
+		l.Error().Int("attempts", attempts).Msg("login code hash mismatch")
+		return rpc_error.ErrInvalidLoginCode
+	}
+
+	if err = s.rs.LoginCodeRepo.Consume(ctx, loginCode.ID); err != nil {
+		l.Err(err).Msg("failed to consume login code")
+		if errors.Is(err, repository.ErrNotFound) {
+			return rpc_error.ErrInvalidLoginCode
+		}
+		return rpc_error.ErrServerError
+	}
+
+	return nil
+}
+
+func (s *service) LoginWithCode(
+	ctx context.Context,
+	req *pb.LoginWithCodeRequest,
+) (res *pb.LoginResponse, err error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "login user with code").Logger()
+
+	defer func() {
+		observeLogin(metrics.LoginMethodCode, err)
+	}()
+
+	u, channel, err := s.findLoginUser(ctx, req)
+
+	l = l.With().Str("channel", string(channel)).Logger()
+
+	if err != nil {
+		l.Err(err).Msg("failed to find user")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, rpc_error.ErrInvalidLoginCode
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	l = l.With().Str("user_id", u.ID).Logger()
+
+	if err = s.verifyLoginCode(ctx, l, u, channel, req.Code); err != nil {
+		return nil, err
+	}
+
+	if u, err = checkAccountStatus(ctx, l, s.rs, u); err != nil {
+		return nil, err
+	}
+
+	token, err := s.jwtManager.Generate(u, accessTokenDuration)
+	if err != nil {
+		l.Err(err).Msg("failed to generate access token")
+		return nil, rpc_error.ErrServerError
+	}
+
+	l.Info().Msg("user authenticated with login code successfully")
+
+	return &pb.LoginResponse{
+		User:        u,
+		AccessToken: token,
+	}, nil
+}
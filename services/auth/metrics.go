@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"bridge/internal/metrics"
+	"bridge/internal/rpc_error"
+	"errors"
+)
+
+// loginFailureReason returns the metrics label for the error returned by a failed login.
+func loginFailureReason(err error) string {
+	switch {
+	case errors.Is(err, rpc_error.ErrUnauthenticated):
+		return "invalid_credentials"
+	case errors.Is(err, rpc_error.ErrInvalidLoginCode):
+		return "invalid_login_code"
+	case errors.Is(err, rpc_error.ErrLoginCodeAttemptsExceeded):
+		return "attempts_exceeded"
+	case errors.Is(err, rpc_error.ErrInactiveAccount):
+		return "inactive_account"
+	case errors.Is(err, rpc_error.ErrSuspendedAccount):
+		return "suspended_account"
+	default:
+		return "server_error"
+	}
+}
+
+// observeLogin records the outcome of a login using method.
+func observeLogin(method string, err error) {
+	if err != nil {
+		metrics.ObserveLogin(method, metrics.LoginFailed, loginFailureReason(err))
+		return
+	}
+	metrics.ObserveLogin(method, metrics.LoginSucceeded, "")
+}
@@ -2,36 +2,48 @@ package auth
 
 import (
 	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/metrics"
 	"bridge/internal/repository"
 	"bridge/internal/rpc_error"
+	"bridge/internal/sender"
 	"bridge/internal/utils"
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"time"
 )
 
+// accessTokenDuration is how long issued access tokens are valid for.
+const accessTokenDuration = 60 * time.Minute
+
+// authenticatedMethods are the methods of the service which require an authenticated user, the others are public.
+var authenticatedMethods = map[string]struct{}{
+	"/api.v1.AuthService/RequestVerificationCode": {},
+	"/api.v1.AuthService/VerifyContact":           {},
+}
+
 type service struct {
-	OverrideAuthFunc
 	pb.UnimplementedAuthServiceServer
 
-	jwtManager JWTManager
-	l          zerolog.Logger
-	rs         repository.Store
+	authenticate AuthenticatorFunc
+	jwtManager   JWTManager
+	l            zerolog.Logger
+	rs           repository.Store
+	senders      sender.Senders
 }
 
-func (s *service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
-	var (
-		sanitizedReq = &pb.RegisterRequest{
-			Name:        req.Name,
-			Email:       req.Email,
-			PhoneNumber: req.PhoneNumber,
-		}
+// AuthenticatorFuncOverride lets the public methods through and authenticates the calls to authenticatedMethods.
+func (s *service) AuthenticatorFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
+	if _, ok := authenticatedMethods[fullMethodName]; ok {
+		return s.authenticate(ctx)
+	}
+	return ctx, nil
+}
 
-		l = s.l.With().Str("action", "register user").Interface("req", sanitizedReq).Logger()
-	)
+func (s *service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "register user").Interface("req", req).Logger()
 
 	if req.Password != req.ConfirmPassword {
 		l.Err(errors.New("passwords do not match")).Msg("password mismatch")
@@ -57,22 +69,25 @@ func (s *service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Re
 	user.Name = req.Name
 	user.Password = passwordHash
 	user.AccountStatus = pb.User_PENDING_ACTIVE
+	user.EmailVerified = true
+	user.PhoneNumberVerified = true
 	user.CreatedAt = timestamppb.New(time.Now())
 	user.UpdatedAt = timestamppb.New(time.Now())
 
 	if err = s.rs.UserRepo.Create(ctx, user); err != nil {
 		l.Err(err).Msg("failed to create user")
-		return nil, utils.ParseDBError(err)
+		return nil, repository.RPCError(err)
 	}
 
 	l = l.With().Interface("user", user).Logger()
 
-	token, err := s.jwtManager.Generate(user, 60*time.Minute)
+	token, err := s.jwtManager.Generate(user, accessTokenDuration)
 	if err != nil {
 		l.Err(err).Msg("failed to generate access token")
 		return nil, rpc_error.ErrServerError
 	}
 
+	metrics.ObserveRegistration()
 	l.Info().Msg("user registered successfully")
 
 	return &pb.RegisterResponse{
@@ -81,13 +96,17 @@ func (s *service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Re
 	}, nil
 }
 
-func (s *service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	l := s.l.With().Str("action", "login user").Str("email", req.Email).Logger()
+func (s *service) Login(ctx context.Context, req *pb.LoginRequest) (res *pb.LoginResponse, err error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "login user").Interface("req", req).Logger()
+
+	defer func() {
+		observeLogin(metrics.LoginMethodPassword, err)
+	}()
 
 	credentials, err := s.rs.UserRepo.Authenticate(ctx, req.GetEmail())
 	if err != nil {
 		l.Err(err).Msg("failed to authenticate user")
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, repository.ErrNotFound) {
 			return nil, rpc_error.ErrUnauthenticated
 		}
 		return nil, rpc_error.ErrServerError
@@ -106,11 +125,11 @@ func (s *service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginRes
 
 	l = l.With().Interface("user", user).Logger()
 
-	if user.AccountStatus == pb.User_INACTIVE {
-		return nil, rpc_error.ErrInactiveAccount
+	if user, err = checkAccountStatus(ctx, l, s.rs, user); err != nil {
+		return nil, err
 	}
 
-	token, err := s.jwtManager.Generate(user, 60*time.Minute)
+	token, err := s.jwtManager.Generate(user, accessTokenDuration)
 	if err != nil {
 		l.Err(err).Msg("failed to generate access token")
 		return nil, rpc_error.ErrServerError
@@ -124,10 +143,17 @@ func (s *service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginRes
 	}, nil
 }
 
-func NewService(jwtManager JWTManager, l zerolog.Logger, rs repository.Store) pb.AuthServiceServer {
+func NewService(
+	jwtManager JWTManager,
+	l zerolog.Logger,
+	rs repository.Store,
+	senders sender.Senders,
+) pb.AuthServiceServer {
 	return &service{
-		jwtManager: jwtManager,
-		l:          l.With().Str("service", "auth").Logger(),
-		rs:         rs,
+		authenticate: NewAuthProcessor(jwtManager, l, rs).Authenticate(),
+		jwtManager:   jwtManager,
+		l:            l.With().Str("service", "auth").Logger(),
+		rs:           rs,
+		senders:      senders,
 	}
 }
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/config"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/internal/sender"
+	"bridge/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// verificationChannels are the channels the verification codes of the contacts are sent through. The codes are stored
+// with the login codes, which can't be requested for an unverified contact.
+var verificationChannels = map[pb.ContactChannel]models.LoginChannel{
+	pb.ContactChannel_CONTACT_CHANNEL_EMAIL:        models.LoginChannelEmail,
+	pb.ContactChannel_CONTACT_CHANNEL_PHONE_NUMBER: models.LoginChannelSMS,
+}
+
+// contactVerified returns whether the contact of u for channel was verified.
+func contactVerified(u *pb.User, channel models.LoginChannel) bool {
+	if channel == models.LoginChannelEmail {
+		return u.EmailVerified
+	}
+	return u.PhoneNumberVerified
+}
+
+// contact returns the email or phone number of u for channel.
+func contact(u *pb.User, channel models.LoginChannel) string {
+	if channel == models.LoginChannelEmail {
+		return u.Email
+	}
+	return u.PhoneNumber
+}
+
+func newVerificationMessage(channel models.LoginChannel, u *pb.User, code string) sender.Message {
+	body := fmt.Sprintf(
+		"Your %s verification code is %s. It expires in %d minutes.",
+		config.EnvKey.Name, code, int(otpTTL.Minutes()),
+	)
+
+	if channel == models.LoginChannelEmail {
+		return sender.Message{
+			To:      u.Email,
+			Subject: fmt.Sprintf("Verify your %s email", config.EnvKey.Name),
+			Body:    fmt.Sprintf("Hi %s, %s", u.Name, body),
+		}
+	}
+
+	return sender.Message{To: u.PhoneNumber, Body: body}
+}
+
+func (s *service) RequestVerificationCode(
+	ctx context.Context,
+	req *pb.RequestVerificationCodeRequest,
+) (*pb.RequestVerificationCodeResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "request verification code").Logger()
+
+	u, ok := UserFromContext(ctx)
+	if !ok {
+		l.Error().Msg("missing authenticated user")
+		return nil, rpc_error.ErrUnauthenticated
+	}
+
+	channel := verificationChannels[req.Channel]
+	l = l.With().Str("user_id", u.ID).Str("channel", string(channel)).Logger()
+
+	if contactVerified(u, channel) {
+		l.Error().Msg("contact already verified")
+		return nil, rpc_error.ErrContactAlreadyVerified
+	}
+
+	code, err := utils.SecureDigits(otpCodeLength)
+	if err != nil {
+		l.Err(err).Msg("failed to generate verification code")
+		return nil, rpc_error.ErrServerError
+	}
+
+	codeHash, err := utils.HashString(code)
+	if err != nil {
+		l.Err(err).Msg("failed to hash verification code")
+		return nil, rpc_error.ErrServerError
+	}
+
+	now := time.Now()
+	verificationCode := &models.LoginCode{
+		UserID:    u.ID,
+		Channel:   channel,
+		CodeHash:  codeHash,
+		ExpiresAt: now.Add(otpTTL),
+		CreatedAt: now,
+	}
+
+	if err = s.rs.LoginCodeRepo.Replace(ctx, verificationCode, LoginCodeCooldown); err != nil {
+		l.Err(err).Msg("failed to replace verification code")
+		if errors.Is(err, repository.ErrLoginCodeCooldown) {
+			return nil, rpc_error.ErrRateLimited
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	codeSender := s.senders.SMS
+	if channel == models.LoginChannelEmail {
+		codeSender = s.senders.Email
+	}
+
+	if err = codeSender.Send(ctx, newVerificationMessage(channel, u, code)); err != nil {
+		l.Err(err).Msg("failed to send verification code")
+		return nil, rpc_error.ErrServerError
+	}
+
+	l.Info().Msg("verification code sent successfully")
+	return &pb.RequestVerificationCodeResponse{ExpiresAt: timestamppb.New(verificationCode.ExpiresAt)}, nil
+}
+
+func (s *service) VerifyContact(ctx context.Context, req *pb.VerifyContactRequest) (*pb.VerifyContactResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "verify contact").Logger()
+
+	u, ok := UserFromContext(ctx)
+	if !ok {
+		l.Error().Msg("missing authenticated user")
+		return nil, rpc_error.ErrUnauthenticated
+	}
+
+	channel := verificationChannels[req.Channel]
+	l = l.With().Str("user_id", u.ID).Str("channel", string(channel)).Logger()
+
+	if contactVerified(u, channel) {
+		l.Error().Msg("contact already verified")
+		return nil, rpc_error.ErrContactAlreadyVerified
+	}
+
+	if err := s.verifyLoginCode(ctx, l, u, channel, req.Code); err != nil {
+		return nil, err
+	}
+
+	// The contact is only verified if it wasn't changed since the code was sent to it.
+	if err := s.rs.UserRepo.VerifyContact(ctx, u.ID, channel, contact(u, channel)); err != nil {
+		l.Err(err).Msg("failed to verify contact")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, rpc_error.ErrConcurrentModification
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	if channel == models.LoginChannelEmail {
+		u.EmailVerified = true
+	} else {
+		u.PhoneNumberVerified = true
+	}
+
+	l.Info().Msg("contact verified successfully")
+	return &pb.VerifyContactResponse{User: u}, nil
+}
@@ -0,0 +1,79 @@
+package kyc
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/services/auth"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+)
+
+// RequiredMethods are the RPCs that can only be called by users with an approved KYC submission. The webhook
+// subscriptions send the events, which hold user data, to the url set by their creator.
+var RequiredMethods = []string{
+	"/api.v1.WebhookService/CreateWebhookSubscription",
+	"/api.v1.WebhookService/UpdateWebhookSubscription",
+}
+
+// Enforcer ensures the authenticated user has an approved KYC submission before calling specific RPCs.
+//
+// Enforce returns rpc_error.ErrKYCRequired if the method requires an approved KYC submission and the authenticated
+// user does not have one.
+type Enforcer interface {
+	Enforce(ctx context.Context, fullMethod string) error
+}
+
+type enforcer struct {
+	l       zerolog.Logger
+	methods map[string]struct{}
+	rs      repository.Store
+}
+
+func (e *enforcer) Enforce(ctx context.Context, fullMethod string) error {
+	if _, ok := e.methods[fullMethod]; !ok {
+		return nil
+	}
+
+	l := logger.FromContext(ctx, e.l).With().Str("action", "enforce kyc").Str("method", fullMethod).Logger()
+
+	u, ok := auth.UserFromContext(ctx)
+	if !ok {
+		l.Error().Msg("missing authenticated user")
+		return rpc_error.ErrUnauthenticated
+	}
+
+	l = l.With().Str("user_id", u.ID).Logger()
+
+	submission, err := e.rs.KYCRepo.FindLatestByUserID(ctx, u.ID)
+	if err != nil {
+		l.Err(err).Msg("failed to find latest submission")
+		if errors.Is(err, repository.ErrNotFound) {
+			return rpc_error.ErrKYCRequired
+		}
+		return rpc_error.ErrServerError
+	}
+
+	if submission.Status != pb.KYCSubmission_APPROVED {
+		l.Error().Stringer("status", submission.Status).Msg("kyc not approved")
+		return rpc_error.ErrKYCRequired
+	}
+
+	return nil
+}
+
+// NewEnforcer creates an Enforcer for the provided full method names.
+func NewEnforcer(l zerolog.Logger, rs repository.Store, methods ...string) Enforcer {
+	set := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		set[method] = struct{}{}
+	}
+
+	return &enforcer{
+		l:       l.With().Str("service", "kyc enforcer").Logger(),
+		methods: set,
+		rs:      rs,
+	}
+}
@@ -0,0 +1,250 @@
+package kyc_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/config"
+	"bridge/internal/config/vault"
+	"bridge/internal/factory"
+	"bridge/internal/logger"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/internal/testutils"
+	"bridge/internal/testutils/docker_test"
+	"bridge/services/auth"
+	"bridge/services/kyc"
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/status"
+	"log"
+	"os"
+	"testing"
+)
+
+type testService struct {
+	db *sqlx.DB
+}
+
+var testSvc = &testService{}
+
+func testMain(m *testing.M) int {
+	pgSrv, postgresCleanup, err := docker_test.NewPostgresSrv()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	defer func() {
+		if err = postgresCleanup(); err != nil {
+			log.Fatalln(err)
+		}
+	}()
+
+	vaultClient, vaultCleanup, err := docker_test.NewVaultClient()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	defer func() {
+		if err = vaultCleanup(); err != nil {
+			log.Fatalln(err)
+		}
+	}()
+
+	vaultProvider, err := vault.NewProvider(vaultClient.Address, vaultClient.Path, vaultClient.Token)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	appConfig := config.NewConfig(vaultProvider)
+	if err = appConfig.Load(context.Background(), ""); err != nil {
+		log.Fatalln(err)
+	}
+
+	testSvc.db = pgSrv.DB
+	return m.Run()
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(testMain(m))
+}
+
+func testStore(t *testing.T, users ...*pb.User) repository.Store {
+	t.Helper()
+
+	userRepo, err := repository.NewTestUserRepo(context.Background(), testSvc.db, users...)
+	assert.NoError(t, err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.KYCRepo = repository.NewTestKYCRepo(testSvc.db)
+	rs.UserRepo = userRepo
+	return rs
+}
+
+func TestServer_KYCWorkflow(t *testing.T) {
+	var (
+		asserts  = assert.New(t)
+		ctx      = context.Background()
+		u        = factory.NewUser()
+		reviewer = factory.NewUser()
+	)
+
+	reviewer.Role = pb.User_STAFF
+
+	rs := testStore(t, u, reviewer)
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr    = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		userClient = pb.NewKYCServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, u.Email, factory.DefaultPassword),
+		)
+		reviewerClient = pb.NewKYCServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, reviewer.Email, factory.DefaultPassword),
+		)
+		submission = factory.NewKYCSubmission(u.ID)
+	)
+
+	submitRes, err := userClient.SubmitKYC(ctx, &pb.SubmitKYCRequest{
+		IdNumber:  submission.IdNumber,
+		KraPin:    submission.KraPin,
+		Documents: submission.Documents,
+	})
+	asserts.NoError(err)
+	asserts.Equal(pb.KYCSubmission_SUBMITTED, submitRes.Submission.Status)
+
+	submissionID := submitRes.Submission.ID
+
+	_, err = userClient.SubmitKYC(ctx, &pb.SubmitKYCRequest{
+		IdNumber:  submission.IdNumber,
+		KraPin:    submission.KraPin,
+		Documents: submission.Documents,
+	})
+	statusFromError, ok := status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrKYCSubmissionExists.Error())
+
+	_, err = userClient.ReviewKYC(ctx, &pb.ReviewKYCRequest{
+		SubmissionId: submissionID,
+		Decision:     pb.KYCSubmission_APPROVED,
+		Notes:        "approving my own submission",
+	})
+	statusFromError, ok = status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrPermissionDenied.Error())
+
+	startRes, err := reviewerClient.StartKYCReview(ctx, &pb.StartKYCReviewRequest{SubmissionId: submissionID})
+	asserts.NoError(err)
+	asserts.Equal(pb.KYCSubmission_UNDER_REVIEW, startRes.Submission.Status)
+	asserts.Equal(reviewer.ID, startRes.Submission.ReviewerId)
+
+	reviewRes, err := reviewerClient.ReviewKYC(ctx, &pb.ReviewKYCRequest{
+		SubmissionId: submissionID,
+		Decision:     pb.KYCSubmission_APPROVED,
+		Notes:        "documents verified",
+	})
+	asserts.NoError(err)
+	asserts.Equal(pb.KYCSubmission_APPROVED, reviewRes.Submission.Status)
+	asserts.Equal("documents verified", reviewRes.Submission.ReviewNotes)
+
+	statusRes, err := userClient.GetKYCStatus(ctx, &pb.GetKYCStatusRequest{})
+	asserts.NoError(err)
+	asserts.Equal(pb.KYCSubmission_APPROVED, statusRes.Submission.Status)
+
+	_, err = reviewerClient.ReviewKYC(ctx, &pb.ReviewKYCRequest{
+		SubmissionId: submissionID,
+		Decision:     pb.KYCSubmission_REJECTED,
+		Notes:        "changed my mind",
+	})
+	statusFromError, ok = status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrInvalidKYCTransition.Error())
+}
+
+func TestServer_SubmitKYC_Validation(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+		rs      = testStore(t, u)
+	)
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr    = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		userClient = pb.NewKYCServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, u.Email, factory.DefaultPassword),
+		)
+		submission = factory.NewKYCSubmission(u.ID)
+	)
+
+	tests := []struct {
+		name string
+		req  *pb.SubmitKYCRequest
+	}{
+		{
+			name: "id number must be 7 or 8 digits",
+			req: &pb.SubmitKYCRequest{
+				IdNumber:  "12AB56",
+				KraPin:    submission.KraPin,
+				Documents: submission.Documents,
+			},
+		},
+		{
+			name: "kra pin must match the issued format",
+			req: &pb.SubmitKYCRequest{
+				IdNumber:  submission.IdNumber,
+				KraPin:    "123456789",
+				Documents: submission.Documents,
+			},
+		},
+		{
+			name: "at least one document is required",
+			req: &pb.SubmitKYCRequest{
+				IdNumber: submission.IdNumber,
+				KraPin:   submission.KraPin,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := userClient.SubmitKYC(ctx, tt.req)
+			asserts.Nil(res)
+
+			statusFromError, ok := status.FromError(err)
+			asserts.True(ok)
+			asserts.Equal("InvalidArgument", statusFromError.Code().String())
+		})
+	}
+}
+
+func TestEnforcer_Enforce(t *testing.T) {
+	var (
+		asserts  = assert.New(t)
+		ctx      = context.Background()
+		u        = factory.NewUser()
+		verified = factory.NewUser()
+		rs       = testStore(t, u, verified)
+		method   = kyc.RequiredMethods[0]
+		enforcer = kyc.NewEnforcer(logger.TestLogger, rs, method)
+	)
+
+	submission := factory.NewKYCSubmission(verified.ID)
+	asserts.NoError(rs.KYCRepo.Create(ctx, submission))
+
+	submission.Status = pb.KYCSubmission_APPROVED
+	asserts.NoError(rs.KYCRepo.UpdateStatus(ctx, submission, pb.KYCSubmission_SUBMITTED))
+
+	asserts.NoError(enforcer.Enforce(auth.ContextWithUser(ctx, u), "/api.v1.UserService/Update"))
+	asserts.ErrorIs(enforcer.Enforce(auth.ContextWithUser(ctx, u), method), rpc_error.ErrKYCRequired)
+	asserts.NoError(enforcer.Enforce(auth.ContextWithUser(ctx, verified), method))
+	asserts.ErrorIs(enforcer.Enforce(ctx, method), rpc_error.ErrUnauthenticated)
+}
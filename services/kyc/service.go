@@ -0,0 +1,199 @@
+package kyc
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/audit"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/services/auth"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/proto"
+)
+
+// kycAuditIgnored are the submission fields left out of the audit trail.
+var kycAuditIgnored = []string{"created_at", "updated_at"}
+
+// reviewerRoles are the roles allowed to review KYC submissions.
+var reviewerRoles = []pb.User_Role{pb.User_STAFF, pb.User_ADMIN}
+
+type service struct {
+	pb.UnimplementedKYCServiceServer
+
+	l  zerolog.Logger
+	rs repository.Store
+}
+
+func (s *service) SubmitKYC(ctx context.Context, req *pb.SubmitKYCRequest) (*pb.SubmitKYCResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "submit kyc").Int("documents", len(req.Documents)).Logger()
+
+	u, ok := auth.UserFromContext(ctx)
+	if !ok {
+		l.Error().Msg("missing authenticated user")
+		return nil, rpc_error.ErrUnauthenticated
+	}
+
+	l = l.With().Str("user_id", u.ID).Logger()
+
+	latest, err := s.rs.KYCRepo.FindLatestByUserID(ctx, u.ID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		l.Err(err).Msg("failed to find latest submission")
+		return nil, rpc_error.ErrServerError
+	}
+
+	if latest != nil && (models.KYCPending(latest.Status) || latest.Status == pb.KYCSubmission_APPROVED) {
+		l.Error().Str("submission_id", latest.ID).Stringer("status", latest.Status).Msg("submission exists")
+		return nil, rpc_error.ErrKYCSubmissionExists
+	}
+
+	submission := &pb.KYCSubmission{
+		UserId:    u.ID,
+		IdNumber:  req.IdNumber,
+		KraPin:    req.KraPin,
+		Documents: req.Documents,
+		Status:    pb.KYCSubmission_SUBMITTED,
+	}
+
+	events := audit.Record(ctx, audit.ActionKYCSubmit, audit.TargetKYCSubmission, "", nil, submission, kycAuditIgnored...)
+
+	if err = s.rs.KYCRepo.Create(ctx, submission, events...); err != nil {
+		l.Err(err).Msg("failed to create submission")
+		return nil, rpc_error.ErrServerError
+	}
+
+	l.Info().Str("submission_id", submission.ID).Msg("kyc submitted successfully")
+	return &pb.SubmitKYCResponse{Submission: submission}, nil
+}
+
+func (s *service) GetKYCStatus(ctx context.Context, _ *pb.GetKYCStatusRequest) (*pb.GetKYCStatusResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "get kyc status").Logger()
+
+	u, ok := auth.UserFromContext(ctx)
+	if !ok {
+		l.Error().Msg("missing authenticated user")
+		return nil, rpc_error.ErrUnauthenticated
+	}
+
+	submission, err := s.rs.KYCRepo.FindLatestByUserID(ctx, u.ID)
+	if err != nil {
+		l.Err(err).Str("user_id", u.ID).Msg("failed to find latest submission")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, rpc_error.ErrKYCSubmissionNotFound
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	return &pb.GetKYCStatusResponse{Submission: submission}, nil
+}
+
+// transition moves a submission to the provided status on behalf of the reviewer.
+func (s *service) transition(
+	ctx context.Context,
+	l zerolog.Logger,
+	submissionID string,
+	to pb.KYCSubmission_Status,
+	notes string,
+) (*pb.KYCSubmission, error) {
+	reviewer, err := auth.RequireRole(ctx, reviewerRoles...)
+	if err != nil {
+		l.Err(err).Msg("user not allowed to review kyc")
+		return nil, err
+	}
+
+	l = l.With().Str("reviewer_id", reviewer.ID).Logger()
+
+	submission, err := s.rs.KYCRepo.FindByID(ctx, submissionID)
+	if err != nil {
+		l.Err(err).Msg("failed to find submission")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, rpc_error.ErrKYCSubmissionNotFound
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	if submission.UserId == reviewer.ID {
+		l.Error().Msg("reviewer cannot review their own submission")
+		return nil, rpc_error.ErrPermissionDenied
+	}
+
+	before := proto.Clone(submission)
+
+	from := submission.Status
+	if !models.CanTransitionKYCStatus(from, to) {
+		l.Error().Stringer("from", from).Msg("invalid kyc status transition")
+		return nil, rpc_error.ErrInvalidKYCTransition
+	}
+
+	submission.Status = to
+	submission.ReviewerId = reviewer.ID
+	if notes != "" {
+		submission.ReviewNotes = notes
+	}
+
+	events := audit.Record(
+		ctx,
+		audit.ActionKYCReview,
+		audit.TargetKYCSubmission,
+		submission.ID,
+		before,
+		submission,
+		kycAuditIgnored...,
+	)
+
+	if err = s.rs.KYCRepo.UpdateStatus(ctx, submission, from, events...); err != nil {
+		l.Err(err).Msg("failed to update submission status")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, rpc_error.ErrInvalidKYCTransition
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	if to == pb.KYCSubmission_APPROVED {
+		// Approving copies the KYC data to the user, so it must not be served from the cache anymore.
+		if err = s.rs.UserRepo.Invalidate(ctx, submission.UserId); err != nil {
+			l.Err(err).Msg("failed to invalidate cached user")
+		}
+	}
+
+	l.Info().Stringer("from", from).Msg("kyc status updated successfully")
+	return submission, nil
+}
+
+func (s *service) StartKYCReview(
+	ctx context.Context,
+	req *pb.StartKYCReviewRequest,
+) (*pb.StartKYCReviewResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().
+		Str("action", "start kyc review").
+		Str("submission_id", req.SubmissionId).
+		Logger()
+
+	submission, err := s.transition(ctx, l, req.SubmissionId, pb.KYCSubmission_UNDER_REVIEW, "")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.StartKYCReviewResponse{Submission: submission}, nil
+}
+
+func (s *service) ReviewKYC(ctx context.Context, req *pb.ReviewKYCRequest) (*pb.ReviewKYCResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "review kyc").
+		Str("submission_id", req.SubmissionId).
+		Stringer("decision", req.Decision).
+		Logger()
+
+	submission, err := s.transition(ctx, l, req.SubmissionId, req.Decision, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReviewKYCResponse{Submission: submission}, nil
+}
+
+func NewService(l zerolog.Logger, rs repository.Store) pb.KYCServiceServer {
+	return &service{
+		l:  l.With().Str("service", "kyc").Logger(),
+		rs: rs,
+	}
+}
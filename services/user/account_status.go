@@ -0,0 +1,143 @@
+package user
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/audit"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/services/auth"
+	"context"
+	"errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// staffRoles are the roles allowed to manage the account status of other users.
+var staffRoles = []pb.User_Role{pb.User_STAFF, pb.User_ADMIN}
+
+// changeAccountStatus moves the user to the provided status if the transition is allowed, recording who made the
+// change and why.
+func (s *service) changeAccountStatus(
+	ctx context.Context,
+	userID string,
+	to pb.User_AccountStatus,
+	reason string,
+	expiresAt *timestamppb.Timestamp,
+) (*pb.User, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "change account status").
+		Str("user_id", userID).
+		Stringer("to", to).
+		Logger()
+
+	actor, err := auth.RequireRole(ctx, staffRoles...)
+	if err != nil {
+		l.Err(err).Msg("actor not allowed to change account status")
+		return nil, err
+	}
+
+	l = l.With().Str("actor_id", actor.ID).Logger()
+
+	if actor.ID == userID {
+		l.Error().Msg("actor cannot change their own account status")
+		return nil, rpc_error.ErrPermissionDenied
+	}
+
+	u, err := s.rs.UserRepo.FindByID(ctx, userID)
+	if err != nil {
+		l.Err(err).Msg("failed to find user")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, rpc_error.ErrResourceNotFound
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	if !models.CanTransitionAccountStatus(u.AccountStatus, to) {
+		l.Error().Stringer("from", u.AccountStatus).Msg("invalid account status transition")
+		return nil, rpc_error.ErrInvalidStatusTransition
+	}
+
+	change := &pb.AccountStatusChange{
+		UserId:    u.ID,
+		From:      u.AccountStatus,
+		To:        to,
+		Reason:    reason,
+		ActorId:   actor.ID,
+		ExpiresAt: expiresAt,
+	}
+
+	before := proto.Clone(u)
+
+	u.AccountStatus = to
+	u.StatusReason = reason
+	u.StatusExpiresAt = expiresAt
+
+	events := audit.Record(ctx, audit.ActionUserAccountStatusChange, audit.TargetUser, u.ID, before, u, "updated_at")
+
+	if err = s.rs.UserRepo.UpdateAccountStatus(ctx, change, events...); err != nil {
+		l.Err(err).Msg("failed to update account status")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, rpc_error.ErrInvalidStatusTransition
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	u.UpdatedAt = change.CreatedAt
+
+	l.Info().Str("change_id", change.ID).Msg("account status changed successfully")
+	return u, nil
+}
+
+func (s *service) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.SuspendUserResponse, error) {
+	u, err := s.changeAccountStatus(ctx, req.UserId, pb.User_SUSPENDED, req.Reason, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SuspendUserResponse{User: u}, nil
+}
+
+func (s *service) ReactivateUser(
+	ctx context.Context,
+	req *pb.ReactivateUserRequest,
+) (*pb.ReactivateUserResponse, error) {
+	u, err := s.changeAccountStatus(ctx, req.UserId, pb.User_ACTIVE, req.Reason, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReactivateUserResponse{User: u}, nil
+}
+
+func (s *service) DeactivateUser(
+	ctx context.Context,
+	req *pb.DeactivateUserRequest,
+) (*pb.DeactivateUserResponse, error) {
+	u, err := s.changeAccountStatus(ctx, req.UserId, pb.User_INACTIVE, req.Reason, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeactivateUserResponse{User: u}, nil
+}
+
+func (s *service) ListAccountStatusHistory(
+	ctx context.Context,
+	req *pb.ListAccountStatusHistoryRequest,
+) (*pb.ListAccountStatusHistoryResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().
+		Str("action", "list account status history").
+		Str("user_id", req.UserId).
+		Logger()
+
+	if _, err := auth.RequireRole(ctx, staffRoles...); err != nil {
+		l.Err(err).Msg("actor not allowed to list account status history")
+		return nil, err
+	}
+
+	changes, err := s.rs.UserRepo.ListAccountStatusHistory(ctx, req.UserId)
+	if err != nil {
+		l.Err(err).Msg("failed to list account status history")
+		return nil, rpc_error.ErrServerError
+	}
+
+	return &pb.ListAccountStatusHistoryResponse{Changes: changes}, nil
+}
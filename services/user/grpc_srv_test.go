@@ -5,6 +5,7 @@ import (
 	"bridge/internal/config"
 	"bridge/internal/config/vault"
 	"bridge/internal/factory"
+	"bridge/internal/idempotency"
 	"bridge/internal/logger"
 	"bridge/internal/repository"
 	"bridge/internal/rpc_error"
@@ -12,12 +13,17 @@ import (
 	"bridge/internal/testutils/docker_test"
 	"bridge/services/auth"
 	"context"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"log"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -85,6 +91,7 @@ func TestServer_Create(t *testing.T) {
 	asserts.NoError(err)
 
 	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
 	rs.UserRepo = userRepo
 
 	tests := []struct {
@@ -168,6 +175,61 @@ func TestServer_Create(t *testing.T) {
 	}
 }
 
+func TestServer_CreateIdempotent(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		admin   = factory.NewUser()
+		u       = factory.NewUser()
+	)
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testSvc.db, admin)
+	asserts.NoError(err)
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.IdempotencyRepo = repository.NewTestIdempotencyRepo(testSvc.db)
+	rs.UserRepo = userRepo
+
+	var (
+		srvAddr    = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		cc         = testutils.TestClientConnWithToken(t, srvAddr, admin.Email, factory.DefaultPassword)
+		userClient = pb.NewUserServiceClient(cc)
+		keyCtx     = metadata.AppendToOutgoingContext(ctx, idempotency.HeaderKey, uuid.NewString())
+		req        = &pb.CreateUserRequest{
+			Name:        u.Name,
+			Email:       u.Email,
+			PhoneNumber: u.PhoneNumber,
+		}
+	)
+
+	res, err := userClient.Create(keyCtx, req)
+	asserts.NoError(err)
+
+	// The retry gets the same user instead of AlreadyExists.
+	var header metadata.MD
+	retryRes, err := userClient.Create(keyCtx, req, grpc.Header(&header))
+	asserts.NoError(err)
+	asserts.Equal(res.User.ID, retryRes.User.ID)
+	asserts.Equal([]string{"true"}, header.Get(idempotency.HeaderReplayed))
+
+	req.Name = "Someone Else"
+	_, err = userClient.Create(keyCtx, req)
+	statusFromError, ok := status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrIdempotencyKeyReused.Error())
+
+	// Without a key the retry is a new request.
+	req.Name = u.Name
+	_, err = userClient.Create(ctx, req)
+	statusFromError, ok = status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrEmailExists.Error())
+}
+
 func TestServer_Update(t *testing.T) {
 	var (
 		asserts = assert.New(t)
@@ -179,6 +241,7 @@ func TestServer_Update(t *testing.T) {
 	asserts.NoError(err)
 
 	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
 	rs.UserRepo = userRepo
 
 	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
@@ -212,5 +275,283 @@ func TestServer_Update(t *testing.T) {
 	asserts.NotNil(res)
 	asserts.Equal(req.User.Name, res.User.GetName())
 	asserts.Equal(pb.User_ACTIVE, res.User.GetAccountStatus())
-	asserts.Equal(req.User.Meta.KycData.IdNumber, res.User.Meta.KycData.IdNumber)
+
+	// The KYC data can't be changed through an update, only by approving a KYC submission.
+	asserts.Equal(u.Meta.KycData.IdNumber, res.User.Meta.KycData.IdNumber)
+
+	got, err := userRepo.FindByID(ctx, u.ID)
+	asserts.NoError(err)
+	asserts.Equal(u.Meta.KycData.IdNumber, got.Meta.KycData.IdNumber)
+	asserts.Equal(u.Meta.KycData.KraPin, got.Meta.KycData.KraPin)
+}
+
+func TestServer_Update_Permissions(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+		other   = factory.NewUser()
+		staff   = factory.NewUser()
+	)
+
+	staff.Role = pb.User_STAFF
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testSvc.db, u, other, staff)
+	asserts.NoError(err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.LoginCodeRepo = repository.NewTestLoginCodeRepo(testSvc.db)
+	rs.UserRepo = userRepo
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr    = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		userClient = pb.NewUserServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, u.Email, factory.DefaultPassword),
+		)
+		staffClient = pb.NewUserServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, staff.Email, factory.DefaultPassword),
+		)
+	)
+
+	// Users can't change someone else's contacts.
+	other.Email = factory.NewUser().Email
+
+	res, err := userClient.Update(ctx, &pb.UpdateRequest{User: other})
+	asserts.Nil(res)
+
+	statusFromError, ok := status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrPermissionDenied.Error())
+
+	// The changed contacts must be verified again, the unchanged ones stay verified.
+	res, err = staffClient.Update(ctx, &pb.UpdateRequest{User: other})
+	asserts.NoError(err)
+	asserts.Equal(other.Email, res.User.Email)
+	asserts.False(res.User.EmailVerified)
+	asserts.True(res.User.PhoneNumberVerified)
+
+	u.PhoneNumber = factory.NewUser().PhoneNumber
+
+	res, err = userClient.Update(ctx, &pb.UpdateRequest{User: u})
+	asserts.NoError(err)
+	asserts.True(res.User.EmailVerified)
+	asserts.False(res.User.PhoneNumberVerified)
+}
+
+func TestServer_Update_AccountStatus(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+	)
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testSvc.db, u)
+	asserts.NoError(err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.UserRepo = userRepo
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr    = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		cc         = testutils.TestClientConnWithToken(t, srvAddr, u.Email, factory.DefaultPassword)
+		userClient = pb.NewUserServiceClient(cc)
+	)
+
+	u.AccountStatus = pb.User_INACTIVE
+
+	res, err := userClient.Update(ctx, &pb.UpdateRequest{User: u})
+	asserts.Nil(res)
+
+	statusFromError, ok := status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrAccountStatusChangeForbidden.Error())
+
+	// Updates which don't set the status keep the stored one.
+	u.AccountStatus = pb.User_UNKNOWN
+
+	res, err = userClient.Update(ctx, &pb.UpdateRequest{User: u})
+	asserts.NoError(err)
+	asserts.Equal(pb.User_ACTIVE, res.User.AccountStatus)
+}
+
+func TestServer_ChangeAccountStatus(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		admin   = factory.NewUser()
+		member  = factory.NewUser()
+	)
+
+	admin.Role = pb.User_ADMIN
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testSvc.db, admin, member)
+	asserts.NoError(err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.UserRepo = userRepo
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr     = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		adminClient = pb.NewUserServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, admin.Email, factory.DefaultPassword),
+		)
+		memberClient = pb.NewUserServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, member.Email, factory.DefaultPassword),
+		)
+	)
+
+	tests := []struct {
+		name       string
+		change     func(u *pb.User) (*pb.User, error)
+		wantStatus pb.User_AccountStatus
+		wantErr    error
+	}{
+		{
+			name: "staff can suspend an active user",
+			change: func(u *pb.User) (*pb.User, error) {
+				res, err := adminClient.SuspendUser(ctx, &pb.SuspendUserRequest{
+					UserId:    u.ID,
+					Reason:    "suspicious activity",
+					ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+				})
+				return res.GetUser(), err
+			},
+			wantStatus: pb.User_SUSPENDED,
+		},
+		{
+			name: "staff can deactivate an active user",
+			change: func(u *pb.User) (*pb.User, error) {
+				res, err := adminClient.DeactivateUser(ctx, &pb.DeactivateUserRequest{
+					UserId: u.ID,
+					Reason: "closed by user",
+				})
+				return res.GetUser(), err
+			},
+			wantStatus: pb.User_INACTIVE,
+		},
+		{
+			name: "staff can reactivate a suspended user",
+			change: func(u *pb.User) (*pb.User, error) {
+				_, err := adminClient.SuspendUser(ctx, &pb.SuspendUserRequest{UserId: u.ID, Reason: "investigation"})
+				asserts.NoError(err)
+
+				res, err := adminClient.ReactivateUser(ctx, &pb.ReactivateUserRequest{
+					UserId: u.ID,
+					Reason: "investigation closed",
+				})
+				return res.GetUser(), err
+			},
+			wantStatus: pb.User_ACTIVE,
+		},
+		{
+			name: "active user cannot be reactivated",
+			change: func(u *pb.User) (*pb.User, error) {
+				res, err := adminClient.ReactivateUser(ctx, &pb.ReactivateUserRequest{
+					UserId: u.ID,
+					Reason: "already active",
+				})
+				return res.GetUser(), err
+			},
+			wantErr: rpc_error.ErrInvalidStatusTransition,
+		},
+		{
+			name: "non staff users cannot change account status",
+			change: func(u *pb.User) (*pb.User, error) {
+				res, err := memberClient.SuspendUser(ctx, &pb.SuspendUserRequest{
+					UserId: u.ID,
+					Reason: "not allowed",
+				})
+				return res.GetUser(), err
+			},
+			wantErr: rpc_error.ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			u := factory.NewUser()
+
+			err = userRepo.Create(ctx, u)
+			asserts.NoError(err)
+
+			gotUser, err := tt.change(u)
+			if wantErr := tt.wantErr; wantErr != nil {
+				statusFromError, ok := status.FromError(err)
+				asserts.True(ok)
+				asserts.EqualError(statusFromError.Err(), wantErr.Error())
+				return
+			}
+
+			asserts.NoError(err)
+			asserts.Equal(tt.wantStatus, gotUser.AccountStatus)
+
+			history, err := adminClient.ListAccountStatusHistory(ctx, &pb.ListAccountStatusHistoryRequest{UserId: u.ID})
+			asserts.NoError(err)
+			asserts.NotEmpty(history.Changes)
+			asserts.Equal(tt.wantStatus, history.Changes[0].To)
+			asserts.Equal(admin.ID, history.Changes[0].ActorId)
+		})
+	}
+}
+
+func TestServer_SearchUsers(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		staff   = factory.NewUser()
+		member  = factory.NewUser()
+		word    = "barasa" + strings.ReplaceAll(uuid.NewString(), "-", "")[:6]
+		found   = factory.NewUser()
+	)
+
+	staff.Role = pb.User_STAFF
+	found.Name = "Wekesa " + word
+
+	userRepo, err := repository.NewTestUserRepo(ctx, testSvc.db, staff, member, found)
+	asserts.NoError(err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.UserRepo = userRepo
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr     = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		staffClient = pb.NewUserServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, staff.Email, factory.DefaultPassword),
+		)
+		memberClient = pb.NewUserServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, member.Email, factory.DefaultPassword),
+		)
+	)
+
+	res, err := staffClient.SearchUsers(ctx, &pb.SearchUsersRequest{Query: "wekesa " + word})
+	asserts.NoError(err)
+	asserts.Len(res.Hits, 1)
+	asserts.Equal(found.ID, res.Hits[0].User.ID)
+	asserts.Equal("<mark>Wekesa</mark> <mark>"+word+"</mark>", res.Hits[0].Highlights["name"])
+	asserts.Zero(res.NextOffset)
+
+	_, err = memberClient.SearchUsers(ctx, &pb.SearchUsersRequest{Query: word})
+	statusFromError, ok := status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrPermissionDenied.Error())
+
+	_, err = staffClient.SearchUsers(ctx, &pb.SearchUsersRequest{Query: "a"})
+	asserts.Equal(codes.InvalidArgument, status.Code(err))
 }
@@ -0,0 +1,134 @@
+package user
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/rpc_error"
+	"bridge/services/auth"
+	"context"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// defaultSearchLimit is the number of hits returned when the request doesn't set a limit.
+	defaultSearchLimit = 20
+	// maxSearchOffset is the largest offset accepted by the request validation.
+	maxSearchOffset = 1000
+)
+
+func (s *service) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "search users").Logger()
+
+	if _, err := auth.RequireRole(ctx, staffRoles...); err != nil {
+		l.Err(err).Msg("actor not allowed to search users")
+		return nil, err
+	}
+
+	search := models.UserSearch{
+		Query:  strings.TrimSpace(req.Query),
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+	}
+
+	if search.Limit == 0 {
+		search.Limit = defaultSearchLimit
+	}
+
+	hits, err := s.rs.UserRepo.Search(ctx, search)
+	if err != nil {
+		l.Err(err).Msg("failed to search users")
+		return nil, rpc_error.ErrServerError
+	}
+
+	h := newHighlighter(search.Query)
+	for _, hit := range hits {
+		hit.Highlights = h.highlightUser(hit.User)
+	}
+
+	res := &pb.SearchUsersResponse{Hits: hits}
+	if next := search.Offset + len(hits); len(hits) == search.Limit && next <= maxSearchOffset {
+		res.NextOffset = int32(next)
+	}
+
+	return res, nil
+}
+
+// highlighter marks the words of a search query in the fields of the users found.
+type highlighter struct {
+	re *regexp.Regexp
+}
+
+// newHighlighter returns a highlighter of the words of query, matched case-insensitively. The email punctuation
+// separates the words like in the search.
+func newHighlighter(query string) *highlighter {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return r == ' ' || r == '\t' || strings.ContainsRune("@._+-", r)
+	})
+
+	// The longest words are tried first so that they're marked whole when they contain a shorter one.
+	sort.Slice(words, func(i, j int) bool {
+		return len(words[i]) > len(words[j])
+	})
+
+	var patterns []string
+	for _, word := range words {
+		if len([]rune(word)) < 2 {
+			continue
+		}
+		patterns = append(patterns, regexp.QuoteMeta(word))
+	}
+
+	if len(patterns) == 0 {
+		return &highlighter{}
+	}
+	return &highlighter{re: regexp.MustCompile("(?i)" + strings.Join(patterns, "|"))}
+}
+
+// highlightUser returns the highlights of the fields of u containing a word of the query, by their JSON name.
+func (h *highlighter) highlightUser(u *pb.User) map[string]string {
+	fields := map[string]string{
+		"name":         u.Name,
+		"email":        u.Email,
+		"phone_number": u.PhoneNumber,
+	}
+
+	highlights := make(map[string]string)
+	for name, value := range fields {
+		if highlighted, ok := h.highlight(value); ok {
+			highlights[name] = highlighted
+		}
+	}
+	return highlights
+}
+
+// highlight returns value HTML escaped with the words of the query wrapped in <mark> tags, and whether any matched.
+func (h *highlighter) highlight(value string) (string, bool) {
+	if h.re == nil {
+		return "", false
+	}
+
+	matches := h.re.FindAllStringIndex(value, -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, m := range matches {
+		b.WriteString(html.EscapeString(value[last:m[0]]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(value[m[0]:m[1]]))
+		b.WriteString("</mark>")
+		last = m[1]
+	}
+
+	b.WriteString(html.EscapeString(value[last:]))
+	return b.String(), true
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"bridge/api/v1/pb"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHighlighter(t *testing.T) {
+	t.Parallel()
+
+	u := &pb.User{
+		Name:        "Akinyi <Odhiambo>",
+		Email:       "akinyi.odhiambo@example.com",
+		PhoneNumber: "+254712345678",
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  map[string]string
+	}{
+		{
+			name:  "marks the words case-insensitively",
+			query: "AKINYI odhi",
+			want: map[string]string{
+				"name":  "<mark>Akinyi</mark> &lt;<mark>Odhi</mark>ambo&gt;",
+				"email": "<mark>akinyi</mark>.<mark>odhi</mark>ambo@example.com",
+			},
+		},
+		{
+			name:  "splits the email on its punctuation",
+			query: "odhiambo@example",
+			want: map[string]string{
+				"name":  "Akinyi &lt;<mark>Odhiambo</mark>&gt;",
+				"email": "akinyi.<mark>odhiambo</mark>@<mark>example</mark>.com",
+			},
+		},
+		{
+			name:  "prefers the longest word",
+			query: "71 712345",
+			want:  map[string]string{"phone_number": "+254<mark>712345</mark>678"},
+		},
+		{
+			name:  "ignores single characters",
+			query: "a",
+			want:  map[string]string{},
+		},
+		{
+			name:  "quotes the regexp syntax",
+			query: ".* +254",
+			want:  map[string]string{"phone_number": "+<mark>254</mark>712345678"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, newHighlighter(tt.query).highlightUser(u))
+		})
+	}
+}
@@ -2,15 +2,22 @@ package user
 
 import (
 	"bridge/api/v1/pb"
+	"bridge/internal/audit"
+	"bridge/internal/logger"
+	"bridge/internal/models"
 	"bridge/internal/repository"
 	"bridge/internal/rpc_error"
 	"bridge/internal/utils"
+	"bridge/services/auth"
 	"context"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"time"
 )
 
+// userAuditIgnored are the user fields left out of the audit trail, the password is only changed by the auth service.
+var userAuditIgnored = []string{"password", "created_at", "updated_at", "deleted_at"}
+
 type service struct {
 	pb.UnimplementedUserServiceServer
 
@@ -20,7 +27,7 @@ type service struct {
 
 func (s *service) Create(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
 	var (
-		l = s.l.With().Str("action", "create user").Interface("req", req).Logger()
+		l = logger.FromContext(ctx, s.l).With().Str("action", "create user").Interface("req", req).Logger()
 		u = &pb.User{
 			Email:       req.Email,
 			PhoneNumber: req.PhoneNumber,
@@ -41,13 +48,18 @@ func (s *service) Create(ctx context.Context, req *pb.CreateUserRequest) (*pb.Cr
 
 	u.Name = req.Name
 	u.Password = passwordHash
+	u.AccountStatus = pb.User_PENDING_ACTIVE
 	u.Meta = req.Meta
+	u.EmailVerified = true
+	u.PhoneNumberVerified = true
 	u.CreatedAt = timestamppb.New(time.Now())
 	u.UpdatedAt = timestamppb.New(time.Now())
 
-	if err = s.rs.UserRepo.Create(ctx, u); err != nil {
+	events := audit.Record(ctx, audit.ActionUserCreate, audit.TargetUser, "", nil, u, userAuditIgnored...)
+
+	if err = s.rs.UserRepo.Create(ctx, u, events...); err != nil {
 		l.Err(err).Msg("failed to create user")
-		return nil, utils.ParseDBError(err)
+		return nil, repository.RPCError(err)
 	}
 
 	l.Info().Interface("user", u).Msg("user created successfully")
@@ -56,12 +68,75 @@ func (s *service) Create(ctx context.Context, req *pb.CreateUserRequest) (*pb.Cr
 
 func (s *service) Update(ctx context.Context, req *pb.UpdateRequest) (*pb.UpdateResponse, error) {
 	var (
-		l = s.l.With().Str("action", "update user").Interface("req", req).Logger()
+		l = logger.FromContext(ctx, s.l).With().Str("action", "update user").Interface("req", req).Logger()
 		u = req.User
 	)
-	if err := s.rs.UserRepo.Update(ctx, u); err != nil {
+
+	// The users update themselves, the staff update anyone.
+	actor, ok := auth.UserFromContext(ctx)
+	if !ok {
+		l.Error().Msg("missing authenticated user")
+		return nil, rpc_error.ErrUnauthenticated
+	}
+
+	if actor.ID != u.ID {
+		if _, err := auth.RequireRole(ctx, staffRoles...); err != nil {
+			l.Err(err).Str("actor_id", actor.ID).Msg("actor not allowed to update user")
+			return nil, err
+		}
+	}
+
+	existing, err := s.rs.UserRepo.FindByID(ctx, u.ID)
+	if err != nil {
+		l.Err(err).Msg("failed to find user")
+		return nil, repository.RPCError(err)
+	}
+
+	// Status changes go through the account status RPCs so that they are validated and recorded. The stored status
+	// is kept when the request doesn't set one.
+	if u.AccountStatus == pb.User_UNKNOWN {
+		u.AccountStatus = existing.AccountStatus
+	}
+
+	if u.AccountStatus != existing.AccountStatus {
+		l.Error().Stringer("account_status", existing.AccountStatus).Msg("account status change through update")
+		return nil, rpc_error.ErrAccountStatusChangeForbidden
+	}
+
+	u.Role = existing.Role
+	u.StatusReason = existing.StatusReason
+	u.StatusExpiresAt = existing.StatusExpiresAt
+
+	// The KYC data is only written once a submission is approved, through KYCService.ReviewKYC.
+	if u.Meta == nil {
+		u.Meta = &pb.UserMeta{}
+	}
+	u.Meta.KycData = existing.GetMeta().GetKycData()
+
+	// A changed email or phone number isn't used for the login codes until the user confirms it through
+	// AuthService.VerifyContact.
+	u.EmailVerified = existing.EmailVerified && u.Email == existing.Email
+	u.PhoneNumberVerified = existing.PhoneNumberVerified && u.PhoneNumber == existing.PhoneNumber
+
+	events := audit.Record(ctx, audit.ActionUserUpdate, audit.TargetUser, u.ID, existing, u, userAuditIgnored...)
+
+	if err = s.rs.UserRepo.Update(ctx, u, events...); err != nil {
 		l.Err(err).Msg("failed to update user")
-		return nil, utils.ParseDBError(err)
+		return nil, repository.RPCError(err)
+	}
+
+	// The codes sent to the previous contacts can no longer be used.
+	for channel, changed := range map[models.LoginChannel]bool{
+		models.LoginChannelEmail: u.Email != existing.Email,
+		models.LoginChannelSMS:   u.PhoneNumber != existing.PhoneNumber,
+	} {
+		if !changed {
+			continue
+		}
+
+		if err = s.rs.LoginCodeRepo.Invalidate(ctx, u.ID, channel); err != nil {
+			l.Err(err).Str("channel", string(channel)).Msg("failed to invalidate login codes")
+		}
 	}
 
 	l.Info().Interface("user", u).Msg("user updated successfully")
@@ -0,0 +1,223 @@
+package webhook_test
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/config"
+	"bridge/internal/config/vault"
+	"bridge/internal/factory"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/internal/testutils"
+	"bridge/internal/testutils/docker_test"
+	"bridge/services/auth"
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/status"
+	"log"
+	"os"
+	"testing"
+)
+
+type testService struct {
+	db *sqlx.DB
+}
+
+var testSvc = &testService{}
+
+func testMain(m *testing.M) int {
+	pgSrv, postgresCleanup, err := docker_test.NewPostgresSrv()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	defer func() {
+		if err = postgresCleanup(); err != nil {
+			log.Fatalln(err)
+		}
+	}()
+
+	vaultClient, vaultCleanup, err := docker_test.NewVaultClient()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	defer func() {
+		if err = vaultCleanup(); err != nil {
+			log.Fatalln(err)
+		}
+	}()
+
+	vaultProvider, err := vault.NewProvider(vaultClient.Address, vaultClient.Path, vaultClient.Token)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	appConfig := config.NewConfig(vaultProvider)
+	if err = appConfig.Load(context.Background(), ""); err != nil {
+		log.Fatalln(err)
+	}
+
+	testSvc.db = pgSrv.DB
+	return m.Run()
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(testMain(m))
+}
+
+func testStore(t *testing.T, users ...*pb.User) repository.Store {
+	t.Helper()
+
+	userRepo, err := repository.NewTestUserRepo(context.Background(), testSvc.db, users...)
+	assert.NoError(t, err)
+
+	rs := repository.NewStore()
+	rs.AuditRepo = repository.NewTestAuditRepo(testSvc.db)
+	rs.JobRepo = repository.NewTestJobRepo(testSvc.db)
+	rs.KYCRepo = repository.NewTestKYCRepo(testSvc.db)
+	rs.UserRepo = userRepo
+	rs.WebhookRepo = repository.NewTestWebhookRepo(testSvc.db)
+	return rs
+}
+
+func TestServer_WebhookSubscriptions(t *testing.T) {
+	var (
+		asserts = assert.New(t)
+		ctx     = context.Background()
+		u       = factory.NewUser()
+		admin   = factory.NewUser()
+	)
+
+	admin.Role = pb.User_ADMIN
+
+	rs := testStore(t, u, admin)
+
+	// Creating and updating subscriptions requires an approved KYC submission.
+	submission := factory.NewKYCSubmission(admin.ID)
+	asserts.NoError(rs.KYCRepo.Create(ctx, submission))
+
+	submission.Status = pb.KYCSubmission_APPROVED
+	asserts.NoError(rs.KYCRepo.UpdateStatus(ctx, submission, pb.KYCSubmission_SUBMITTED))
+
+	jwtManager, err := auth.NewPasetoToken(config.EnvKey.JwtKey)
+	asserts.NoError(err)
+
+	var (
+		srvAddr    = testutils.TestGRPCSrv(t, jwtManager, logger.TestLogger, rs)
+		userClient = pb.NewWebhookServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, u.Email, factory.DefaultPassword),
+		)
+		adminClient = pb.NewWebhookServiceClient(
+			testutils.TestClientConnWithToken(t, srvAddr, admin.Email, factory.DefaultPassword),
+		)
+		createReq = &pb.CreateWebhookSubscriptionRequest{
+			Url:        "https://example.com/webhooks",
+			EventTypes: []string{string(models.EventUserRegistered)},
+		}
+	)
+
+	_, err = userClient.CreateWebhookSubscription(ctx, createReq)
+	statusFromError, ok := status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrKYCRequired.Error())
+
+	_, err = userClient.ListWebhookSubscriptions(ctx, &pb.ListWebhookSubscriptionsRequest{})
+	statusFromError, ok = status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrPermissionDenied.Error())
+
+	// The events which aren't published by the API can't be subscribed to.
+	for _, eventType := range []string{"user.deleted", string(models.EventCategoryCreated)} {
+		_, err = adminClient.CreateWebhookSubscription(ctx, &pb.CreateWebhookSubscriptionRequest{
+			Url:        createReq.Url,
+			EventTypes: []string{eventType},
+		})
+		statusFromError, ok = status.FromError(err)
+		asserts.True(ok)
+		asserts.EqualError(statusFromError.Err(), rpc_error.ErrInvalidEventType.Error())
+	}
+
+	for _, url := range []string{"http://example.com/webhooks", "https://169.254.169.254/latest/meta-data"} {
+		_, err = adminClient.CreateWebhookSubscription(ctx, &pb.CreateWebhookSubscriptionRequest{
+			Url:        url,
+			EventTypes: createReq.EventTypes,
+		})
+		statusFromError, ok = status.FromError(err)
+		asserts.True(ok)
+		asserts.EqualError(statusFromError.Err(), rpc_error.ErrInvalidWebhookURL.Error(), url)
+	}
+
+	createRes, err := adminClient.CreateWebhookSubscription(ctx, createReq)
+	asserts.NoError(err)
+	asserts.Equal(pb.WebhookSubscription_ACTIVE, createRes.Subscription.Status)
+	asserts.Equal(admin.ID, createRes.Subscription.CreatedBy)
+	// The generated secret is only returned when the subscription is created.
+	asserts.NotEmpty(createRes.Subscription.Secret)
+
+	subscriptionID := createRes.Subscription.ID
+
+	listRes, err := adminClient.ListWebhookSubscriptions(ctx, &pb.ListWebhookSubscriptionsRequest{})
+	asserts.NoError(err)
+	for _, subscription := range listRes.Subscriptions {
+		asserts.Empty(subscription.Secret)
+	}
+
+	updateRes, err := adminClient.UpdateWebhookSubscription(ctx, &pb.UpdateWebhookSubscriptionRequest{
+		SubscriptionId: subscriptionID,
+		Url:            createReq.Url,
+		EventTypes:     []string{string(models.EventUserRegistered), string(models.EventUserUpdated)},
+		Status:         pb.WebhookSubscription_DISABLED,
+	})
+	asserts.NoError(err)
+	asserts.Equal(pb.WebhookSubscription_DISABLED, updateRes.Subscription.Status)
+	asserts.NotNil(updateRes.Subscription.DisabledAt)
+	asserts.Empty(updateRes.Subscription.Secret)
+
+	delivery := &pb.WebhookDelivery{
+		SubscriptionId: subscriptionID,
+		EventId:        u.ID,
+		EventType:      string(models.EventUserRegistered),
+		Payload:        `{"type":"user.registered"}`,
+	}
+	asserts.NoError(rs.WebhookRepo.CreateDelivery(ctx, delivery))
+
+	// Deliveries to a disabled subscription can't be replayed.
+	_, err = adminClient.ReplayDelivery(ctx, &pb.ReplayDeliveryRequest{DeliveryId: delivery.ID})
+	statusFromError, ok = status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrWebhookSubscriptionDisabled.Error())
+
+	_, err = adminClient.UpdateWebhookSubscription(ctx, &pb.UpdateWebhookSubscriptionRequest{
+		SubscriptionId: subscriptionID,
+		Url:            createReq.Url,
+		EventTypes:     createReq.EventTypes,
+		Status:         pb.WebhookSubscription_ACTIVE,
+	})
+	asserts.NoError(err)
+
+	replayRes, err := adminClient.ReplayDelivery(ctx, &pb.ReplayDeliveryRequest{DeliveryId: delivery.ID})
+	asserts.NoError(err)
+	asserts.Equal(delivery.ID, replayRes.Delivery.ReplayOf)
+	asserts.Equal(pb.WebhookDelivery_PENDING, replayRes.Delivery.Status)
+
+	deliveriesRes, err := adminClient.ListWebhookDeliveries(ctx, &pb.ListWebhookDeliveriesRequest{
+		SubscriptionId: subscriptionID,
+	})
+	asserts.NoError(err)
+	asserts.Len(deliveriesRes.Deliveries, 2)
+
+	_, err = adminClient.DeleteWebhookSubscription(ctx, &pb.DeleteWebhookSubscriptionRequest{
+		SubscriptionId: subscriptionID,
+	})
+	asserts.NoError(err)
+
+	_, err = adminClient.DeleteWebhookSubscription(ctx, &pb.DeleteWebhookSubscriptionRequest{
+		SubscriptionId: subscriptionID,
+	})
+	statusFromError, ok = status.FromError(err)
+	asserts.True(ok)
+	asserts.EqualError(statusFromError.Err(), rpc_error.ErrWebhookSubscriptionNotFound.Error())
+}
@@ -0,0 +1,323 @@
+package webhook
+
+import (
+	"bridge/api/v1/pb"
+	"bridge/internal/audit"
+	"bridge/internal/logger"
+	"bridge/internal/models"
+	"bridge/internal/repository"
+	"bridge/internal/rpc_error"
+	"bridge/internal/webhooks"
+	"bridge/internal/worker"
+	"bridge/services/auth"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/proto"
+)
+
+// webhookAuditIgnored are the subscription fields left out of the audit trail.
+var webhookAuditIgnored = []string{"consecutive_failures", "created_at", "updated_at", "disabled_at"}
+
+// defaultDeliveriesLimit is the number of deliveries listed when the request doesn't set a limit.
+const defaultDeliveriesLimit = 20
+
+type service struct {
+	pb.UnimplementedWebhookServiceServer
+
+	allowlist webhooks.Allowlist
+	enqueuer  worker.Enqueuer
+	l         zerolog.Logger
+	rs        repository.Store
+}
+
+// validateEventTypes checks that the subscription is to events which are published.
+func validateEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if !models.IsEventType(eventType) {
+			return rpc_error.ErrInvalidEventType
+		}
+	}
+	return nil
+}
+
+// validateURL checks that the subscription's url is one the webhooks can be delivered to.
+func (s *service) validateURL(rawURL string) error {
+	if err := webhooks.ValidateURL(rawURL, s.allowlist); err != nil {
+		return rpc_error.ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// subscriptionError maps the repository errors of a subscription lookup to an rpc error.
+func subscriptionError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return rpc_error.ErrWebhookSubscriptionNotFound
+	}
+	return rpc_error.ErrServerError
+}
+
+func (s *service) CreateWebhookSubscription(
+	ctx context.Context,
+	req *pb.CreateWebhookSubscriptionRequest,
+) (*pb.CreateWebhookSubscriptionResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "create webhook subscription").Logger()
+
+	admin, err := auth.RequireRole(ctx, pb.User_ADMIN)
+	if err != nil {
+		l.Err(err).Msg("user not allowed to manage webhooks")
+		return nil, err
+	}
+
+	if err = s.validateURL(req.Url); err != nil {
+		l.Err(err).Str("url", req.Url).Msg("invalid url")
+		return nil, err
+	}
+
+	if err = validateEventTypes(req.EventTypes); err != nil {
+		l.Err(err).Strs("event_types", req.EventTypes).Msg("invalid event types")
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = webhooks.NewSecret(); err != nil {
+			l.Err(err).Msg("failed to generate secret")
+			return nil, rpc_error.ErrServerError
+		}
+	}
+
+	subscription := &pb.WebhookSubscription{
+		Url:        req.Url,
+		EventTypes: req.EventTypes,
+		Secret:     secret,
+		Status:     pb.WebhookSubscription_ACTIVE,
+		CreatedBy:  admin.ID,
+	}
+
+	events := audit.Record(
+		ctx,
+		audit.ActionWebhookSubscriptionCreate,
+		audit.TargetWebhookSubscription,
+		"",
+		nil,
+		subscription,
+		webhookAuditIgnored...,
+	)
+
+	if err = s.rs.WebhookRepo.CreateSubscription(ctx, subscription, events...); err != nil {
+		l.Err(err).Msg("failed to create subscription")
+		return nil, rpc_error.ErrServerError
+	}
+
+	l.Info().Str("subscription_id", subscription.ID).Msg("webhook subscription created successfully")
+	return &pb.CreateWebhookSubscriptionResponse{Subscription: subscription}, nil
+}
+
+func (s *service) ListWebhookSubscriptions(
+	ctx context.Context,
+	_ *pb.ListWebhookSubscriptionsRequest,
+) (*pb.ListWebhookSubscriptionsResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "list webhook subscriptions").Logger()
+
+	if _, err := auth.RequireRole(ctx, pb.User_ADMIN); err != nil {
+		l.Err(err).Msg("user not allowed to manage webhooks")
+		return nil, err
+	}
+
+	subscriptions, err := s.rs.WebhookRepo.ListSubscriptions(ctx)
+	if err != nil {
+		l.Err(err).Msg("failed to list subscriptions")
+		return nil, rpc_error.ErrServerError
+	}
+
+	return &pb.ListWebhookSubscriptionsResponse{Subscriptions: subscriptions}, nil
+}
+
+func (s *service) UpdateWebhookSubscription(
+	ctx context.Context,
+	req *pb.UpdateWebhookSubscriptionRequest,
+) (*pb.UpdateWebhookSubscriptionResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "update webhook subscription").
+		Str("subscription_id", req.SubscriptionId).
+		Logger()
+
+	if _, err := auth.RequireRole(ctx, pb.User_ADMIN); err != nil {
+		l.Err(err).Msg("user not allowed to manage webhooks")
+		return nil, err
+	}
+
+	if err := s.validateURL(req.Url); err != nil {
+		l.Err(err).Str("url", req.Url).Msg("invalid url")
+		return nil, err
+	}
+
+	if err := validateEventTypes(req.EventTypes); err != nil {
+		l.Err(err).Strs("event_types", req.EventTypes).Msg("invalid event types")
+		return nil, err
+	}
+
+	subscription, err := s.rs.WebhookRepo.FindSubscriptionByID(ctx, req.SubscriptionId)
+	if err != nil {
+		l.Err(err).Msg("failed to find subscription")
+		return nil, subscriptionError(err)
+	}
+
+	from := subscription.Status
+	subscription.Secret = ""
+	before := proto.Clone(subscription)
+
+	subscription.Url = req.Url
+	subscription.EventTypes = req.EventTypes
+	subscription.Status = req.Status
+
+	events := audit.Record(
+		ctx,
+		audit.ActionWebhookSubscriptionUpdate,
+		audit.TargetWebhookSubscription,
+		subscription.ID,
+		before,
+		subscription,
+		webhookAuditIgnored...,
+	)
+
+	if err = s.rs.WebhookRepo.UpdateSubscription(ctx, subscription, events...); err != nil {
+		l.Err(err).Msg("failed to update subscription")
+		return nil, subscriptionError(err)
+	}
+
+	l.Info().Stringer("from", from).Stringer("to", subscription.Status).Msg("webhook subscription updated successfully")
+	return &pb.UpdateWebhookSubscriptionResponse{Subscription: subscription}, nil
+}
+
+func (s *service) DeleteWebhookSubscription(
+	ctx context.Context,
+	req *pb.DeleteWebhookSubscriptionRequest,
+) (*pb.DeleteWebhookSubscriptionResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "delete webhook subscription").
+		Str("subscription_id", req.SubscriptionId).
+		Logger()
+
+	if _, err := auth.RequireRole(ctx, pb.User_ADMIN); err != nil {
+		l.Err(err).Msg("user not allowed to manage webhooks")
+		return nil, err
+	}
+
+	events := audit.Record(
+		ctx,
+		audit.ActionWebhookSubscriptionDelete,
+		audit.TargetWebhookSubscription,
+		req.SubscriptionId,
+		nil,
+		nil,
+	)
+
+	if err := s.rs.WebhookRepo.DeleteSubscription(ctx, req.SubscriptionId, events...); err != nil {
+		l.Err(err).Msg("failed to delete subscription")
+		return nil, subscriptionError(err)
+	}
+
+	l.Info().Msg("webhook subscription deleted successfully")
+	return &pb.DeleteWebhookSubscriptionResponse{}, nil
+}
+
+func (s *service) ListWebhookDeliveries(
+	ctx context.Context,
+	req *pb.ListWebhookDeliveriesRequest,
+) (*pb.ListWebhookDeliveriesResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "list webhook deliveries").
+		Str("subscription_id", req.SubscriptionId).
+		Logger()
+
+	if _, err := auth.RequireRole(ctx, pb.User_ADMIN); err != nil {
+		l.Err(err).Msg("user not allowed to manage webhooks")
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultDeliveriesLimit
+	}
+
+	deliveries, err := s.rs.WebhookRepo.ListDeliveries(ctx, req.SubscriptionId, limit)
+	if err != nil {
+		l.Err(err).Msg("failed to list deliveries")
+		return nil, rpc_error.ErrServerError
+	}
+
+	return &pb.ListWebhookDeliveriesResponse{Deliveries: deliveries}, nil
+}
+
+func (s *service) ReplayDelivery(
+	ctx context.Context,
+	req *pb.ReplayDeliveryRequest,
+) (*pb.ReplayDeliveryResponse, error) {
+	l := logger.FromContext(ctx, s.l).With().Str("action", "replay delivery").
+		Str("delivery_id", req.DeliveryId).
+		Logger()
+
+	if _, err := auth.RequireRole(ctx, pb.User_ADMIN); err != nil {
+		l.Err(err).Msg("user not allowed to manage webhooks")
+		return nil, err
+	}
+
+	delivery, err := s.rs.WebhookRepo.FindDeliveryByID(ctx, req.DeliveryId)
+	if err != nil {
+		l.Err(err).Msg("failed to find delivery")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, rpc_error.ErrWebhookDeliveryNotFound
+		}
+		return nil, rpc_error.ErrServerError
+	}
+
+	subscription, err := s.rs.WebhookRepo.FindSubscriptionByID(ctx, delivery.SubscriptionId)
+	if err != nil {
+		l.Err(err).Msg("failed to find subscription")
+		return nil, subscriptionError(err)
+	}
+
+	if subscription.Status != pb.WebhookSubscription_ACTIVE {
+		l.Error().Str("subscription_id", subscription.ID).Msg("subscription is disabled")
+		return nil, rpc_error.ErrWebhookSubscriptionDisabled
+	}
+
+	// The replay is a new delivery of the same event, so it has its own attempts and entry in the delivery log.
+	replay := &pb.WebhookDelivery{
+		SubscriptionId: delivery.SubscriptionId,
+		EventId:        delivery.EventId,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		ReplayOf:       delivery.ID,
+	}
+
+	events := audit.Record(ctx, audit.ActionWebhookDeliveryReplay, audit.TargetWebhookDelivery, delivery.ID, nil, nil)
+
+	if err = s.rs.WebhookRepo.CreateDelivery(ctx, replay, events...); err != nil {
+		l.Err(err).Msg("failed to create delivery")
+		return nil, rpc_error.ErrServerError
+	}
+
+	if _, err = webhooks.EnqueueDelivery(ctx, s.enqueuer, replay.ID); err != nil {
+		l.Err(err).Str("replay_id", replay.ID).Msg("failed to enqueue delivery")
+		return nil, rpc_error.ErrServerError
+	}
+
+	l.Info().Str("replay_id", replay.ID).Msg("delivery replayed successfully")
+	return &pb.ReplayDeliveryResponse{Delivery: replay}, nil
+}
+
+// NewService creates the WebhookServiceServer. The subscriptions' urls must use https and a public address, unless
+// their host is in allowlist.
+func NewService(
+	l zerolog.Logger,
+	rs repository.Store,
+	enqueuer worker.Enqueuer,
+	allowlist webhooks.Allowlist,
+) pb.WebhookServiceServer {
+	return &service{
+		allowlist: allowlist,
+		enqueuer:  enqueuer,
+		l:         l.With().Str("service", "webhook").Logger(),
+		rs:        rs,
+	}
+}